@@ -0,0 +1,128 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:operation POST /api-keys CreateAPIKey
+// ---
+// summary: Issue an API key.
+// description: Issues an API key that acts for the signed in user, limited to its scopes and to what the role of the user allows. The key is only returned in this response; keep it secret and send it as "Authorization: ApiKey <key>". Only admins can issue keys with the admin scope, and managing keys with a key needs the admin scope.
+// parameters:
+// - name: key
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/APIKeyRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   201:
+//     $ref: '#/responses/APIKeyResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var request models.APIKeyRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	key, err := app.UserService.CreateAPIKey(request, principal(r))
+	if err != nil {
+		log.Println(appconst.Apikeyerror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusCreated, models.Response{Data: key, Status: http.StatusCreated, Message: appconst.Success})
+}
+
+// swagger:operation GET /api-keys ListAPIKeys
+// ---
+// summary: List API keys.
+// description: Lists the API keys of the signed in user, newest first, revoked ones included. Keys are shown by their prefix only.
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/APIKeyListResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := app.UserService.ListAPIKeys(principal(r))
+	if err != nil {
+		log.Println(appconst.Apikeylist, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: keys, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation DELETE /api-keys/{id} RevokeAPIKey
+// ---
+// summary: Revoke an API key.
+// description: Revokes an API key for good; requests made with it are rejected from then on. Users can revoke their own keys, admins any key.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/APIKeyResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	keyID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Println(appconst.Parsingapikey, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingapikey + err.Error()})
+		return
+	}
+
+	err = app.UserService.RevokeAPIKey(keyID, principal(r))
+	if err != nil {
+		log.Println(appconst.Apikeynotrevoked, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: models.APIKey{ID: keyID}, Status: http.StatusOK, Message: appconst.Success})
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/services/users"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	testCases := []struct {
+		name               string
+		actor              models.Principal
+		requestBody        string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "Issue",
+			actor:       editor,
+			requestBody: `{"name":"ci","scopes":["articles:read"]}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().CreateAPIKey(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Admin Scope",
+			actor:              editor,
+			requestBody:        `{"name":"ci","scopes":["admin"]}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    appconst.Adminscope,
+		},
+		{
+			name:               "Unknown Scope",
+			actor:              editor,
+			requestBody:        `{"name":"ci","scopes":["everything"]}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "scopes must be one or more of: articles:read, articles:write, admin",
+		},
+		{
+			name:               "Error Parsing JSON",
+			actor:              editor,
+			requestBody:        `{invalid-json}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.JSONparsing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				UserService: users.NewUserService(mockDB, nil),
+			}
+
+			w := httptest.NewRecorder()
+			app.CreateAPIKey(w, signedIn(httptest.NewRequest("POST", "/api-keys", bytes.NewBufferString(tc.requestBody)), tc.actor))
+
+			var response struct {
+				models.Response
+				Data models.APIKey `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+			if w.Code == http.StatusCreated {
+				// The key is returned once, its hash never
+				assert.True(t, strings.HasPrefix(response.Data.Key, response.Data.Prefix+"_"))
+				assert.NotContains(t, w.Body.String(), "key_hash")
+			}
+		})
+	}
+}
+
+func TestListAPIKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	mockDB.EXPECT().APIKeys(editor.UserID).Return([]models.APIKey{{ID: 7, UserID: editor.UserID, Name: "ci", Prefix: "ak_0123abcd", KeyHash: "hash"}}, nil)
+
+	app := &Controller{
+		UserService: users.NewUserService(mockDB, nil),
+	}
+
+	w := httptest.NewRecorder()
+	app.ListAPIKeys(w, signedIn(httptest.NewRequest("GET", "/api-keys", nil), editor))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"prefix":"ak_0123abcd"`)
+	assert.NotContains(t, w.Body.String(), "hash")
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name: "Own Key",
+			id:   "7",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneAPIKey(7).Return(&models.APIKey{ID: 7, UserID: editor.UserID}, nil)
+				db.EXPECT().RevokeAPIKey(7).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name: "Key Of Another User",
+			id:   "8",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneAPIKey(8).Return(&models.APIKey{ID: 8, UserID: 1}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    appconst.Noapikey,
+		},
+		{
+			name: "Unknown Key",
+			id:   "9",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneAPIKey(9).Return(nil, apperrors.NotFound(appconst.Noapikey, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    appconst.Noapikey,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    `Error parsing API key ID: strconv.Atoi: parsing "abc": invalid syntax`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				UserService: users.NewUserService(mockDB, nil),
+			}
+
+			w := httptest.NewRecorder()
+			app.RevokeAPIKey(w, signedIn(newArticleRequest("DELETE", tc.id, ""), editor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+)
+
+// swagger:operation POST /auth/register Register
+// ---
+// summary: Register a user.
+// description: Creates a user account. The password is stored as an argon2id hash.
+// parameters:
+// - name: registration
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/Registration'
+// responses:
+//   201:
+//     $ref: '#/responses/UserResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) Register(w http.ResponseWriter, r *http.Request) {
+	var registration models.Registration
+	err := utility.ReadJSON(w, r, &registration)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	user, err := app.UserService.Register(registration)
+	if err != nil {
+		log.Println(appconst.Registererror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusCreated, models.Response{Data: user, Status: http.StatusCreated, Message: appconst.Success})
+}
+
+// swagger:operation POST /auth/login Login
+// ---
+// summary: Sign in.
+// description: Checks a username and password and starts a session. Send the access token as "Authorization: Bearer <token>"; when it expires, exchange the refresh token for a new session.
+// parameters:
+// - name: credentials
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/Credentials'
+// responses:
+//   200:
+//     $ref: '#/responses/SessionResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) Login(w http.ResponseWriter, r *http.Request) {
+	var credentials models.Credentials
+	err := utility.ReadJSON(w, r, &credentials)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	session, err := app.UserService.Login(credentials)
+	if err != nil {
+		log.Println(appconst.Loginerror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: session, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation POST /auth/refresh Refresh
+// ---
+// summary: Refresh a session.
+// description: Exchanges a refresh token for a new access token and refresh token. Each refresh token works once; using one again signs out every session it was rotated into.
+// parameters:
+// - name: refresh
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/RefreshRequest'
+// responses:
+//   200:
+//     $ref: '#/responses/SessionResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) Refresh(w http.ResponseWriter, r *http.Request) {
+	var request models.RefreshRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	session, err := app.UserService.Refresh(request.RefreshToken)
+	if err != nil {
+		log.Println(appconst.Refresherror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: session, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation POST /auth/logout Logout
+// ---
+// summary: Sign out.
+// description: Revokes a refresh token and every token it was rotated from or into. Access tokens already issued stay valid until they expire.
+// parameters:
+// - name: refresh
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/RefreshRequest'
+// responses:
+//   200:
+//     $ref: '#/responses/SuccessResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) Logout(w http.ResponseWriter, r *http.Request) {
+	var request models.RefreshRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	if err := app.UserService.Logout(request.RefreshToken); err != nil {
+		log.Println(appconst.Logouterror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: nil, Status: http.StatusOK, Message: appconst.Success})
+}
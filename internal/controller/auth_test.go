@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/password"
+	"backend/services/users"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthHandlers(t *testing.T) {
+	tokens, err := auth.NewTokens(auth.Config{Algorithm: auth.HS256, Secret: []byte("test secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	assert.NoError(t, err)
+	hash, _ := password.Hash("analytical engine")
+
+	testCases := []struct {
+		name               string
+		handler            func(app *Controller) http.HandlerFunc
+		requestBody        string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "Register",
+			handler:     func(app *Controller) http.HandlerFunc { return app.Register },
+			requestBody: `{"username":"ada","email":"ada@example.com","password":"analytical engine"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().CreateUser(gomock.Any()).Return(1, nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:        "Register Taken Username",
+			handler:     func(app *Controller) http.HandlerFunc { return app.Register },
+			requestBody: `{"username":"ada","email":"ada@example.com","password":"analytical engine"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().CreateUser(gomock.Any()).Return(0, apperrors.Conflict(appconst.Usertaken, nil))
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    appconst.Usertaken,
+		},
+		{
+			name:               "Register Error Parsing JSON",
+			handler:            func(app *Controller) http.HandlerFunc { return app.Register },
+			requestBody:        `{invalid-json}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.JSONparsing,
+		},
+		{
+			name:        "Login",
+			handler:     func(app *Controller) http.HandlerFunc { return app.Login },
+			requestBody: `{"username":"ada","password":"analytical engine"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().UserByUsername("ada").Return(&models.User{ID: 1, Username: "ada", PasswordHash: hash}, nil)
+				db.EXPECT().CreateRefreshToken(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:        "Login Wrong Password",
+			handler:     func(app *Controller) http.HandlerFunc { return app.Login },
+			requestBody: `{"username":"ada","password":"difference engine"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().UserByUsername("ada").Return(&models.User{ID: 1, Username: "ada", PasswordHash: hash}, nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    appconst.Badcredentials,
+		},
+		{
+			name:        "Refresh Used Token",
+			handler:     func(app *Controller) http.HandlerFunc { return app.Refresh },
+			requestBody: `{"refresh_token":"used"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().UseRefreshToken(auth.HashRefreshToken("used")).Return(nil, apperrors.NotFound(appconst.Invalidrefresh, sql.ErrNoRows))
+				db.EXPECT().RevokeRefreshTokens(auth.HashRefreshToken("used")).Return(nil)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    appconst.Invalidrefresh,
+		},
+		{
+			name:        "Logout",
+			handler:     func(app *Controller) http.HandlerFunc { return app.Logout },
+			requestBody: `{"refresh_token":"token"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().RevokeRefreshTokens(auth.HashRefreshToken("token")).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				UserService: users.NewUserService(mockDB, tokens),
+			}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, httptest.NewRequest("POST", "/auth", bytes.NewBufferString(tc.requestBody)))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+			// Password hashes never leave the server
+			assert.NotContains(t, w.Body.String(), "argon2id")
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:operation GET /articles/{id}/comments ArticleComments
+// ---
+// summary: List the comments on an article.
+// description: Lists the threads on an article, oldest first, paged with limit and offset. Pages count threads, and every thread comes with all of its replies. The tree view nests replies under the comment they answer; the flat view lists each thread depth first with the depth of every comment. Deleted comments with replies are kept in their thread without their body and author.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: view
+//   in: query
+//   type: string
+//   enum: [tree, flat]
+//   default: tree
+// - name: limit
+//   in: query
+//   type: integer
+// - name: offset
+//   in: query
+//   type: integer
+// responses:
+//   200:
+//     $ref: '#/responses/CommentListResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ArticleComments(w http.ResponseWriter, r *http.Request) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Offsetonly, nil)
+	}
+	if err != nil {
+		log.Println(appconst.Commentlist, err)
+		writeError(w, err)
+		return
+	}
+
+	page, err := app.CommentService.GetComments(articleID, r.URL.Query().Get("view"), params, principal(r))
+	if err != nil {
+		log.Println(appconst.Commentlist, err)
+		writeError(w, err)
+		return
+	}
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Comments
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
+
+// swagger:operation POST /articles/{id}/comments PostComment
+// ---
+// summary: Comment on an article.
+// description: Comments on an article, or replies to a comment on it when parent_id is set. Signed in users can comment on every article they can see.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: comment
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/CommentRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   201:
+//     $ref: '#/responses/CommentResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) PostComment(w http.ResponseWriter, r *http.Request) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	var request models.CommentRequest
+	err = utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	comment, err := app.CommentService.PostComment(articleID, request, principal(r))
+	if err != nil {
+		log.Println(appconst.Commenterror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusCreated, models.Response{Data: comment, Status: http.StatusCreated, Message: appconst.Success})
+}
+
+// swagger:operation PUT /articles/{id}/comments/{comment} EditComment
+// ---
+// summary: Edit a comment.
+// description: Replaces the body of a comment. Only the author of a comment can edit it.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: comment
+//   in: path
+//   required: true
+//   type: integer
+// - name: body
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/CommentRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CommentResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) EditComment(w http.ResponseWriter, r *http.Request) {
+	articleID, commentID, ok := commentParams(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.CommentRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	comment, err := app.CommentService.EditComment(articleID, commentID, request, principal(r))
+	if err != nil {
+		log.Println(appconst.Commentnotsaved, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: comment, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation DELETE /articles/{id}/comments/{comment} DeleteComment
+// ---
+// summary: Delete a comment.
+// description: Deletes a comment. Authors can delete their own comments, editors any comment. A comment with replies stays in its thread, marked deleted and without its body.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: comment
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CommentResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	articleID, commentID, ok := commentParams(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.CommentService.DeleteComment(articleID, commentID, principal(r))
+	if err != nil {
+		log.Println(appconst.Commentnotdeleted, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: models.Comment{ID: commentID, ArticleID: articleID}, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// commentParams reads the article ID and comment ID from the URL, writing
+// a bad request response when either is not a number.
+func commentParams(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.Atoi(chi.URLParam(r, "comment"))
+	if err != nil {
+		log.Println(appconst.Parsingcomment, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingcomment + err.Error()})
+		return 0, 0, false
+	}
+
+	return articleID, commentID, true
+}
@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/services/comments"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newCommentRequest builds a request to target carrying the chi "id" and,
+// unless it is empty, "comment" URL parameters.
+func newCommentRequest(method, target, id, comment, body string) *http.Request {
+	r := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	if comment != "" {
+		rctx.URLParams.Add("comment", comment)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+var publishedArticle = &models.Article{ID: 1, AuthorID: 1, Status: models.StatusPublished}
+
+// commentAuthor is the account behind editor, registered long enough ago
+// not to count as new.
+var commentAuthor = &models.User{ID: editor.UserID, Username: editor.Username, CreatedAt: &time.Time{}}
+
+func TestArticleComments(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		target             string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:   "Tree",
+			id:     "1",
+			target: "/articles/1/comments?limit=1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().ArticleComments(1, gomock.Any()).Return(&models.CommentPage{Comments: []models.Comment{{ID: 1, ArticleID: 1, Body: "First"}}, PageInfo: models.PageInfo{Total: 2, HasNext: true}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Unknown View",
+			id:                 "1",
+			target:             "/articles/1/comments?view=nested",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "view must be one of: tree, flat",
+		},
+		{
+			name:   "Unknown Article",
+			id:     "9",
+			target: "/articles/9/comments",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(9).Return(nil, apperrors.NotFound(appconst.NoArticleforid, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    appconst.NoArticleforid,
+		},
+		{
+			name:               "Cursor",
+			id:                 "1",
+			target:             "/articles/1/comments?cursor=abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Invalidcursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				CommentService: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+			}
+
+			w := httptest.NewRecorder()
+			app.ArticleComments(w, newCommentRequest("GET", tc.target, tc.id, "", ""))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+			if w.Code == http.StatusOK {
+				assert.Equal(t, 2, response.Pagination.Total)
+				assert.NotEmpty(t, w.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func TestPostComment(t *testing.T) {
+	testCases := []struct {
+		name               string
+		actor              models.Principal
+		requestBody        string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "Comment",
+			actor:       editor,
+			requestBody: `{"body":"Nice post"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().OneUser(editor.UserID).Return(commentAuthor, nil)
+				db.EXPECT().CreateComment(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:        "Reply To Unknown Comment",
+			actor:       editor,
+			requestBody: `{"body":"Nice post","parent_id":9}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().OneComment(9).Return(nil, apperrors.NotFound(appconst.Nocomment, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Noparent,
+		},
+		{
+			name:               "Empty Body",
+			actor:              editor,
+			requestBody:        `{"body":""}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Commentbody,
+		},
+		{
+			name:               "Error Parsing JSON",
+			actor:              editor,
+			requestBody:        `{invalid-json}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.JSONparsing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				CommentService: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+			}
+
+			w := httptest.NewRecorder()
+			app.PostComment(w, signedIn(newCommentRequest("POST", "/articles/1/comments", "1", "", tc.requestBody), tc.actor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
+
+func TestEditComment(t *testing.T) {
+	testCases := []struct {
+		name               string
+		comment            string
+		actor              models.Principal
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:    "Own Comment",
+			comment: "4",
+			actor:   editor,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 1, AuthorID: editor.UserID, Status: models.CommentApproved}, nil)
+				db.EXPECT().OneUser(editor.UserID).Return(commentAuthor, nil)
+				db.EXPECT().UpdateComment(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:    "Comment Of Another User",
+			comment: "4",
+			actor:   admin,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 1, AuthorID: editor.UserID}, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    appconst.Forbidden,
+		},
+		{
+			name:               "Invalid ID",
+			comment:            "abc",
+			actor:              editor,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    `Error parsing comment ID: strconv.Atoi: parsing "abc": invalid syntax`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				CommentService: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+			}
+
+			w := httptest.NewRecorder()
+			app.EditComment(w, signedIn(newCommentRequest("PUT", "/articles/1/comments/"+tc.comment, "1", tc.comment, `{"body":"Edited"}`), tc.actor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	testCases := []struct {
+		name               string
+		actor              models.Principal
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:  "Moderator",
+			actor: editor,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 1, AuthorID: 9}, nil)
+				db.EXPECT().DeleteComment(4).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:  "Comment On Another Article",
+			actor: editor,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(publishedArticle, nil)
+				db.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 2, AuthorID: editor.UserID}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    appconst.Nocomment,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				CommentService: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+			}
+
+			w := httptest.NewRecorder()
+			app.DeleteComment(w, signedIn(newCommentRequest("DELETE", "/articles/1/comments/4", "1", "4", ""), tc.actor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
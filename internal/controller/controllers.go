@@ -1,14 +1,23 @@
 package controller
 
 import (
+	"backend/internal/graph"
 	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
 	"backend/pkg/models"
 	"backend/pkg/repository/dbrepo"
 	"backend/pkg/utility"
 	services "backend/services/articles"
+	"backend/services/comments"
+	"backend/services/media"
+	"backend/services/taxonomy"
+	"backend/services/users"
 	"database/sql"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
@@ -19,11 +28,60 @@ Do not remove the following interfaces, as they are used for mock data generatio
 Command to generate mock data: make mocks
 */
 type DBInterface interface {
+	CreateUser(user *models.User) (int, error)
+	OneUser(id int) (*models.User, error)
+	UserByUsername(username string) (*models.User, error)
+	UsersByIDs(ids []int) ([]models.User, error)
+	AllUsers(params models.ListParams) (*models.UserPage, error)
+	SetUserRole(id int, role string) (*models.User, error)
+	CreateRefreshToken(token *models.RefreshToken) error
+	UseRefreshToken(hash string) (*models.RefreshToken, error)
+	RevokeRefreshTokens(hash string) error
+	CreateAPIKey(key *models.APIKey) error
+	APIKeys(userID int) ([]models.APIKey, error)
+	OneAPIKey(id int) (*models.APIKey, error)
+	RevokeAPIKey(id int) error
+	UseAPIKey(hash string) (*models.APIKey, error)
 	Connection() *sql.DB
-	CreateTable()
-	AllArticles() ([]models.Article, error)
+	AllArticles(params models.ListParams) (*models.ArticlePage, error)
 	CreateArticle(article *models.Article) (int, error)
 	OneArticle(id int) (*models.Article, error)
+	ArticleBySlug(slug string) (*models.Article, error)
+	UpdateArticle(article *models.Article) error
+	SetArticleStatus(article *models.Article, from string) error
+	DeleteArticle(id int) error
+	SearchArticles(query string, params models.ListParams) (*models.SearchPage, error)
+	ScheduledArticles(params models.ListParams) (*models.ArticlePage, error)
+	PublishDueArticles(limit int, publishedBy string) ([]models.Article, error)
+	LatestArticles(filter models.ArticleFilter, limit int) ([]models.Article, error)
+	SitemapPages(size int) ([]models.SitemapPage, error)
+	SitemapEntries(page, size int) ([]models.SitemapEntry, error)
+	ArticleRevisions(articleID int, params models.ListParams) (*models.RevisionPage, error)
+	ArticleRevision(articleID, revision int) (*models.Revision, error)
+	CreateComment(comment *models.Comment) error
+	OneComment(id int) (*models.Comment, error)
+	ArticleComments(articleID int, params models.ListParams) (*models.CommentPage, error)
+	CommentsByArticles(articleIDs []int, limit int) ([]models.Comment, error)
+	UpdateComment(comment *models.Comment) error
+	DeleteComment(id int) error
+	CommentQueue(status string, params models.ListParams) (*models.CommentPage, error)
+	SetCommentStatus(id int, status, moderatedBy string) (*models.Comment, error)
+	Tags(params models.ListParams) (*models.TagPage, error)
+	OneTag(slug string) (*models.Tag, error)
+	RenameTag(tag *models.Tag) error
+	MergeTags(from, into int) error
+	Categories() ([]models.Category, error)
+	OneCategory(slug string) (*models.Category, error)
+	CreateCategory(category *models.Category) error
+	UpdateCategory(category *models.Category) error
+	DeleteCategory(id int) error
+	CreateMedia(media *models.Media) error
+	OneMedia(id int) (*models.Media, error)
+	MediaByChecksum(checksum string) (*models.Media, error)
+	ClaimPendingMedia(limit int) ([]models.Media, error)
+	SaveMediaVariants(media *models.Media, variants []models.MediaVariant) error
+	SetMediaStatus(id int, status string) error
+	MediaVariant(mediaID, width int) (*models.MediaVariant, error)
 }
 
 type UtilityInterface interface {
@@ -31,16 +89,76 @@ type UtilityInterface interface {
 	ReadJSON(w http.ResponseWriter, r *http.Request, data interface{}) error
 }
 type Controller struct {
-	DSN            string
-	DB             dbrepo.DatabaseRepo
-	Utility        UtilityInterface
-	ArticleService *services.ArticleService
+	DSN             string
+	DB              dbrepo.DatabaseRepo
+	Utility         UtilityInterface
+	ArticleService  *services.ArticleService
+	UserService     *users.UserService
+	CommentService  *comments.CommentService
+	TaxonomyService *taxonomy.TaxonomyService
+	MediaService    *media.MediaService
+	// Site is linked to from feeds and sitemaps
+	Site models.Site
+	// Robots is the robots.txt served to crawlers
+	Robots string
+	// Graph is the schema served at /graphql
+	Graph *graph.Schema
 }
 type Handler interface {
 	HealthCheck(w http.ResponseWriter, r *http.Request)
 	AllArticle(w http.ResponseWriter, r *http.Request)
 	GetArticle(w http.ResponseWriter, r *http.Request)
+	GetArticleBySlug(w http.ResponseWriter, r *http.Request)
 	InsertArticle(w http.ResponseWriter, r *http.Request)
+	UpdateArticle(w http.ResponseWriter, r *http.Request)
+	PatchArticle(w http.ResponseWriter, r *http.Request)
+	DeleteArticle(w http.ResponseWriter, r *http.Request)
+	SearchArticles(w http.ResponseWriter, r *http.Request)
+	SubmitArticle(w http.ResponseWriter, r *http.Request)
+	PublishArticle(w http.ResponseWriter, r *http.Request)
+	UnpublishArticle(w http.ResponseWriter, r *http.Request)
+	ArchiveArticle(w http.ResponseWriter, r *http.Request)
+	ScheduledArticles(w http.ResponseWriter, r *http.Request)
+	ArticleRevisions(w http.ResponseWriter, r *http.Request)
+	GetRevision(w http.ResponseWriter, r *http.Request)
+	DiffRevisions(w http.ResponseWriter, r *http.Request)
+	RestoreRevision(w http.ResponseWriter, r *http.Request)
+	Register(w http.ResponseWriter, r *http.Request)
+	Login(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	Logout(w http.ResponseWriter, r *http.Request)
+	ListUsers(w http.ResponseWriter, r *http.Request)
+	SetUserRole(w http.ResponseWriter, r *http.Request)
+	CreateAPIKey(w http.ResponseWriter, r *http.Request)
+	ListAPIKeys(w http.ResponseWriter, r *http.Request)
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request)
+	ArticleComments(w http.ResponseWriter, r *http.Request)
+	PostComment(w http.ResponseWriter, r *http.Request)
+	EditComment(w http.ResponseWriter, r *http.Request)
+	DeleteComment(w http.ResponseWriter, r *http.Request)
+	CommentQueue(w http.ResponseWriter, r *http.Request)
+	ApproveComment(w http.ResponseWriter, r *http.Request)
+	RejectComment(w http.ResponseWriter, r *http.Request)
+	SpamComment(w http.ResponseWriter, r *http.Request)
+	ListTags(w http.ResponseWriter, r *http.Request)
+	TagArticles(w http.ResponseWriter, r *http.Request)
+	RenameTag(w http.ResponseWriter, r *http.Request)
+	MergeTag(w http.ResponseWriter, r *http.Request)
+	ListCategories(w http.ResponseWriter, r *http.Request)
+	CategoryArticles(w http.ResponseWriter, r *http.Request)
+	CreateCategory(w http.ResponseWriter, r *http.Request)
+	UpdateCategory(w http.ResponseWriter, r *http.Request)
+	DeleteCategory(w http.ResponseWriter, r *http.Request)
+	UploadMedia(w http.ResponseWriter, r *http.Request)
+	GetMedia(w http.ResponseWriter, r *http.Request)
+	GetMediaVariant(w http.ResponseWriter, r *http.Request)
+	Feed(w http.ResponseWriter, r *http.Request)
+	AuthorFeed(w http.ResponseWriter, r *http.Request)
+	TagFeed(w http.ResponseWriter, r *http.Request)
+	Sitemap(w http.ResponseWriter, r *http.Request)
+	SitemapPage(w http.ResponseWriter, r *http.Request)
+	RobotsTxt(w http.ResponseWriter, r *http.Request)
+	GraphQL(w http.ResponseWriter, r *http.Request)
 }
 
 // HealthCheck performs a basic health check of the service.
@@ -64,35 +182,58 @@ func (app *Controller) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	utility.WriteJSON(w, http.StatusOK, response)
 }
 
-// AllArticle retrieves a list of articles.
+// AllArticle retrieves a page of articles.
 //
 // swagger:route GET /articles allArticle
 //
-// Retrieve a list of articles.
+// Retrieve a page of articles. Pages are addressed with limit and either
+// offset or the opaque cursor returned in the next and prev links. The
+// listing can be sorted and filtered; unknown parameters, sort fields and
+// statuses are rejected.
 //
 // Responses:
 //
 //	200: ArticleListResponse
+//	400: ErrorResponse
 //	500: ErrorResponse
 func (app *Controller) AllArticle(w http.ResponseWriter, r *http.Request) {
-	// Retrieve the list of articles from the database
-	articles, err := app.ArticleService.GetAllArticles()
+	// Read the requested page, sort and filters from the query string
+	params, err := articleListParams(r)
+	var format string
+	if err == nil {
+		format, err = articleFormat(r)
+	}
+	if err != nil {
+		log.Println(appconst.Errorconst, err)
+		writeError(w, err)
+		return
+	}
+	params.Viewer = principal(r)
+
+	// Retrieve the page of articles from the database
+	page, err := app.ArticleService.GetAllArticles(params)
 	if err != nil {
 		// Handle the error
 		log.Println(appconst.Errorconst, err)
-		utility.WriteJSON(w, http.StatusInternalServerError, models.Response{Data: nil, Status: http.StatusInternalServerError, Message: appconst.Errorconst + err.Error()})
+		writeError(w, err)
 		return
 	}
+	formatArticles(page.Articles, format)
+
 	// Create the response struct
 	var response models.Response
 
 	response.Status = http.StatusOK
 	response.Message = appconst.Success
 	// Set the response data as a slice of articles
-	response.Data = articles
+	response.Data = page.Articles
+
+	// Describe the neighbouring pages in the body and the Link header
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, r.URL.Query().Has("offset"))
 
 	// Set the response headers and write the JSON response
-	utility.WriteJSON(w, http.StatusOK, response)
+	utility.WriteJSON(w, http.StatusOK, response, links)
 }
 
 // swagger:route GET /articles/{id} idParameter
@@ -104,7 +245,10 @@ func (app *Controller) AllArticle(w http.ResponseWriter, r *http.Request) {
 // Responses:
 //
 //	200: ArticleListResponse
+//	400: ErrorResponse
+//	404: ErrorResponse
 //	500: ErrorResponse
+//	503: ErrorResponse
 
 func (app *Controller) GetArticle(w http.ResponseWriter, r *http.Request) {
 	// Get the article ID from the URL parameter
@@ -115,14 +259,76 @@ func (app *Controller) GetArticle(w http.ResponseWriter, r *http.Request) {
 		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
 		return
 	}
+	format, err := articleFormat(r)
+	if err != nil {
+		log.Println(appconst.Retrivearticle, err)
+		writeError(w, err)
+		return
+	}
 	// Retrieve the article from the service
-	article, err := app.ArticleService.GetArticleByID(articleID)
+	article, err := app.ArticleService.GetArticleByID(articleID, principal(r))
 	if err != nil {
 		// Handle the error
 		log.Println(appconst.Retrivearticle, err)
-		utility.WriteJSON(w, http.StatusInternalServerError, models.Response{Data: nil, Status: http.StatusInternalServerError, Message: appconst.Retrivearticle + err.Error()})
+		writeError(w, err)
 		return
 	}
+	formatArticle(article, format)
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = article
+	utility.WriteJSON(w, http.StatusOK, response)
+}
+
+// swagger:operation GET /articles/by-slug/{slug} GetArticleBySlug
+// ---
+// summary: Retrieve an article by its slug.
+// description: Returns the article with the slug. An old slug the article had before its title changed redirects to the current one.
+// parameters:
+// - name: slug
+//   in: path
+//   type: string
+//   required: true
+// - name: format
+//   in: query
+//   type: string
+//   description: 'Format of the content: markdown, the default, or html rendered from it'
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   301:
+//     description: The slug is an old one; Location holds the current one.
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	format, err := articleFormat(r)
+	if err != nil {
+		log.Println(appconst.Retrivearticle, err)
+		writeError(w, err)
+		return
+	}
+	article, err := app.ArticleService.GetArticleBySlug(slug, principal(r))
+	if err != nil {
+		log.Println(appconst.Retrivearticle, err)
+		writeError(w, err)
+		return
+	}
+
+	// An old slug moves permanently to the current one, keeping the query
+	if article.Slug != slug {
+		target := url.URL{Path: "/articles/by-slug/" + article.Slug, RawQuery: r.URL.RawQuery}
+		http.Redirect(w, r, target.String(), http.StatusMovedPermanently)
+		return
+	}
+	formatArticle(article, format)
 
 	var response models.Response
 	response.Status = http.StatusOK
@@ -134,7 +340,7 @@ func (app *Controller) GetArticle(w http.ResponseWriter, r *http.Request) {
 // swagger:operation POST /articles InsertArticle
 // ---
 // summary: Create an article.
-// description: Parses a JSON request to create a new article and returns the result.
+// description: Parses a JSON request to create a new article and returns the result. The signed in user is the author.
 // parameters:
 // - name: article
 //   in: body
@@ -142,10 +348,15 @@ func (app *Controller) GetArticle(w http.ResponseWriter, r *http.Request) {
 //   required: true
 //   schema:
 //     $ref: '#/definitions/Article'
+// security:
+// - bearer: []
+// - apiKey: []
 // responses:
 //   201:
 //     description: Created
 //     $ref: '#/responses/ArticleResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
 //   500:
 //     $ref: '#/responses/ErrorResponse'
 
@@ -160,11 +371,11 @@ func (app *Controller) InsertArticle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Insert the article into the service
-	articleID, err := app.ArticleService.CreateArticle(&article)
+	articleID, err := app.ArticleService.CreateArticle(&article, principal(r))
 	if err != nil {
 		// Handle the error here
 		log.Println(appconst.Articlenotcreated, err)
-		utility.WriteJSON(w, http.StatusInternalServerError, models.Response{Data: nil, Status: http.StatusInternalServerError, Message: appconst.Articlenotcreated + err.Error()})
+		writeError(w, err)
 		return
 	}
 
@@ -181,3 +392,413 @@ func (app *Controller) InsertArticle(w http.ResponseWriter, r *http.Request) {
 	// Set the response headers and write the JSON response
 	utility.WriteJSON(w, http.StatusCreated, response)
 }
+
+// swagger:operation PUT /articles/{id} UpdateArticle
+// ---
+// summary: Replace an article.
+// description: Replaces every field of the article with the given ID. The article keeps its author unless author_id is given, which only editors may do. Authors can replace their own articles, editors any article.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: article
+//   in: body
+//   description: The full article data.
+//   required: true
+//   schema:
+//     $ref: '#/definitions/Article'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) UpdateArticle(w http.ResponseWriter, r *http.Request) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	var article models.Article
+	err = utility.ReadJSON(w, r, &article)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	updated, err := app.ArticleService.UpdateArticle(articleID, &article, principal(r))
+	if err != nil {
+		log.Println(appconst.Articlenotupdated, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: updated, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation PATCH /articles/{id} PatchArticle
+// ---
+// summary: Partially update an article.
+// description: Applies a JSON merge patch (RFC 7396) to the article with the given ID. Authors can patch their own articles, editors any article.
+// consumes:
+// - application/merge-patch+json
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: patch
+//   in: body
+//   description: The fields to change; null removes a field.
+//   required: true
+//   schema:
+//     $ref: '#/definitions/Article'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) PatchArticle(w http.ResponseWriter, r *http.Request) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	maxBytes := 1024 * 1024 // one megabyte
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, int64(maxBytes)))
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	updated, err := app.ArticleService.PatchArticle(articleID, patch, principal(r))
+	if err != nil {
+		log.Println(appconst.Articlenotupdated, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: updated, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation DELETE /articles/{id} DeleteArticle
+// ---
+// summary: Delete an article.
+// description: Authors can delete their own articles, editors any article.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) DeleteArticle(w http.ResponseWriter, r *http.Request) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	err = app.ArticleService.DeleteArticle(articleID, principal(r))
+	if err != nil {
+		log.Println(appconst.Articlenotdeleted, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: models.Article{ID: articleID}, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation POST /articles/{id}/submit SubmitArticle
+// ---
+// summary: Submit a draft for review.
+// description: Moves a draft article to in_review. Authors can submit their own articles, editors any article.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) SubmitArticle(w http.ResponseWriter, r *http.Request) {
+	app.transitionArticle(w, r, app.ArticleService.SubmitArticle)
+}
+
+// swagger:operation POST /articles/{id}/publish PublishArticle
+// ---
+// summary: Publish an article.
+// description: Makes an article that is in_review public and sets its published_at. Only editors can publish.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) PublishArticle(w http.ResponseWriter, r *http.Request) {
+	app.transitionArticle(w, r, app.ArticleService.PublishArticle)
+}
+
+// swagger:operation POST /articles/{id}/unpublish UnpublishArticle
+// ---
+// summary: Unpublish an article.
+// description: Takes a published article back to draft and clears its published_at. Only editors can unpublish.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) UnpublishArticle(w http.ResponseWriter, r *http.Request) {
+	app.transitionArticle(w, r, app.ArticleService.UnpublishArticle)
+}
+
+// swagger:operation POST /articles/{id}/archive ArchiveArticle
+// ---
+// summary: Archive an article.
+// description: Retires an article; archived articles cannot change status again. Only editors can archive.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ArchiveArticle(w http.ResponseWriter, r *http.Request) {
+	app.transitionArticle(w, r, app.ArticleService.ArchiveArticle)
+}
+
+// transitionArticle runs a workflow action on the article in the URL and
+// writes the article in its new status.
+func (app *Controller) transitionArticle(w http.ResponseWriter, r *http.Request, action func(id int, actor models.Principal) (*models.Article, error)) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	article, err := action(articleID, principal(r))
+	if err != nil {
+		log.Println(appconst.Statusnotchanged, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: article, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// principal returns the user making the request, as authenticated by the
+// routes middleware.
+func principal(r *http.Request) models.Principal {
+	return auth.FromContext(r.Context())
+}
+
+// articleIDParam reads the numeric article ID from the URL.
+func articleIDParam(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "id"))
+}
+
+// swagger:route GET /articles/search searchArticles
+//
+// Search articles with a full-text query. Quoted phrases, OR, -exclusions
+// and prefix terms ending in * are supported. Results are ranked by
+// relevance, titles weighing more than content and author, and paged with
+// limit and offset.
+//
+// Responses:
+//
+//	200: SearchResponse
+//	400: ErrorResponse
+//	500: ErrorResponse
+
+func (app *Controller) SearchArticles(w http.ResponseWriter, r *http.Request) {
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Searchcursor, nil)
+	}
+	var format string
+	if err == nil {
+		format, err = articleFormat(r)
+	}
+	if err != nil {
+		log.Println(appconst.Searcherror, err)
+		writeError(w, err)
+		return
+	}
+	params.Viewer = principal(r)
+
+	page, err := app.ArticleService.SearchArticles(r.URL.Query().Get("q"), params)
+	if err != nil {
+		log.Println(appconst.Searcherror, err)
+		writeError(w, err)
+		return
+	}
+	for i := range page.Results {
+		formatArticle(&page.Results[i].Article, format)
+	}
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Results
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
+
+// swagger:route GET /articles/scheduled scheduledArticles
+//
+// List the reviewed articles waiting to be published automatically, soonest
+// publish_at first, paged with limit and offset. Editors see every scheduled
+// article, other users only their own.
+//
+// Responses:
+//
+//	200: ArticleListResponse
+//	400: ErrorResponse
+//	401: ErrorResponse
+//	500: ErrorResponse
+
+func (app *Controller) ScheduledArticles(w http.ResponseWriter, r *http.Request) {
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Offsetonly, nil)
+	}
+	var format string
+	if err == nil {
+		format, err = articleFormat(r)
+	}
+	if err != nil {
+		log.Println(appconst.Scheduledlist, err)
+		writeError(w, err)
+		return
+	}
+	params.Viewer = principal(r)
+
+	page, err := app.ArticleService.GetScheduledArticles(params)
+	if err != nil {
+		log.Println(appconst.Scheduledlist, err)
+		writeError(w, err)
+		return
+	}
+	formatArticles(page.Articles, format)
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Articles
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
@@ -2,15 +2,22 @@ package controller
 
 import (
 	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
 	"backend/pkg/models"
 	services "backend/services/articles"
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -29,7 +36,7 @@ func TestAllArticle(t *testing.T) {
 				{ID: 2, Title: "Article 2", Content: "Content 2", Author: "Author 2"},
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":200,"message":"Success","data":[{"id":1,"title":"Article 1","content":"Content 1","author":"Author 1"},{"id":2,"title":"Article 2","content":"Content 2","author":"Author 2"}]}`,
+			expectedResponseBody: `{"status":200,"message":"Success","data":[{"id":1,"title":"Article 1","content":"Content 1","author":"Author 1"},{"id":2,"title":"Article 2","content":"Content 2","author":"Author 2"}],"pagination":{"total":2,"limit":20}}`,
 		},
 	}
 
@@ -41,7 +48,7 @@ func TestAllArticle(t *testing.T) {
 			// Create a mock object for your DBInterface
 			mockDB := mocks.NewMockDBInterface(ctrl)
 			// Set expectations for mockDB's AllArticles method
-			mockDB.EXPECT().AllArticles().Return(tc.mockDBAllArticlesReturn, nil)
+			mockDB.EXPECT().AllArticles(models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}}).Return(&models.ArticlePage{Articles: tc.mockDBAllArticlesReturn, PageInfo: models.PageInfo{Total: 2}}, nil)
 
 			// Create an instance of your Application with the mock dependencies
 			app := &Controller{
@@ -71,7 +78,7 @@ func TestAllArticle_Error(t *testing.T) {
 	mockDB := mocks.NewMockDBInterface(ctrl)
 
 	// Set expectations for mockDB's AllArticles method to return an error
-	mockDB.EXPECT().AllArticles().Return(nil, errors.New("some error"))
+	mockDB.EXPECT().AllArticles(gomock.Any()).Return(nil, errors.New("some error"))
 
 	// Create an instance of your Application with the mock dependencies
 	app := &Controller{
@@ -113,6 +120,7 @@ func TestInsertArticle1(t *testing.T) {
 	testCases := []struct {
 		name             string
 		sampleArticle    *models.Article
+		principal        models.Principal
 		requestBody      string
 		expectedStatus   int
 		expectedResponse string
@@ -126,13 +134,35 @@ func TestInsertArticle1(t *testing.T) {
 				Content: "Sample Content",
 				Author:  "Sample Author",
 			},
-			requestBody:      `{"ID": 1, "Title": "Sample Article", "Content": "Sample Content", "Author": "Sample Author"}`,
+			principal:        models.Principal{UserID: 7, Username: "Sample Author", Role: models.RoleAuthor},
+			requestBody:      `{"ID": 1, "Title": "Sample Article", "Content": "Sample Content", "author_id": 7}`,
 			expectedStatus:   http.StatusCreated,
 			expectedResponse: `{"status":201,"message":"Success","data":{"id":1}}`,
 			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Sample Author"}, nil)
 				db.EXPECT().CreateArticle(gomock.Any()).Return(1, nil)
 			},
 		},
+		{
+			name:             "Removed User",
+			sampleArticle:    nil,
+			principal:        models.Principal{UserID: 8, Username: "Gone", Role: models.RoleAuthor},
+			requestBody:      `{"Title": "Sample Article", "author": "Mallory", "author_id": 7}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":400,"message":"author_id must reference a registered user","data":null}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				// The author is the signed in user, not the author_id sent
+				db.EXPECT().OneUser(8).Return(nil, apperrors.NotFound(appconst.Nouser, sql.ErrNoRows))
+			},
+		},
+		{
+			name:             "Not Signed In",
+			sampleArticle:    nil,
+			requestBody:      `{"Title": "Sample Article", "author_id": 7}`,
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"status":401,"message":"sign in to do this","data":null}`,
+			mockDBExpect:     func(db *mocks.MockDBInterface) {},
+		},
 		{
 			name:             "Error Parsing JSON",
 			sampleArticle:    nil,
@@ -150,6 +180,7 @@ func TestInsertArticle1(t *testing.T) {
 
 			r, _ := http.NewRequest("POST", "/articles", bytes.NewBufferString(tc.requestBody))
 			r.Header.Set("Content-Type", "application/json")
+			r = signedIn(r, tc.principal)
 
 			w := httptest.NewRecorder()
 
@@ -181,13 +212,14 @@ func TestInsertArticle_Error(t *testing.T) {
 
 	// Create a sample article for your test
 	sampleArticle := &models.Article{
-		ID:      1,
-		Title:   "Sample Article",
-		Content: "Sample Content",
-		Author:  "Sample Author",
+		ID:       1,
+		Title:    "Sample Article",
+		Content:  "Sample Content",
+		AuthorID: 7,
 	}
 
 	// Set expectations for the CreateArticle method in your mockDB to return an error
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Sample Author"}, nil)
 	mockDB.EXPECT().CreateArticle(gomock.Any()).Return(0, errors.New("some error"))
 
 	// Create an HTTP request with the sample article as the JSON body
@@ -198,6 +230,7 @@ func TestInsertArticle_Error(t *testing.T) {
 
 	r, _ := http.NewRequest("POST", "/articles", bytes.NewBuffer(body))
 	r.Header.Set("Content-Type", "application/json")
+	r = signedIn(r, models.Principal{UserID: 7, Username: "Sample Author", Role: models.RoleAuthor})
 
 	// Create an HTTP response recorder for testing
 	w := httptest.NewRecorder()
@@ -226,7 +259,7 @@ func TestGetArticle_Error(t *testing.T) {
 			name: "Error In Retrieving Article",
 			mockArticleService: func(ctrl *gomock.Controller) services.ArticleServices {
 				mock := mocks.NewMockArticleServices(ctrl)
-				mock.EXPECT().GetArticleByID(3).Return(nil, errors.New("some error"))
+				mock.EXPECT().GetArticleByID(3, "").Return(nil, errors.New("some error"))
 				return mock
 			},
 			mockUtility: func(ctrl *gomock.Controller) UtilityInterface {
@@ -314,3 +347,405 @@ func TestHealthCheck(t *testing.T) {
 		})
 	}
 }
+
+// editor is the signed in user of the tests that change articles
+var editor = models.Principal{UserID: 4, Username: "Editor", Role: models.RoleEditor}
+
+// signedIn returns r as made by principal, as the routes middleware would.
+func signedIn(r *http.Request, principal models.Principal) *http.Request {
+	return r.WithContext(auth.NewContext(r.Context(), principal))
+}
+
+// newArticleRequest builds a request carrying the chi "id" URL parameter.
+func newArticleRequest(method, id, body string) *http.Request {
+	r := httptest.NewRequest(method, "/articles/"+id, bytes.NewBufferString(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestUpdateArticle(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		requestBody        string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "Successful Update",
+			id:          "1",
+			requestBody: `{"title":"New Title","content":"New Content","author_id":3}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Title: "Title", Author: "Author", AuthorID: 2}, nil)
+				db.EXPECT().OneUser(3).Return(&models.User{ID: 3, Username: "New Author"}, nil)
+				db.EXPECT().UpdateArticle(&models.Article{ID: 1, Title: "New Title", Slug: "new-title", Content: "New Content", ContentHTML: "<p>New Content</p>\n", Author: "New Author", AuthorID: 3, UpdatedBy: "Editor"}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1,"title":"New Title","slug":"new-title","content":"New Content","author":"New Author","author_id":3,"updated_by":"Editor"}}`,
+		},
+		{
+			name:        "Author Kept",
+			id:          "1",
+			requestBody: `{"title":"New Title","content":"New Content","author":"New Author"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Title: "Title", Author: "Author", AuthorID: 2}, nil)
+				db.EXPECT().OneUser(2).Return(&models.User{ID: 2, Username: "Author"}, nil)
+				db.EXPECT().UpdateArticle(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1,"title":"New Title","slug":"new-title","content":"New Content","author":"Author","author_id":2,"updated_by":"Editor"}}`,
+		},
+		{
+			name:        "Audit Fields In RFC 3339",
+			id:          "1",
+			requestBody: `{"title":"New Title","author_id":3,"created_at":"2000-01-01T00:00:00Z","created_by":"Mallory"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 3}, nil)
+				db.EXPECT().OneUser(3).Return(&models.User{ID: 3, Username: "Author"}, nil)
+				db.EXPECT().UpdateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) error {
+					created := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+					updated := time.Date(2023, 5, 2, 8, 30, 15, 0, time.FixedZone("CEST", 2*60*60))
+					article.CreatedAt, article.UpdatedAt, article.CreatedBy = &created, &updated, "Author"
+					return nil
+				})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1,"title":"New Title","slug":"new-title","author":"Author","author_id":3,"created_at":"2023-05-01T10:00:00Z","updated_at":"2023-05-02T08:30:15+02:00","created_by":"Author","updated_by":"Editor"}}`,
+		},
+		{
+			name:        "Article Not Found",
+			id:          "2",
+			requestBody: `{"title":"New Title","content":"New Content","author_id":3}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(2).Return(nil, apperrors.NotFound(appconst.NoArticleforid, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"status":404,"message":"No article found for the given ID","data":null}`,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			requestBody:        `{}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"Error parsing article ID: strconv.Atoi: parsing \"abc\": invalid syntax","data":null}`,
+		},
+		{
+			name:               "Error Parsing JSON",
+			id:                 "1",
+			requestBody:        `{invalid-json}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"Error parsing JSON request: ","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.UpdateArticle(w, signedIn(newArticleRequest("PUT", tc.id, tc.requestBody), editor))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
+
+func TestPatchArticle(t *testing.T) {
+	existing := &models.Article{ID: 1, Title: "Title", Content: "Content", Author: "Author", AuthorID: 3}
+
+	testCases := []struct {
+		name               string
+		requestBody        string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:        "Successful Patch",
+			requestBody: `{"title":"Patched Title"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(existing, nil)
+				db.EXPECT().OneUser(3).Return(&models.User{ID: 3, Username: "Author"}, nil)
+				db.EXPECT().UpdateArticle(&models.Article{ID: 1, Title: "Patched Title", Slug: "patched-title", Content: "Content", ContentHTML: "<p>Content</p>\n", Author: "Author", AuthorID: 3, UpdatedBy: "Editor"}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1,"title":"Patched Title","slug":"patched-title","content":"Content","author":"Author","author_id":3,"updated_by":"Editor"}}`,
+		},
+		{
+			name:        "Unknown Field",
+			requestBody: `{"subtitle":"Nope"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(existing, nil)
+			},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"Error applying merge patch","data":null}`,
+		},
+		{
+			name:        "Article Not Found",
+			requestBody: `{"title":"Patched Title"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(nil, apperrors.NotFound(appconst.NoArticleforid, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"status":404,"message":"No article found for the given ID","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.PatchArticle(w, signedIn(newArticleRequest("PATCH", "1", tc.requestBody), editor))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
+
+func TestDeleteArticle(t *testing.T) {
+	testCases := []struct {
+		name               string
+		actor              models.Principal
+		mockDeleteReturn   error
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:               "Successful Delete",
+			mockDeleteReturn:   nil,
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1}}`,
+		},
+		{
+			name:               "Article Not Found",
+			mockDeleteReturn:   apperrors.NotFound(appconst.NoArticleforid, sql.ErrNoRows),
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"status":404,"message":"No article found for the given ID","data":null}`,
+		},
+		{
+			name:               "Database Error",
+			mockDeleteReturn:   errors.New("some error"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedResponse:   `{"status":500,"message":"Internal server error","data":null}`,
+		},
+		{
+			name:               "Article Of Another Author",
+			actor:              models.Principal{UserID: 9, Username: "Other", Role: models.RoleAuthor},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"status":403,"message":"you are not allowed to do this","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 2, Status: models.StatusPublished}, nil)
+			actor := tc.actor
+			if actor.Anonymous() {
+				actor = editor
+				mockDB.EXPECT().DeleteArticle(1).Return(tc.mockDeleteReturn)
+			}
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.DeleteArticle(w, signedIn(newArticleRequest("DELETE", "1", ""), actor))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
+
+func TestPublishArticle(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name: "Successful Publish",
+			id:   "1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Title: "Title", Author: "Author", Status: models.StatusInReview}, nil)
+				db.EXPECT().SetArticleStatus(gomock.Any(), models.StatusInReview).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1,"title":"Title","author":"Author","status":"published","updated_by":"Editor"}}`,
+		},
+		{
+			name: "Draft Must Be Reviewed First",
+			id:   "1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Status: models.StatusDraft}, nil)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedResponse:   `{"status":409,"message":"cannot publish an article that is draft","data":null}`,
+		},
+		{
+			name: "Article Not Found",
+			id:   "2",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(2).Return(nil, apperrors.NotFound(appconst.NoArticleforid, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"status":404,"message":"No article found for the given ID","data":null}`,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"Error parsing article ID: strconv.Atoi: parsing \"abc\": invalid syntax","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.PublishArticle(w, signedIn(newArticleRequest("POST", tc.id, ""), editor))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
+
+func TestGetArticle_Visibility(t *testing.T) {
+	testCases := []struct {
+		name               string
+		status             string
+		viewer             models.Principal
+		expectedStatusCode int
+	}{
+		{name: "Published Article", status: models.StatusPublished, expectedStatusCode: http.StatusOK},
+		{name: "Draft Is Hidden", status: models.StatusDraft, expectedStatusCode: http.StatusNotFound},
+		{name: "Archived Is Hidden", status: models.StatusArchived, expectedStatusCode: http.StatusNotFound},
+		{name: "Draft Is Hidden From Others", status: models.StatusDraft, viewer: models.Principal{UserID: 9, Username: "Other", Role: models.RoleAuthor}, expectedStatusCode: http.StatusNotFound},
+		{name: "Draft Is Visible To Its Author", status: models.StatusDraft, viewer: models.Principal{UserID: 2, Username: "Author", Role: models.RoleAuthor}, expectedStatusCode: http.StatusOK},
+		{name: "Draft Is Visible To Editors", status: models.StatusDraft, viewer: editor, expectedStatusCode: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Author: "Author", AuthorID: 2, Status: tc.status}, nil)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.GetArticle(w, signedIn(newArticleRequest("GET", "1", ""), tc.viewer))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestGetArticleBySlug(t *testing.T) {
+	testCases := []struct {
+		name               string
+		slug               string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedLocation   string
+	}{
+		{
+			name: "Current Slug",
+			slug: "docker-basics",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ArticleBySlug("docker-basics").Return(&models.Article{ID: 1, Slug: "docker-basics", Status: models.StatusPublished}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Old Slug",
+			slug: "docker-101",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ArticleBySlug("docker-101").Return(&models.Article{ID: 1, Slug: "docker-basics", Status: models.StatusPublished}, nil)
+			},
+			expectedStatusCode: http.StatusMovedPermanently,
+			expectedLocation:   "/articles/by-slug/docker-basics",
+		},
+		{
+			name: "Draft Is Hidden",
+			slug: "docker-basics",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ArticleBySlug("docker-basics").Return(&models.Article{ID: 1, Slug: "docker-basics", AuthorID: 2, Status: models.StatusDraft}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Unknown Slug",
+			slug: "missing",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ArticleBySlug("missing").Return(nil, apperrors.NotFound(appconst.NoArticleforslug, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.GetArticleBySlug(w, newSlugRequest("GET", "/articles/by-slug/"+tc.slug, tc.slug, ""))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedLocation, w.Header().Get("Location"))
+		})
+	}
+}
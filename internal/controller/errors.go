@@ -0,0 +1,48 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"errors"
+	"net/http"
+)
+
+// errorStatus returns the HTTP status code for a domain error.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperrors.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperrors.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, apperrors.ErrUnsupported):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, apperrors.ErrTimeout), errors.Is(err, apperrors.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError is the single place where errors are turned into responses.
+// Only the client-safe message of a domain error is written; anything else
+// is reported as an internal error so driver details never leak.
+func writeError(w http.ResponseWriter, err error) {
+	status := errorStatus(err)
+
+	message, ok := apperrors.Message(err)
+	if !ok || status == http.StatusInternalServerError {
+		message = appconst.Internalerror
+	}
+
+	utility.WriteJSON(w, status, models.Response{Data: nil, Status: status, Message: message})
+}
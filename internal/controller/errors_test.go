@@ -0,0 +1,95 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteError(t *testing.T) {
+	testCases := []struct {
+		name             string
+		err              error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "Not found",
+			err:              apperrors.NotFound(appconst.NoArticleforid, sql.ErrNoRows),
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":404,"message":"No article found for the given ID","data":null}`,
+		},
+		{
+			name:             "Conflict",
+			err:              apperrors.Conflict(appconst.Conflicterror, errors.New("duplicate key")),
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":409,"message":"The request conflicts with an existing resource","data":null}`,
+		},
+		{
+			name:             "Validation",
+			err:              apperrors.Validation(appconst.Validationerror, nil),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":400,"message":"The request is invalid","data":null}`,
+		},
+		{
+			name:             "Unauthorized",
+			err:              apperrors.Unauthorized(appconst.Unauthorizederror, nil),
+			expectedStatus:   http.StatusUnauthorized,
+			expectedResponse: `{"status":401,"message":"Authentication required","data":null}`,
+		},
+		{
+			name:             "Forbidden",
+			err:              apperrors.Forbidden(appconst.Forbidden, nil),
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":403,"message":"you are not allowed to do this","data":null}`,
+		},
+		{
+			name:             "Too large",
+			err:              apperrors.TooLarge("the file must be at most 10 bytes", nil),
+			expectedStatus:   http.StatusRequestEntityTooLarge,
+			expectedResponse: `{"status":413,"message":"the file must be at most 10 bytes","data":null}`,
+		},
+		{
+			name:             "Unsupported",
+			err:              apperrors.Unsupported("the file must be one of: image/png", nil),
+			expectedStatus:   http.StatusUnsupportedMediaType,
+			expectedResponse: `{"status":415,"message":"the file must be one of: image/png","data":null}`,
+		},
+		{
+			name:             "Database timeout",
+			err:              apperrors.Timeout(appconst.Timeouterror, context.DeadlineExceeded),
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedResponse: `{"status":503,"message":"The request timed out, please retry","data":null}`,
+		},
+		{
+			name:             "Unavailable",
+			err:              apperrors.Unavailable(appconst.Unavailableerror, sql.ErrConnDone),
+			expectedStatus:   http.StatusServiceUnavailable,
+			expectedResponse: `{"status":503,"message":"Service temporarily unavailable","data":null}`,
+		},
+		{
+			name:             "Driver details are not leaked",
+			err:              errors.New(`pq: relation "articles" does not exist`),
+			expectedStatus:   http.StatusInternalServerError,
+			expectedResponse: `{"status":500,"message":"Internal server error","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+
+			writeError(w, tc.err)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/feed"
+	"backend/pkg/models"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// feedMaxAge is how long feed readers, crawlers and caches may keep a feed
+// or sitemap, in seconds
+const feedMaxAge = "300"
+
+// swagger:operation GET /feed.{format} Feed
+// ---
+// summary: Feed of the latest articles.
+// description: The 20 most recently published articles as RSS 2.0, Atom or JSON Feed 1.1, with their rendered HTML. Feeds carry an ETag and a Last-Modified time, so readers polling them with If-None-Match or If-Modified-Since get a 304 until an article is published or changed.
+// produces:
+// - application/rss+xml
+// - application/atom+xml
+// - application/feed+json
+// parameters:
+// - name: format
+//   in: path
+//   type: string
+//   enum: [rss, atom, json]
+//   required: true
+// responses:
+//   200:
+//     description: The feed
+//   304:
+//     description: The feed has not changed
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) Feed(w http.ResponseWriter, r *http.Request) {
+	app.serveFeed(w, r, "", "")
+}
+
+// swagger:operation GET /authors/{username}/feed.{format} AuthorFeed
+// ---
+// summary: Feed of the latest articles of an author.
+// description: The 20 most recently published articles of an author, served like the feed of all articles.
+// produces:
+// - application/rss+xml
+// - application/atom+xml
+// - application/feed+json
+// parameters:
+// - name: username
+//   in: path
+//   type: string
+//   required: true
+// - name: format
+//   in: path
+//   type: string
+//   enum: [rss, atom, json]
+//   required: true
+// responses:
+//   200:
+//     description: The feed
+//   304:
+//     description: The feed has not changed
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) AuthorFeed(w http.ResponseWriter, r *http.Request) {
+	app.serveFeed(w, r, chi.URLParam(r, "username"), "")
+}
+
+// swagger:operation GET /tags/{slug}/feed.{format} TagFeed
+// ---
+// summary: Feed of the latest articles with a tag.
+// description: The 20 most recently published articles with a tag, served like the feed of all articles.
+// produces:
+// - application/rss+xml
+// - application/atom+xml
+// - application/feed+json
+// parameters:
+// - name: slug
+//   in: path
+//   type: string
+//   required: true
+// - name: format
+//   in: path
+//   type: string
+//   enum: [rss, atom, json]
+//   required: true
+// responses:
+//   200:
+//     description: The feed
+//   304:
+//     description: The feed has not changed
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) TagFeed(w http.ResponseWriter, r *http.Request) {
+	app.serveFeed(w, r, "", chi.URLParam(r, "slug"))
+}
+
+// serveFeed writes the feed of the articles of author with tag in the
+// format of the request path.
+func (app *Controller) serveFeed(w http.ResponseWriter, r *http.Request, author, tag string) {
+	format, ok := feed.Formats[chi.URLParam(r, "format")]
+	if !ok {
+		writeError(w, apperrors.NotFound(appconst.Nofeed, nil))
+		return
+	}
+
+	articles, err := app.ArticleService.GetFeed(author, tag)
+	if err != nil {
+		log.Println(appconst.Feederror, err)
+		writeError(w, err)
+		return
+	}
+
+	doc := app.feedDocument(articles, r.URL.Path)
+	body, err := format.Write(doc)
+	if err != nil {
+		log.Println(appconst.Feederror, err)
+		writeError(w, err)
+		return
+	}
+
+	serveDocument(w, r, format.ContentType, doc.Updated, body)
+}
+
+// serveDocument writes a generated document that crawlers and readers poll.
+// Its ETag is a hash of the document, so it changes with anything in it,
+// and http.ServeContent answers conditional requests.
+func serveDocument(w http.ResponseWriter, r *http.Request, contentType string, modified time.Time, body []byte) {
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", "public, max-age="+feedMaxAge)
+	http.ServeContent(w, r, "", modified, bytes.NewReader(body))
+}
+
+// feedDocument turns the articles of a feed into a feed served from path.
+// Every link is absolute, since readers fetch feeds from anywhere.
+func (app *Controller) feedDocument(articles *models.Feed, path string) *feed.Feed {
+	doc := &feed.Feed{
+		Title:       app.Site.Title,
+		Description: "The latest articles of " + app.Site.Title,
+		Link:        app.Site.Link("/"),
+		FeedURL:     app.Site.Link(path),
+	}
+	switch {
+	case articles.Author != "":
+		doc.Title += ": articles by " + articles.Author
+		doc.Description = "The latest articles by " + articles.Author
+		doc.Link = app.Site.Link("/articles?author=" + url.QueryEscape(articles.Author))
+	case articles.Tag != nil:
+		doc.Title += ": " + articles.Tag.Name
+		doc.Description = "The latest articles tagged " + articles.Tag.Name
+		doc.Link = app.Site.Link("/tags/" + url.PathEscape(articles.Tag.Slug) + "/articles")
+	}
+
+	for i := range articles.Articles {
+		article := &articles.Articles[i]
+		item := feed.Item{
+			ID:          app.Site.ArticleID(article),
+			Title:       article.Title,
+			Link:        app.Site.ArticleLink(article),
+			Author:      article.Author,
+			ContentHTML: article.ContentHTML,
+			Tags:        article.Tags,
+		}
+		if article.PublishedAt != nil {
+			item.Published = *article.PublishedAt
+		}
+		item.Updated = item.Published
+		if article.UpdatedAt != nil && article.UpdatedAt.After(item.Updated) {
+			item.Updated = *article.UpdatedAt
+		}
+		if item.Updated.After(doc.Updated) {
+			doc.Updated = item.Updated
+		}
+		item.Image = coverImage(app.Site, article.Cover)
+		doc.Items = append(doc.Items, item)
+	}
+	return doc
+}
+
+// coverImage returns the widest variant of a cover, or the uploaded image
+// until its variants are made.
+func coverImage(site models.Site, cover *models.Cover) *feed.Image {
+	if cover == nil {
+		return nil
+	}
+	if len(cover.Variants) == 0 {
+		return &feed.Image{URL: site.Link(cover.URL)}
+	}
+	widest := cover.Variants[len(cover.Variants)-1]
+	return &feed.Image{URL: site.Link(widest.URL), ContentType: widest.ContentType, Size: widest.Size}
+}
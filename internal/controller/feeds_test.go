@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"bytes"
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newFeedRequest builds a request for a feed with its route parameters.
+func newFeedRequest(path string, params map[string]string, header http.Header) *http.Request {
+	r := httptest.NewRequest("GET", path, nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	rctx := chi.NewRouteContext()
+	for name, value := range params {
+		rctx.URLParams.Add(name, value)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestFeeds(t *testing.T) {
+	published := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	updated := published.Add(2 * time.Hour)
+	latest := []models.Article{
+		{
+			ID: 2, Title: "Newer", Slug: "newer", Author: "Ada", ContentHTML: "<p>New</p>", PublishedAt: &published, UpdatedAt: &updated, Tags: []string{"Go"},
+			Cover: &models.Cover{URL: "/media/3/variants/640", Variants: []models.MediaVariant{{URL: "/media/3/variants/640", Width: 640, ContentType: "image/jpeg", Size: 2048}}},
+		},
+		{ID: 1, Title: "Older", Slug: "older", Author: "Ada", ContentHTML: "<p>Old</p>", PublishedAt: &published},
+	}
+
+	testCases := []struct {
+		name               string
+		handler            func(app *Controller) http.HandlerFunc
+		path               string
+		params             map[string]string
+		header             http.Header
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedHeader     http.Header
+		expectedContains   []string
+	}{
+		{
+			name:    "RSS",
+			handler: func(app *Controller) http.HandlerFunc { return app.Feed },
+			path:    "/feed.rss",
+			params:  map[string]string{"format": "rss"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().LatestArticles(models.ArticleFilter{}, models.FeedSize).Return(latest, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":  {"application/rss+xml; charset=utf-8"},
+				"Last-Modified": {"Fri, 01 Mar 2024 11:30:00 GMT"},
+				"Cache-Control": {"public, max-age=300"},
+			},
+			expectedContains: []string{
+				"<title>Blog</title>",
+				`<atom:link href="https://blog.example.com/feed.rss" rel="self"`,
+				"<link>https://blog.example.com/articles/by-slug/newer</link>",
+				`<guid isPermaLink="false">https://blog.example.com/articles/2</guid>`,
+				"<description>&lt;p&gt;New&lt;/p&gt;</description>",
+				`<enclosure url="https://blog.example.com/media/3/variants/640" length="2048" type="image/jpeg">`,
+			},
+		},
+		{
+			name:    "Atom",
+			handler: func(app *Controller) http.HandlerFunc { return app.Feed },
+			path:    "/feed.atom",
+			params:  map[string]string{"format": "atom"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().LatestArticles(models.ArticleFilter{}, models.FeedSize).Return(latest, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     http.Header{"Content-Type": {"application/atom+xml; charset=utf-8"}},
+			expectedContains:   []string{"<updated>2024-03-01T11:30:00Z</updated>", `<content type="html">&lt;p&gt;Old&lt;/p&gt;</content>`},
+		},
+		{
+			name:    "JSON Feed Of An Author",
+			handler: func(app *Controller) http.HandlerFunc { return app.AuthorFeed },
+			path:    "/authors/ada/feed.json",
+			params:  map[string]string{"username": "ada", "format": "json"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().UserByUsername("ada").Return(&models.User{ID: 7, Username: "Ada"}, nil)
+				db.EXPECT().LatestArticles(models.ArticleFilter{Author: "Ada"}, models.FeedSize).Return(latest, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     http.Header{"Content-Type": {"application/feed+json; charset=utf-8"}},
+			expectedContains: []string{
+				`"title": "Blog: articles by Ada"`,
+				`"home_page_url": "https://blog.example.com/articles?author=Ada"`,
+				`"feed_url": "https://blog.example.com/authors/ada/feed.json"`,
+				`"content_html": "<p>New</p>"`,
+			},
+		},
+		{
+			name:    "Tag",
+			handler: func(app *Controller) http.HandlerFunc { return app.TagFeed },
+			path:    "/tags/go/feed.rss",
+			params:  map[string]string{"slug": "go", "format": "rss"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("go").Return(&models.Tag{ID: 4, Name: "Go", Slug: "go"}, nil)
+				db.EXPECT().LatestArticles(models.ArticleFilter{Tag: "go"}, models.FeedSize).Return([]models.Article{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedContains:   []string{"<title>Blog: Go</title>", "<link>https://blog.example.com/tags/go/articles</link>"},
+		},
+		{
+			name:    "Not Modified Since",
+			handler: func(app *Controller) http.HandlerFunc { return app.Feed },
+			path:    "/feed.rss",
+			params:  map[string]string{"format": "rss"},
+			header:  http.Header{"If-Modified-Since": {"Fri, 01 Mar 2024 11:30:00 GMT"}},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().LatestArticles(models.ArticleFilter{}, models.FeedSize).Return(latest, nil)
+			},
+			expectedStatusCode: http.StatusNotModified,
+		},
+		{
+			name:    "Unknown Tag",
+			handler: func(app *Controller) http.HandlerFunc { return app.TagFeed },
+			path:    "/tags/nothing/feed.rss",
+			params:  map[string]string{"slug": "nothing", "format": "rss"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("nothing").Return(nil, apperrors.NotFound(appconst.Notag, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedContains:   []string{`"message":"No tag found"`},
+		},
+		{
+			name:               "Unknown Format",
+			handler:            func(app *Controller) http.HandlerFunc { return app.Feed },
+			path:               "/feed.xml",
+			params:             map[string]string{"format": "xml"},
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusNotFound,
+			expectedContains:   []string{`"message":"No feed in this format, use rss, atom or json"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+			app := &Controller{ArticleService: services.NewArticleService(mockDB), Site: models.NewSite("Blog", "https://blog.example.com/")}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, newFeedRequest(tc.path, tc.params, tc.header))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			for name, values := range tc.expectedHeader {
+				assert.Equal(t, values, w.Header()[name], name)
+			}
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+		})
+	}
+}
+
+func TestFeedETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	app := &Controller{ArticleService: services.NewArticleService(mockDB), Site: models.NewSite("Blog", "https://blog.example.com")}
+	article := models.Article{ID: 1, Title: "First", Slug: "first"}
+
+	mockDB.EXPECT().LatestArticles(models.ArticleFilter{}, models.FeedSize).Return([]models.Article{article}, nil).Times(2)
+	w := httptest.NewRecorder()
+	app.Feed(w, newFeedRequest("/feed.json", map[string]string{"format": "json"}, nil))
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// The same feed is not sent again
+	w = httptest.NewRecorder()
+	app.Feed(w, newFeedRequest("/feed.json", map[string]string{"format": "json"}, http.Header{"If-None-Match": {etag}}))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, bytes.TrimSpace(w.Body.Bytes()))
+
+	// A changed article changes the feed
+	article.Title = "First, edited"
+	mockDB.EXPECT().LatestArticles(models.ArticleFilter{}, models.FeedSize).Return([]models.Article{article}, nil)
+	w = httptest.NewRecorder()
+	app.Feed(w, newFeedRequest("/feed.json", map[string]string{"format": "json"}, http.Header{"If-None-Match": {etag}}))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// articleFormat reads the format the article read endpoints return content
+// in: markdown, the default, or html.
+func articleFormat(r *http.Request) (string, error) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		return models.FormatMarkdown, nil
+	}
+	if !contains(models.ContentFormats, format) {
+		return "", apperrors.Validation(fmt.Sprintf(appconst.Invalidformat, strings.Join(models.ContentFormats, ", ")), nil)
+	}
+	return format, nil
+}
+
+// formatArticle puts the content of article in format.
+func formatArticle(article *models.Article, format string) {
+	if format == models.FormatHTML {
+		article.Content = article.ContentHTML
+	}
+}
+
+// formatArticles puts the content of every article in format.
+func formatArticles(articles []models.Article, format string) {
+	for i := range articles {
+		formatArticle(&articles[i], format)
+	}
+}
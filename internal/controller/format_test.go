@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"backend/mocks"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArticle_Format(t *testing.T) {
+	testCases := []struct {
+		name               string
+		target             string
+		stored             models.Article
+		expectedStatusCode int
+		expectedContent    string
+	}{
+		{
+			name:               "Markdown By Default",
+			target:             "/articles/1",
+			stored:             models.Article{ID: 1, Content: "**Hi**", ContentHTML: "<p><strong>Hi</strong></p>\n", Status: models.StatusPublished},
+			expectedStatusCode: http.StatusOK,
+			expectedContent:    "**Hi**",
+		},
+		{
+			name:               "HTML",
+			target:             "/articles/1?format=html",
+			stored:             models.Article{ID: 1, Content: "**Hi**", ContentHTML: "<p><strong>Hi</strong></p>\n", Status: models.StatusPublished},
+			expectedStatusCode: http.StatusOK,
+			expectedContent:    "<p><strong>Hi</strong></p>\n",
+		},
+		{
+			name:               "Unknown Format",
+			target:             "/articles/1?format=pdf",
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			if tc.expectedStatusCode == http.StatusOK {
+				stored := tc.stored
+				mockDB.EXPECT().OneArticle(1).Return(&stored, nil)
+			}
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			r := newArticleRequest("GET", "1", "")
+			r.URL, _ = r.URL.Parse(tc.target)
+			w := httptest.NewRecorder()
+			app.GetArticle(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			if tc.expectedStatusCode != http.StatusOK {
+				return
+			}
+			var response struct {
+				Data models.Article `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedContent, response.Data.Content)
+			assert.NotContains(t, w.Body.String(), "content_html")
+		})
+	}
+}
+
+func TestAllArticle_Format(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	mockDB.EXPECT().AllArticles(gomock.Any()).Return(&models.ArticlePage{Articles: []models.Article{
+		{ID: 1, Content: "# One", ContentHTML: "<h1>One</h1>"},
+		{ID: 2, Content: "# Two", ContentHTML: "<h1>Two</h1>"},
+	}}, nil)
+
+	app := &Controller{
+		ArticleService: services.NewArticleService(mockDB),
+	}
+
+	w := httptest.NewRecorder()
+	app.AllArticle(w, httptest.NewRequest("GET", "/articles?format=html", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []models.Article `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "<h1>One</h1>", response.Data[0].Content)
+	assert.Equal(t, "<h1>Two</h1>", response.Data[1].Content)
+}
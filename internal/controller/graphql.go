@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"backend/internal/graph"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// swagger:operation POST /graphql GraphQL
+// ---
+// summary: Run a GraphQL query or mutation.
+// description: Queries articles with their authors, tags and comments, and creates, updates and deletes articles. Fields are resolved with the same rules as the REST endpoints, so anonymous requests see published articles only and mutations need a signed in user. Queries nested more deeply or asking for more fields than the server allows are rejected with the code QUERY_TOO_COMPLEX before anything is resolved. Errors are reported in the errors of the response, each with a code in its extensions, and the response status is 200 whenever the request itself could be read.
+// consumes:
+// - application/json
+// parameters:
+// - name: request
+//   in: body
+//   description: The query, the name of the operation to run and the values of its variables.
+//   required: true
+//   schema:
+//     type: object
+//     required:
+//     - query
+//     properties:
+//       query:
+//         type: string
+//       operationName:
+//         type: string
+//       variables:
+//         type: object
+// responses:
+//   200:
+//     description: The data and errors of the query
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) GraphQL(w http.ResponseWriter, r *http.Request) {
+	var request graph.Request
+	if err := utility.ReadJSON(w, r, &request); err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		writeError(w, apperrors.Validation(appconst.Graphqlquery, nil))
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, app.Graph.Execute(r.Context(), request))
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"backend/internal/graph"
+	"backend/mocks"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"backend/services/comments"
+	"backend/services/taxonomy"
+	"backend/services/users"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQL(t *testing.T) {
+	editor := models.Principal{UserID: 2, Username: "grace", Role: models.RoleEditor}
+
+	testCases := []struct {
+		name               string
+		body               string
+		principal          models.Principal
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedBody       string
+	}{
+		{
+			name:      "Query",
+			body:      `{"query": "query Article($id: ID!) { article(id: $id) { title status } }", "operationName": "Article", "variables": {"id": 4}}`,
+			principal: editor,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(4).Return(&models.Article{ID: 4, Title: "Draft", AuthorID: 1, Status: models.StatusDraft}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":{"article":{"title":"Draft","status":"draft"}}}`,
+		},
+		{
+			name:               "Too deep",
+			body:               `{"query": "{ articles { nodes { comments { author { articles { nodes { comments { author { articles { nodes { id } } } } } } } } } } }"}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":null,"errors":[{"message":"the query is nested more than 10 levels deep","locations":[],"extensions":{"code":"QUERY_TOO_COMPLEX"}}]}`,
+		},
+		{
+			name:               "Syntax error",
+			body:               `{"query": "{ articles {"}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       `{"data":null,"errors":[{"message":"Syntax Error GraphQL request (1:13) Expected Name, found EOF\n\n1: { articles {\n               ^\n","locations":[{"line":1,"column":13}]}]}`,
+		},
+		{
+			name:               "No query",
+			body:               `{"variables": {}}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       `{"data":null,"status":400,"message":"the request must have a query"}`,
+		},
+		{
+			name:               "Not JSON",
+			body:               `query { articles { nodes { id } } }`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+			schema, err := graph.NewSchema(graph.Services{
+				Articles: services.NewArticleService(mockDB),
+				Users:    users.NewUserService(mockDB, nil),
+				Comments: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+				Taxonomy: taxonomy.NewTaxonomyService(mockDB),
+			}, graph.Limits{})
+			assert.NoError(t, err)
+			app := &Controller{Graph: schema}
+
+			r := httptest.NewRequest("POST", "/graphql", strings.NewReader(tc.body))
+			r = r.WithContext(auth.NewContext(r.Context(), tc.principal))
+			w := httptest.NewRecorder()
+			app.GraphQL(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			if tc.expectedBody != "" {
+				assert.JSONEq(t, tc.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
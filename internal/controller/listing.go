@@ -0,0 +1,85 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// articleListQuery lists the query parameters accepted by the article listing
+var articleListQuery = []string{"limit", "offset", "cursor", "sort", "author", "created_after", "created_before", "tag", "category", "status", "format"}
+
+// articleListParams reads the paging, sort and filter query parameters of
+// the article listing. Unknown parameters and values outside the whitelists
+// are rejected rather than ignored, so typos don't silently return
+// unfiltered results.
+func articleListParams(r *http.Request) (models.ListParams, error) {
+	query := r.URL.Query()
+	for key := range query {
+		if !contains(articleListQuery, key) {
+			return models.ListParams{}, apperrors.Validation(fmt.Sprintf(appconst.Unknownparameter, key, strings.Join(articleListQuery, ", ")), nil)
+		}
+	}
+
+	params, err := listParams(r)
+	if err != nil {
+		return params, err
+	}
+
+	if value := query.Get("sort"); value != "" {
+		sort, ok := models.ParseSort(value)
+		if !ok {
+			return params, apperrors.Validation(fmt.Sprintf(appconst.Invalidsort, strings.Join(models.ArticleSortFields, ", ")), nil)
+		}
+		params.Sort = sort
+	}
+	if params.Cursor != nil && params.Cursor.Sort != params.Sort.String() {
+		return params, apperrors.Validation(appconst.Cursorsort, nil)
+	}
+
+	params.Filter.Author = query.Get("author")
+	params.Filter.Tag = utility.Slugify(query.Get("tag"))
+	params.Filter.Category = query.Get("category")
+
+	if params.Filter.CreatedAfter, err = timeParam(query.Get("created_after"), "created_after"); err != nil {
+		return params, err
+	}
+	if params.Filter.CreatedBefore, err = timeParam(query.Get("created_before"), "created_before"); err != nil {
+		return params, err
+	}
+
+	if value := query.Get("status"); value != "" {
+		if !contains(models.ArticleStatuses, value) {
+			return params, apperrors.Validation(fmt.Sprintf(appconst.Invalidstatus, strings.Join(models.ArticleStatuses, ", ")), nil)
+		}
+		params.Filter.Status = value
+	}
+
+	return params, nil
+}
+
+// timeParam parses an optional RFC 3339 query parameter
+func timeParam(value, name string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Invalidtimestamp, name), err)
+	}
+	return &t, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
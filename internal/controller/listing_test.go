@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"backend/mocks"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllArticle_SortAndFilter(t *testing.T) {
+	after := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	cursor := models.Cursor{Sort: "-created_at", Value: "2023-05-01 10:00:00+00", ID: 4, Direction: models.CursorNext}
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedParams models.ListParams
+		expectedLink   string
+	}{
+		{
+			name: "Descending sort with every filter",
+			url:  "/articles?sort=-created_at&author=ada&created_after=2023-01-01T00:00:00Z&created_before=2023-06-01T12:00:00Z&tag=Go&category=news&status=published",
+			expectedParams: models.ListParams{
+				Limit:  models.DefaultPageSize,
+				Sort:   models.Sort{Field: models.SortCreatedAt, Desc: true},
+				Filter: models.ArticleFilter{Author: "ada", CreatedAfter: &after, CreatedBefore: &before, Tag: "go", Category: "news", Status: models.StatusPublished},
+			},
+			expectedLink: `</articles?author=ada&category=news&created_after=2023-01-01T00%3A00%3A00Z&created_before=2023-06-01T12%3A00%3A00Z&limit=20&sort=-created_at&status=published&tag=Go>; rel="first"`,
+		},
+		{
+			name: "Cursor issued for the same sort",
+			url:  "/articles?sort=-created_at&cursor=" + cursor.Encode(),
+			expectedParams: models.ListParams{
+				Limit:  models.DefaultPageSize,
+				Sort:   models.Sort{Field: models.SortCreatedAt, Desc: true},
+				Cursor: &cursor,
+			},
+			expectedLink: `</articles?limit=20&sort=-created_at>; rel="first"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			mockDB.EXPECT().AllArticles(tc.expectedParams).Return(&models.ArticlePage{Articles: []models.Article{}}, nil)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.AllArticle(w, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.expectedLink, w.Header().Get("Link"))
+		})
+	}
+}
+
+func TestAllArticle_InvalidSortAndFilter(t *testing.T) {
+	titleCursor := models.Cursor{Sort: "title", Value: "B", ID: 2, Direction: models.CursorNext}.Encode()
+
+	testCases := []struct {
+		name            string
+		url             string
+		expectedMessage string
+	}{
+		{
+			name:            "Unknown sort field",
+			url:             "/articles?sort=content",
+			expectedMessage: "sort must be one of: title, created_at, updated_at, author, id, prefixed with - for descending order",
+		},
+		{
+			name:            "Unknown query parameter",
+			url:             "/articles?auther=ada",
+			expectedMessage: `unknown query parameter \"auther\", valid parameters are: limit, offset, cursor, sort, author, created_after, created_before, tag, category, status, format`,
+		},
+		{
+			name:            "Malformed timestamp",
+			url:             "/articles?created_after=yesterday",
+			expectedMessage: "created_after must be an RFC 3339 timestamp",
+		},
+		{
+			name:            "Unknown status",
+			url:             "/articles?status=deleted",
+			expectedMessage: "status must be one of: draft, in_review, published, archived",
+		},
+		{
+			name:            "Cursor from another sort",
+			url:             "/articles?sort=-id&cursor=" + titleCursor,
+			expectedMessage: "cursor was issued for a different sort order",
+		},
+	}
+
+	app := &Controller{}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.AllArticle(w, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"status":400,"message":"`+tc.expectedMessage+`","data":null}`, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// multipartOverhead is how much larger than the largest file an upload
+// request may be, for the multipart headers and boundaries around the file
+const multipartOverhead = 64 << 10
+
+// swagger:operation POST /media UploadMedia
+// ---
+// summary: Upload a file.
+// description: Uploads an image or PDF as the part named file of a multipart/form-data body. The content type is sniffed from the bytes of the file, whatever the client says it is. Images are stored without their metadata, such as EXIF data and GPS positions; JPEGs keep their orientation. A file that was uploaded before is not stored again; the earlier upload is returned with status 200 instead of 201.
+// consumes:
+// - multipart/form-data
+// parameters:
+// - name: file
+//   in: formData
+//   type: file
+//   required: true
+//   description: The file to upload, at most 10 MiB unless the server is configured otherwise
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/MediaResponse'
+//   201:
+//     $ref: '#/responses/MediaResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   413:
+//     $ref: '#/responses/ErrorResponse'
+//   415:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+//   503:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	maxSize := app.MediaService.MaxSize()
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+
+	file, filename, err := filePart(r)
+	var uploaded *models.Media
+	var created bool
+	if err == nil {
+		uploaded, created, err = app.MediaService.UploadMedia(file, filename, principal(r))
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			err = apperrors.TooLarge(fmt.Sprintf(appconst.Mediatoolarge, maxSize), err)
+		}
+		log.Println(appconst.Mediaerror, err)
+		writeError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+		w.Header().Set("Location", uploaded.URL)
+	}
+	utility.WriteJSON(w, status, models.Response{Data: uploaded, Status: status, Message: appconst.Success})
+}
+
+// filePart returns the part of a multipart upload named file and the name
+// of the file it holds. Parts before it are skipped.
+func filePart(r *http.Request) (io.Reader, string, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", apperrors.Validation(appconst.Mediafile, err)
+	}
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, "", apperrors.Validation(appconst.Mediafile, nil)
+		}
+		if err != nil {
+			return nil, "", apperrors.Validation(appconst.Mediafile, err)
+		}
+		if part.FormName() == "file" {
+			return part, part.FileName(), nil
+		}
+	}
+}
+
+// swagger:operation GET /media/{id} GetMedia
+// ---
+// summary: Download a file.
+// description: Serves an uploaded file. Ranges of it can be asked for with the Range header. The bytes of a file never change, so responses can be cached for good, and its ETag is the quoted SHA-256 checksum of the file.
+// produces:
+// - application/octet-stream
+// parameters:
+// - name: id
+//   in: path
+//   type: integer
+//   required: true
+//   description: ID of the file
+// - name: Range
+//   in: header
+//   type: string
+//   description: Bytes of the file to serve, e.g. bytes=0-1023
+// responses:
+//   200:
+//     description: The file
+//     schema:
+//       type: file
+//   206:
+//     description: The requested range of the file
+//     schema:
+//       type: file
+//   304:
+//     description: The file has not changed
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   416:
+//     description: The requested range is not in the file
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+//   503:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) GetMedia(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Println(appconst.Parsingmedia, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingmedia + err.Error()})
+		return
+	}
+
+	file, blob, err := app.MediaService.OpenMedia(mediaID)
+	if err != nil {
+		log.Println(appconst.Mediaunavailable, err)
+		writeError(w, err)
+		return
+	}
+	defer blob.Close()
+
+	if file.Filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": file.Filename}))
+	}
+	var modified time.Time
+	if file.CreatedAt != nil {
+		modified = *file.CreatedAt
+	}
+	// Files are stored by checksum, so the bytes behind an ID never change
+	serveBlob(w, r, file.ContentType, file.Checksum, modified, blob)
+}
+
+// swagger:operation GET /media/{id}/variants/{width} GetMediaVariant
+// ---
+// summary: Download a resized variant of an image.
+// description: Serves an uploaded image resized to one of the widths listed in the variants of an article cover, without the metadata of the upload. Variants are made in the background shortly after an image is uploaded. The bytes of a variant never change, so responses can be cached for good.
+// produces:
+// - image/jpeg
+// - image/png
+// parameters:
+// - name: id
+//   in: path
+//   type: integer
+//   required: true
+//   description: ID of the image
+// - name: width
+//   in: path
+//   type: integer
+//   required: true
+//   description: Width of the variant in pixels
+// - name: Range
+//   in: header
+//   type: string
+//   description: Bytes of the variant to serve, e.g. bytes=0-1023
+// responses:
+//   200:
+//     description: The variant
+//     schema:
+//       type: file
+//   206:
+//     description: The requested range of the variant
+//     schema:
+//       type: file
+//   304:
+//     description: The variant has not changed
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   416:
+//     description: The requested range is not in the variant
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+//   503:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) GetMediaVariant(w http.ResponseWriter, r *http.Request) {
+	mediaID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Println(appconst.Parsingmedia, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingmedia + err.Error()})
+		return
+	}
+	width, err := strconv.Atoi(chi.URLParam(r, "width"))
+	if err != nil {
+		log.Println(appconst.Parsingwidth, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingwidth + err.Error()})
+		return
+	}
+
+	variant, blob, err := app.MediaService.OpenVariant(mediaID, width)
+	if err != nil {
+		log.Println(appconst.Mediaunavailable, err)
+		writeError(w, err)
+		return
+	}
+	defer blob.Close()
+
+	// Variants are stored under the checksum of their image and their width
+	serveBlob(w, r, variant.ContentType, path.Base(variant.StorageKey), time.Time{}, blob)
+}
+
+// serveBlob serves stored bytes that never change behind their URL, tagged
+// with etag.
+func serveBlob(w http.ResponseWriter, r *http.Request, contentType, etag string, modified time.Time, blob io.ReadSeeker) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+etag+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", modified, blob)
+}
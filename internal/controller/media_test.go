@@ -0,0 +1,321 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/storage"
+	"backend/services/media"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// gif is a whole 1x1 GIF image
+var gif = []byte("GIF89a\x01\x00\x01\x00\x80\x00\x00\xff\xff\xff\x00\x00\x00!\xf9\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;")
+
+// newUploadRequest builds a multipart upload with data as the part named field.
+func newUploadRequest(field string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile(field, "dot.gif")
+	part.Write(data)
+	writer.Close()
+
+	r := httptest.NewRequest("POST", "/media", &body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return signedIn(r, editor)
+}
+
+func newMediaController(t *testing.T, mockDB *mocks.MockDBInterface, maxSize int64) (*Controller, *storage.LocalStore) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	return &Controller{MediaService: media.NewMediaService(mockDB, store, maxSize, nil)}, store
+}
+
+func TestUploadMedia(t *testing.T) {
+	sum := sha256.Sum256(gif)
+	checksum := hex.EncodeToString(sum[:])
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		maxSize            int64
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+		expectedLocation   string
+	}{
+		{
+			name:    "New File",
+			request: newUploadRequest("file", gif),
+			maxSize: 1024,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().MediaByChecksum(checksum).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows))
+				db.EXPECT().CreateMedia(gomock.Any()).DoAndReturn(func(file *models.Media) error {
+					file.ID = 3
+					return nil
+				})
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMessage:    appconst.Success,
+			expectedLocation:   "/media/3",
+		},
+		{
+			name:    "Uploaded Before",
+			request: newUploadRequest("file", gif),
+			maxSize: 1024,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().MediaByChecksum(checksum).Return(&models.Media{ID: 3, Checksum: checksum}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Too Large",
+			request:            newUploadRequest("file", gif),
+			maxSize:            16,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedMessage:    "the file must be at most 16 bytes",
+		},
+		{
+			name:               "Request Too Large",
+			request:            newUploadRequest("file", make([]byte, multipartOverhead+32)),
+			maxSize:            16,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusRequestEntityTooLarge,
+			expectedMessage:    "the file must be at most 16 bytes",
+		},
+		{
+			name:               "Not An Image",
+			request:            newUploadRequest("file", []byte("<html><script>alert(1)</script></html>")),
+			maxSize:            1024,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusUnsupportedMediaType,
+			expectedMessage:    "the file must be one of: image/jpeg, image/png, image/gif, image/webp, application/pdf",
+		},
+		{
+			name:               "No File Part",
+			request:            newUploadRequest("image", gif),
+			maxSize:            1024,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Mediafile,
+		},
+		{
+			name:               "Not Multipart",
+			request:            signedIn(httptest.NewRequest("POST", "/media", bytes.NewReader(gif)), editor),
+			maxSize:            1024,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Mediafile,
+		},
+		{
+			name:               "Anonymous",
+			request:            newUploadRequest("file", gif).WithContext(context.Background()),
+			maxSize:            1024,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedMessage:    appconst.Unauthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+			app, _ := newMediaController(t, mockDB, tc.maxSize)
+
+			w := httptest.NewRecorder()
+			app.UploadMedia(w, tc.request)
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+			assert.Equal(t, tc.expectedLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+// newMediaRequest builds a request for a file carrying the chi "id" URL parameter.
+func newMediaRequest(id string, header http.Header) *http.Request {
+	r := httptest.NewRequest("GET", "/media/"+id, nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestGetMedia(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		header             http.Header
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedBody       []byte
+		expectedHeader     http.Header
+	}{
+		{
+			name: "Whole File",
+			id:   "3",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneMedia(3).Return(&models.Media{ID: 3, Checksum: "abc", ContentType: "image/gif", Filename: "dot.gif", StorageKey: "ab/abc"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       gif,
+			expectedHeader: http.Header{
+				"Content-Type":           {"image/gif"},
+				"Etag":                   {`"abc"`},
+				"Cache-Control":          {"public, max-age=31536000, immutable"},
+				"X-Content-Type-Options": {"nosniff"},
+				"Content-Disposition":    {"inline; filename=dot.gif"},
+				"Accept-Ranges":          {"bytes"},
+			},
+		},
+		{
+			name:   "Range",
+			id:     "3",
+			header: http.Header{"Range": {"bytes=0-5"}},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneMedia(3).Return(&models.Media{ID: 3, Checksum: "abc", ContentType: "image/gif", StorageKey: "ab/abc"}, nil)
+			},
+			expectedStatusCode: http.StatusPartialContent,
+			expectedBody:       []byte("GIF89a"),
+			expectedHeader: http.Header{
+				"Content-Range": {"bytes 0-5/43"},
+			},
+		},
+		{
+			name:   "Cached",
+			id:     "3",
+			header: http.Header{"If-None-Match": {`"abc"`}},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneMedia(3).Return(&models.Media{ID: 3, Checksum: "abc", ContentType: "image/gif", StorageKey: "ab/abc"}, nil)
+			},
+			expectedStatusCode: http.StatusNotModified,
+			expectedBody:       []byte{},
+		},
+		{
+			name: "Not Found",
+			id:   "4",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneMedia(4).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       []byte(`{"status":404,"message":"No media found","data":null}`),
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       []byte(`{"status":400,"message":"Error parsing media ID: strconv.Atoi: parsing \"abc\": invalid syntax","data":null}`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+			app, store := newMediaController(t, mockDB, 1024)
+			assert.NoError(t, store.Put(context.Background(), "ab/abc", bytes.NewReader(gif), int64(len(gif)), "image/gif"))
+
+			w := httptest.NewRecorder()
+			app.GetMedia(w, newMediaRequest(tc.id, tc.header))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, string(tc.expectedBody), string(bytes.TrimSpace(w.Body.Bytes())))
+			for name, values := range tc.expectedHeader {
+				assert.Equal(t, values, w.Header()[name], name)
+			}
+		})
+	}
+}
+
+func TestGetMediaVariant(t *testing.T) {
+	testCases := []struct {
+		name               string
+		width              string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedBody       []byte
+		expectedHeader     http.Header
+	}{
+		{
+			name:  "Variant",
+			width: "320",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().MediaVariant(3, 320).Return(&models.MediaVariant{MediaID: 3, Width: 320, ContentType: "image/gif", StorageKey: "ab/abc-320"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       gif,
+			expectedHeader: http.Header{
+				"Content-Type":  {"image/gif"},
+				"Etag":          {`"abc-320"`},
+				"Cache-Control": {"public, max-age=31536000, immutable"},
+			},
+		},
+		{
+			name:  "Not Found",
+			width: "640",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().MediaVariant(3, 640).Return(nil, apperrors.NotFound(appconst.Novariant, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedBody:       []byte(`{"status":404,"message":"No variant of the image found with this width","data":null}`),
+		},
+		{
+			name:               "Invalid Width",
+			width:              "wide",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedBody:       []byte(`{"status":400,"message":"Error parsing variant width: strconv.Atoi: parsing \"wide\": invalid syntax","data":null}`),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+			app, store := newMediaController(t, mockDB, 1024)
+			assert.NoError(t, store.Put(context.Background(), "ab/abc-320", bytes.NewReader(gif), int64(len(gif)), "image/gif"))
+
+			r := newMediaRequest("3", nil)
+			chi.RouteContext(r.Context()).URLParams.Add("width", tc.width)
+			w := httptest.NewRecorder()
+			app.GetMediaVariant(w, r)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, string(tc.expectedBody), string(bytes.TrimSpace(w.Body.Bytes())))
+			for name, values := range tc.expectedHeader {
+				assert.Equal(t, values, w.Header()[name], name)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:operation GET /moderation/comments CommentQueue
+// ---
+// summary: List comments awaiting moderation.
+// description: Lists the comments in one moderation status, oldest first, paged with limit and offset. New comments, and edits scored as likely spam, wait in pending until a moderator decides on them. Only editors can moderate.
+// parameters:
+// - name: status
+//   in: query
+//   type: string
+//   enum: [pending, approved, rejected, spam]
+//   default: pending
+// - name: limit
+//   in: query
+//   type: integer
+// - name: offset
+//   in: query
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CommentListResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) CommentQueue(w http.ResponseWriter, r *http.Request) {
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Offsetonly, nil)
+	}
+	if err != nil {
+		log.Println(appconst.Commentqueue, err)
+		writeError(w, err)
+		return
+	}
+
+	page, err := app.CommentService.ModerationQueue(r.URL.Query().Get("status"), params, principal(r))
+	if err != nil {
+		log.Println(appconst.Commentqueue, err)
+		writeError(w, err)
+		return
+	}
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Comments
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
+
+// swagger:operation POST /moderation/comments/{id}/approve ApproveComment
+// ---
+// summary: Approve a comment.
+// description: Shows a comment in its thread. Only editors can moderate.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CommentResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ApproveComment(w http.ResponseWriter, r *http.Request) {
+	app.moderateComment(w, r, models.CommentApproved)
+}
+
+// swagger:operation POST /moderation/comments/{id}/reject RejectComment
+// ---
+// summary: Reject a comment.
+// description: Hides a comment from its thread. Only editors can moderate.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CommentResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) RejectComment(w http.ResponseWriter, r *http.Request) {
+	app.moderateComment(w, r, models.CommentRejected)
+}
+
+// swagger:operation POST /moderation/comments/{id}/spam SpamComment
+// ---
+// summary: Mark a comment as spam.
+// description: Hides a comment from its thread as spam. Only editors can moderate.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CommentResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) SpamComment(w http.ResponseWriter, r *http.Request) {
+	app.moderateComment(w, r, models.CommentSpam)
+}
+
+// moderateComment moves the comment in the URL to status and writes the
+// moderated comment.
+func (app *Controller) moderateComment(w http.ResponseWriter, r *http.Request, status string) {
+	commentID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Println(appconst.Parsingcomment, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingcomment + err.Error()})
+		return
+	}
+
+	comment, err := app.CommentService.ModerateComment(commentID, status, principal(r))
+	if err != nil {
+		log.Println(appconst.Notmoderated, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: comment, Status: http.StatusOK, Message: appconst.Success})
+}
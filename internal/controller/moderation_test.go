@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"backend/services/comments"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentQueue(t *testing.T) {
+	testCases := []struct {
+		name               string
+		actor              models.Principal
+		target             string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:   "Pending",
+			actor:  editor,
+			target: "/moderation/comments?limit=1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().CommentQueue(models.CommentPending, gomock.Any()).Return(&models.CommentPage{Comments: []models.Comment{{ID: 4, Status: models.CommentPending, SpamScore: 0.6}}, PageInfo: models.PageInfo{Total: 2, HasNext: true}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Unknown Status",
+			actor:              editor,
+			target:             "/moderation/comments?status=hidden",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "status must be one of: pending, approved, rejected, spam",
+		},
+		{
+			name:               "Not A Moderator",
+			actor:              models.Principal{UserID: 2, Username: "author", Role: models.RoleAuthor},
+			target:             "/moderation/comments",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				CommentService: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+			}
+
+			w := httptest.NewRecorder()
+			app.CommentQueue(w, signedIn(httptest.NewRequest("GET", tc.target, nil), tc.actor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, response.Message)
+			}
+		})
+	}
+}
+
+func TestModerateComment(t *testing.T) {
+	testCases := []struct {
+		name               string
+		handler            func(app *Controller) http.HandlerFunc
+		id                 string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:    "Approve",
+			handler: func(app *Controller) http.HandlerFunc { return app.ApproveComment },
+			id:      "4",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SetCommentStatus(4, models.CommentApproved, editor.Username).Return(&models.Comment{ID: 4, Status: models.CommentApproved}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:    "Reject",
+			handler: func(app *Controller) http.HandlerFunc { return app.RejectComment },
+			id:      "4",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SetCommentStatus(4, models.CommentRejected, editor.Username).Return(&models.Comment{ID: 4, Status: models.CommentRejected}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:    "Spam",
+			handler: func(app *Controller) http.HandlerFunc { return app.SpamComment },
+			id:      "4",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SetCommentStatus(4, models.CommentSpam, editor.Username).Return(&models.Comment{ID: 4, Status: models.CommentSpam}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Error Parsing ID",
+			handler:            func(app *Controller) http.HandlerFunc { return app.ApproveComment },
+			id:                 "abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Parsingcomment + `strconv.Atoi: parsing "abc": invalid syntax`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				CommentService: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+			}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, signedIn(newCommentRequest("POST", "/moderation/comments/"+tc.id+"/approve", tc.id, "", ""), editor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
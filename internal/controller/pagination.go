@@ -0,0 +1,107 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// listParams reads the limit, offset and cursor query parameters. Offset
+// paging is used when offset is given, keyset paging otherwise.
+func listParams(r *http.Request) (models.ListParams, error) {
+	var params models.ListParams
+	query := r.URL.Query()
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > models.MaxPageSize {
+			return params, apperrors.Validation(fmt.Sprintf(appconst.Invalidlimit, models.MaxPageSize), err)
+		}
+		params.Limit = limit
+	}
+
+	if value := query.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return params, apperrors.Validation(appconst.Invalidoffset, err)
+		}
+		params.Offset = offset
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		if query.Has("offset") {
+			return params, apperrors.Validation(appconst.Cursorwithoffset, nil)
+		}
+		cursor, err := models.DecodeCursor(value)
+		if err != nil {
+			return params, apperrors.Validation(appconst.Invalidcursor, err)
+		}
+		params.Cursor = cursor
+	}
+
+	params.Normalize()
+	return params, nil
+}
+
+// pagination builds the pagination block of the response envelope and the
+// matching RFC 8288 Link header for a page. Links use offset paging when
+// byOffset is set and the page cursors otherwise.
+func pagination(r *http.Request, params models.ListParams, page models.PageInfo, byOffset bool) (*models.Pagination, http.Header) {
+	result := &models.Pagination{Total: page.Total, Limit: params.Limit}
+
+	first := pageURL(r.URL, params.Limit, nil)
+	if byOffset {
+		result.Offset = params.Offset
+		first = pageURL(r.URL, params.Limit, map[string]string{"offset": "0"})
+	}
+
+	if page.HasNext {
+		if byOffset {
+			result.Next = pageURL(r.URL, params.Limit, map[string]string{"offset": strconv.Itoa(params.Offset + params.Limit)})
+		} else {
+			result.Next = pageURL(r.URL, params.Limit, map[string]string{"cursor": page.NextCursor})
+		}
+	}
+
+	if page.HasPrev {
+		if byOffset {
+			prevOffset := params.Offset - params.Limit
+			if prevOffset < 0 {
+				prevOffset = 0
+			}
+			result.Prev = pageURL(r.URL, params.Limit, map[string]string{"offset": strconv.Itoa(prevOffset)})
+		} else {
+			result.Prev = pageURL(r.URL, params.Limit, map[string]string{"cursor": page.PrevCursor})
+		}
+	}
+
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, first)}
+	if result.Next != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, result.Next))
+	}
+	if result.Prev != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, result.Prev))
+	}
+
+	return result, http.Header{"Link": []string{strings.Join(links, ", ")}}
+}
+
+// pageURL returns the request URL with its paging parameters replaced, so
+// any other query parameters are kept in the links.
+func pageURL(u *url.URL, limit int, paging map[string]string) string {
+	query := u.Query()
+	query.Del("offset")
+	query.Del("cursor")
+	query.Set("limit", strconv.Itoa(limit))
+	for key, value := range paging {
+		query.Set(key, value)
+	}
+
+	link := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return link.String()
+}
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"backend/mocks"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllArticle_Pagination(t *testing.T) {
+	cursor := models.Cursor{Sort: "title", Value: "Article 2", ID: 2, Direction: models.CursorNext}.Encode()
+
+	testCases := []struct {
+		name               string
+		url                string
+		expectedParams     models.ListParams
+		page               *models.ArticlePage
+		expectedPagination string
+		expectedLink       string
+	}{
+		{
+			name:               "Offset paging",
+			url:                "/articles?limit=2&offset=2",
+			expectedParams:     models.ListParams{Limit: 2, Offset: 2, Sort: models.Sort{Field: models.SortTitle}},
+			page:               &models.ArticlePage{Articles: []models.Article{{ID: 3}, {ID: 4}}, PageInfo: models.PageInfo{Total: 10, HasNext: true, HasPrev: true}},
+			expectedPagination: `{"total":10,"limit":2,"offset":2,"next":"/articles?limit=2&offset=4","prev":"/articles?limit=2&offset=0"}`,
+			expectedLink:       `</articles?limit=2&offset=0>; rel="first", </articles?limit=2&offset=4>; rel="next", </articles?limit=2&offset=0>; rel="prev"`,
+		},
+		{
+			name:               "Keyset paging from the first page",
+			url:                "/articles?limit=2",
+			expectedParams:     models.ListParams{Limit: 2, Sort: models.Sort{Field: models.SortTitle}},
+			page:               &models.ArticlePage{Articles: []models.Article{{ID: 1}, {ID: 2}}, PageInfo: models.PageInfo{Total: 10, HasNext: true, NextCursor: cursor}},
+			expectedPagination: `{"total":10,"limit":2,"next":"/articles?cursor=` + cursor + `&limit=2"}`,
+			expectedLink:       `</articles?limit=2>; rel="first", </articles?cursor=` + cursor + `&limit=2>; rel="next"`,
+		},
+		{
+			name:               "Keyset paging with a cursor",
+			url:                "/articles?cursor=" + cursor,
+			expectedParams:     models.ListParams{Limit: models.DefaultPageSize, Cursor: &models.Cursor{Sort: "title", Value: "Article 2", ID: 2, Direction: models.CursorNext}, Sort: models.Sort{Field: models.SortTitle}},
+			page:               &models.ArticlePage{Articles: []models.Article{{ID: 3}}, PageInfo: models.PageInfo{Total: 3, HasPrev: true, PrevCursor: "prev"}},
+			expectedPagination: `{"total":3,"limit":20,"prev":"/articles?cursor=prev&limit=20"}`,
+			expectedLink:       `</articles?limit=20>; rel="first", </articles?cursor=prev&limit=20>; rel="prev"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			mockDB.EXPECT().AllArticles(tc.expectedParams).Return(tc.page, nil)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.AllArticle(w, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.expectedLink, w.Header().Get("Link"))
+
+			var body struct {
+				Pagination interface{} `json:"pagination"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			pagination, _ := json.Marshal(body.Pagination)
+			assert.JSONEq(t, tc.expectedPagination, string(pagination))
+		})
+	}
+}
+
+func TestAllArticle_InvalidPagination(t *testing.T) {
+	testCases := []struct {
+		name            string
+		url             string
+		expectedMessage string
+	}{
+		{name: "Limit too large", url: "/articles?limit=1000", expectedMessage: "limit must be a number between 1 and 100"},
+		{name: "Limit not a number", url: "/articles?limit=ten", expectedMessage: "limit must be a number between 1 and 100"},
+		{name: "Negative offset", url: "/articles?offset=-1", expectedMessage: "offset must be a positive number"},
+		{name: "Malformed cursor", url: "/articles?cursor=not-a-cursor", expectedMessage: "cursor is invalid"},
+		{name: "Cursor and offset", url: "/articles?offset=1&cursor=abc", expectedMessage: "cursor and offset cannot be combined"},
+	}
+
+	app := &Controller{}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			app.AllArticle(w, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.JSONEq(t, `{"status":400,"message":"`+tc.expectedMessage+`","data":null}`, w.Body.String())
+		})
+	}
+}
+
+func TestSearchArticles(t *testing.T) {
+	testCases := []struct {
+		name               string
+		url                string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedResponse   string
+		expectedLink       string
+	}{
+		{
+			name: "Ranked results with offset links",
+			url:  "/articles/search?q=docker&limit=1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SearchArticles("docker", models.ListParams{Limit: 1, Sort: models.Sort{Field: models.SortTitle}}).Return(&models.SearchPage{
+					Results:  []models.SearchResult{{Article: models.Article{ID: 1, Title: "Docker"}, Rank: 0.5, Snippet: "<mark>Docker</mark>"}},
+					PageInfo: models.PageInfo{Total: 2, HasNext: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":[{"id":1,"title":"Docker","rank":0.5,"snippet":"<mark>Docker</mark>"}],"pagination":{"total":2,"limit":1,"next":"/articles/search?limit=1&offset=1&q=docker"}}`,
+			expectedLink:       `</articles/search?limit=1&offset=0&q=docker>; rel="first", </articles/search?limit=1&offset=1&q=docker>; rel="next"`,
+		},
+		{
+			name:               "Missing query",
+			url:                "/articles/search",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"q must contain a search query","data":null}`,
+		},
+		{
+			name:               "Cursor is rejected",
+			url:                "/articles/search?q=docker&cursor=" + models.Cursor{Direction: models.CursorNext}.Encode(),
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"search results are paged with offset, not cursor","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			app.SearchArticles(w, httptest.NewRequest("GET", tc.url, nil))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+			assert.Equal(t, tc.expectedLink, w.Header().Get("Link"))
+		})
+	}
+}
+
+func TestScheduledArticles(t *testing.T) {
+	editor := models.Principal{UserID: 2, Username: "grace", Role: models.RoleEditor}
+
+	testCases := []struct {
+		name               string
+		url                string
+		principal          models.Principal
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:      "Upcoming articles with offset links",
+			url:       "/articles/scheduled?limit=1",
+			principal: editor,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ScheduledArticles(models.ListParams{Limit: 1, Sort: models.Sort{Field: models.SortTitle}, Viewer: editor, AllStatuses: true}).Return(&models.ArticlePage{
+					Articles: []models.Article{{ID: 1, Title: "Soon", Status: models.StatusInReview}},
+					PageInfo: models.PageInfo{Total: 2, HasNext: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":[{"id":1,"title":"Soon","status":"in_review"}],"pagination":{"total":2,"limit":1,"next":"/articles/scheduled?limit=1&offset=1"}}`,
+		},
+		{
+			name:               "Cursor is rejected",
+			url:                "/articles/scheduled?cursor=" + models.Cursor{Direction: models.CursorNext}.Encode(),
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"this listing is paged with offset, not cursor","data":null}`,
+		},
+		{
+			name:               "Anonymous",
+			url:                "/articles/scheduled",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"status":401,"message":"sign in to do this","data":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest("GET", tc.url, nil)
+			app.ScheduledArticles(w, r.WithContext(auth.NewContext(r.Context(), tc.principal)))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
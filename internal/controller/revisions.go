@@ -0,0 +1,205 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:route GET /articles/{id}/revisions articleRevisions
+//
+// List the revisions of an article, newest first, paged with limit and
+// offset. Every change to the article is a revision. Authors can read the
+// history of their own articles, editors of any article.
+//
+// Responses:
+//
+//	200: RevisionListResponse
+//	400: ErrorResponse
+//	401: ErrorResponse
+//	403: ErrorResponse
+//	404: ErrorResponse
+//	500: ErrorResponse
+
+func (app *Controller) ArticleRevisions(w http.ResponseWriter, r *http.Request) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return
+	}
+
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Offsetonly, nil)
+	}
+	if err != nil {
+		log.Println(appconst.Revisionerror, err)
+		writeError(w, err)
+		return
+	}
+
+	page, err := app.ArticleService.GetRevisions(articleID, principal(r), params)
+	if err != nil {
+		log.Println(appconst.Revisionerror, err)
+		writeError(w, err)
+		return
+	}
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Revisions
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
+
+// swagger:route GET /articles/{id}/revisions/{rev} getRevision
+//
+// Retrieve one revision of an article.
+//
+// Authors can read the revisions of their own articles, editors of any
+// article.
+//
+// Responses:
+//
+//	200: RevisionResponse
+//	400: ErrorResponse
+//	401: ErrorResponse
+//	403: ErrorResponse
+//	404: ErrorResponse
+//	500: ErrorResponse
+
+func (app *Controller) GetRevision(w http.ResponseWriter, r *http.Request) {
+	articleID, revision, ok := revisionParams(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := app.ArticleService.GetRevision(articleID, revision, principal(r))
+	if err != nil {
+		log.Println(appconst.Revisionerror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: result, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:route GET /articles/{id}/revisions/{rev}/diff diffRevisions
+//
+// Compare a revision with an older one, line by line. The title and content
+// are diffed separately; by default the revision is compared with the one
+// before it, and from=0 compares it with an empty article. Authors can diff
+// the revisions of their own articles, editors of any article.
+//
+// Responses:
+//
+//	200: RevisionDiffResponse
+//	400: ErrorResponse
+//	401: ErrorResponse
+//	403: ErrorResponse
+//	404: ErrorResponse
+//	500: ErrorResponse
+
+func (app *Controller) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	articleID, revision, ok := revisionParams(w, r)
+	if !ok {
+		return
+	}
+
+	from := revision - 1
+	if value := r.URL.Query().Get("from"); value != "" {
+		var err error
+		from, err = strconv.Atoi(value)
+		if err != nil || from < 0 {
+			log.Println(appconst.Revisionerror, err)
+			writeError(w, apperrors.Validation(appconst.Invalidfrom, err))
+			return
+		}
+	}
+
+	diff, err := app.ArticleService.DiffRevisions(articleID, from, revision, principal(r))
+	if err != nil {
+		log.Println(appconst.Revisionerror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: diff, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation POST /articles/{id}/revisions/{rev}/restore RestoreRevision
+// ---
+// summary: Restore a revision.
+// description: Brings back the title and content of an older revision as a new revision. The author, status and schedule of the article are kept. Authors can restore their own articles, editors any article.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: rev
+//   in: path
+//   required: true
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	articleID, revision, ok := revisionParams(w, r)
+	if !ok {
+		return
+	}
+
+	article, err := app.ArticleService.RestoreRevision(articleID, revision, principal(r))
+	if err != nil {
+		log.Println(appconst.Restoreerror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: article, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// revisionParams reads the article ID and revision number from the URL,
+// writing a bad request response when either is not a number.
+func revisionParams(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	articleID, err := articleIDParam(r)
+	if err != nil {
+		log.Println(appconst.Parsingarticle, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingarticle + err.Error()})
+		return 0, 0, false
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil {
+		log.Println(appconst.Parsingrevision, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsingrevision + err.Error()})
+		return 0, 0, false
+	}
+
+	return articleID, revision, true
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRevisionRequest builds a request for a revision route with its URL parameters set
+func newRevisionRequest(method, url, id, rev string) *http.Request {
+	r := httptest.NewRequest(method, url, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	rctx.URLParams.Add("rev", rev)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestRevisionHandlers(t *testing.T) {
+	published := &models.Article{ID: 1, Title: "Now", Author: "Ada", Status: models.StatusPublished, Revision: 2}
+
+	testCases := []struct {
+		name               string
+		request            *http.Request
+		handler            func(app *Controller) http.HandlerFunc
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		{
+			name:    "List revisions with offset links",
+			request: signedIn(newRevisionRequest("GET", "/articles/1/revisions?limit=1", "1", ""), editor),
+			handler: func(app *Controller) http.HandlerFunc { return app.ArticleRevisions },
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+				db.EXPECT().ArticleRevisions(1, models.ListParams{Limit: 1, Sort: models.Sort{Field: models.SortTitle}}).Return(&models.RevisionPage{
+					Revisions: []models.Revision{{ArticleID: 1, Revision: 2, Title: "Now", Author: "Ada", Status: models.StatusPublished, CreatedBy: "Ada"}},
+					PageInfo:  models.PageInfo{Total: 2, HasNext: true},
+				}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":[{"article_id":1,"revision":2,"title":"Now","content":"","author":"Ada","status":"published","created_by":"Ada"}],"pagination":{"total":2,"limit":1,"next":"/articles/1/revisions?limit=1&offset=1"}}`,
+		},
+		{
+			name:    "Get a revision",
+			request: signedIn(newRevisionRequest("GET", "/articles/1/revisions/1", "1", "1"), editor),
+			handler: func(app *Controller) http.HandlerFunc { return app.GetRevision },
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+				db.EXPECT().ArticleRevision(1, 1).Return(&models.Revision{ArticleID: 1, Revision: 1, Title: "Then", Author: "Ada", Status: models.StatusDraft, CreatedBy: "Ada"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"article_id":1,"revision":1,"title":"Then","content":"","author":"Ada","status":"draft","created_by":"Ada"}}`,
+		},
+		{
+			name:    "Unknown revision",
+			request: signedIn(newRevisionRequest("GET", "/articles/1/revisions/7", "1", "7"), editor),
+			handler: func(app *Controller) http.HandlerFunc { return app.GetRevision },
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+				db.EXPECT().ArticleRevision(1, 7).Return(nil, apperrors.NotFound(appconst.Norevision, nil))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedResponse:   `{"status":404,"message":"No revision found for the given number","data":null}`,
+		},
+		{
+			name:               "Anonymous users cannot read the history",
+			request:            newRevisionRequest("GET", "/articles/1/revisions/1", "1", "1"),
+			handler:            func(app *Controller) http.HandlerFunc { return app.GetRevision },
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedResponse:   `{"status":401,"message":"sign in to do this","data":null}`,
+		},
+		{
+			name:    "Other authors cannot read the history",
+			request: signedIn(newRevisionRequest("GET", "/articles/1/revisions", "1", ""), models.Principal{UserID: 9, Username: "Bob", Role: models.RoleAuthor}),
+			handler: func(app *Controller) http.HandlerFunc { return app.ArticleRevisions },
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedResponse:   `{"status":403,"message":"you are not allowed to do this","data":null}`,
+		},
+		{
+			name:               "Invalid revision number",
+			request:            newRevisionRequest("GET", "/articles/1/revisions/latest", "1", "latest"),
+			handler:            func(app *Controller) http.HandlerFunc { return app.GetRevision },
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"Error parsing revision number: strconv.Atoi: parsing \"latest\": invalid syntax","data":null}`,
+		},
+		{
+			name:    "Diff with the previous revision by default",
+			request: signedIn(newRevisionRequest("GET", "/articles/1/revisions/2/diff", "1", "2"), editor),
+			handler: func(app *Controller) http.HandlerFunc { return app.DiffRevisions },
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+				db.EXPECT().ArticleRevision(1, 1).Return(&models.Revision{Title: "Then"}, nil)
+				db.EXPECT().ArticleRevision(1, 2).Return(&models.Revision{Title: "Now"}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"article_id":1,"from":1,"to":2,"title":[{"op":"delete","text":"Then"},{"op":"insert","text":"Now"}],"content":[]}}`,
+		},
+		{
+			name:               "Invalid diff base",
+			request:            newRevisionRequest("GET", "/articles/1/revisions/2/diff?from=-1", "1", "2"),
+			handler:            func(app *Controller) http.HandlerFunc { return app.DiffRevisions },
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedResponse:   `{"status":400,"message":"from must be a revision number","data":null}`,
+		},
+		{
+			name:    "Restore a revision",
+			request: signedIn(newRevisionRequest("POST", "/articles/1/revisions/1/restore", "1", "1"), editor),
+			handler: func(app *Controller) http.HandlerFunc { return app.RestoreRevision },
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Title: "Now", Author: "Ada", AuthorID: 5, Status: models.StatusPublished, Revision: 2}, nil)
+				db.EXPECT().ArticleRevision(1, 1).Return(&models.Revision{Title: "Then", Author: "Ada"}, nil)
+				db.EXPECT().OneUser(5).Return(&models.User{ID: 5, Username: "Ada"}, nil)
+				db.EXPECT().UpdateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) error {
+					article.Revision = 3
+					return nil
+				})
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedResponse:   `{"status":200,"message":"Success","data":{"id":1,"title":"Then","slug":"then","author":"Ada","author_id":5,"status":"published","updated_by":"Editor","revision":3}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				ArticleService: services.NewArticleService(mockDB),
+			}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, tc.request)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.JSONEq(t, tc.expectedResponse, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/sitemap"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:operation GET /sitemap.xml Sitemap
+// ---
+// summary: Sitemap of the published articles.
+// description: Every published article with when it was last changed and its cover image. Once there are more than 50000 articles this is a sitemap index linking to sitemaps of 50000 articles each. Sitemaps carry an ETag and a Last-Modified time like feeds.
+// produces:
+// - application/xml
+// responses:
+//   200:
+//     description: The sitemap or sitemap index
+//   304:
+//     description: The sitemap has not changed
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) Sitemap(w http.ResponseWriter, r *http.Request) {
+	pages, err := app.ArticleService.GetSitemapPages()
+	if err != nil {
+		log.Println(appconst.Sitemaperror, err)
+		writeError(w, err)
+		return
+	}
+	if len(pages) <= 1 {
+		app.serveSitemap(w, r, 1)
+		return
+	}
+
+	var modified time.Time
+	sitemaps := make([]sitemap.Sitemap, len(pages))
+	for i, page := range pages {
+		sitemaps[i] = sitemap.Sitemap{Loc: app.Site.Link(fmt.Sprintf("/sitemaps/%d.xml", page.Number)), LastMod: page.Modified}
+		if page.Modified.After(modified) {
+			modified = page.Modified
+		}
+	}
+	body, err := sitemap.WriteIndex(sitemaps)
+	if err != nil {
+		log.Println(appconst.Sitemaperror, err)
+		writeError(w, err)
+		return
+	}
+	serveDocument(w, r, sitemap.ContentType, modified, body)
+}
+
+// swagger:operation GET /sitemaps/{page}.xml SitemapPage
+// ---
+// summary: One sitemap of the published articles.
+// description: The published articles on a page of the sitemap index, 50000 to a page.
+// produces:
+// - application/xml
+// parameters:
+// - name: page
+//   in: path
+//   type: integer
+//   minimum: 1
+//   required: true
+// responses:
+//   200:
+//     description: The sitemap
+//   304:
+//     description: The sitemap has not changed
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) SitemapPage(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(chi.URLParam(r, "page"))
+	if err != nil {
+		writeError(w, apperrors.NotFound(appconst.Nositemap, err))
+		return
+	}
+	app.serveSitemap(w, r, page)
+}
+
+// serveSitemap writes the sitemap of the articles on a page. Every link in
+// it is absolute, as sitemaps require.
+func (app *Controller) serveSitemap(w http.ResponseWriter, r *http.Request, page int) {
+	entries, err := app.ArticleService.GetSitemap(page)
+	if err != nil {
+		log.Println(appconst.Sitemaperror, err)
+		writeError(w, err)
+		return
+	}
+
+	var modified time.Time
+	urls := make([]sitemap.URL, len(entries))
+	for i, entry := range entries {
+		urls[i] = sitemap.URL{
+			Loc:     app.Site.ArticleLink(&models.Article{Slug: entry.Slug}),
+			LastMod: entry.Modified,
+		}
+		if entry.CoverURL != "" {
+			urls[i].Images = []string{app.Site.Link(entry.CoverURL)}
+		}
+		if entry.Modified.After(modified) {
+			modified = entry.Modified
+		}
+	}
+	body, err := sitemap.Write(urls)
+	if err != nil {
+		log.Println(appconst.Sitemaperror, err)
+		writeError(w, err)
+		return
+	}
+	serveDocument(w, r, sitemap.ContentType, modified, body)
+}
+
+// swagger:operation GET /robots.txt RobotsTxt
+// ---
+// summary: Rules for crawlers.
+// description: Keeps crawlers out of the API paths that are not public and points them to the sitemap, unless the server is given a robots.txt of its own.
+// produces:
+// - text/plain
+// responses:
+//   200:
+//     description: The robots.txt
+
+func (app *Controller) RobotsTxt(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age="+feedMaxAge)
+	_, _ = io.WriteString(w, app.Robots)
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"backend/mocks"
+	"backend/pkg/models"
+	"backend/pkg/sitemap"
+	services "backend/services/articles"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSitemaps(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	entries := []models.SitemapEntry{
+		{ArticleID: 2, Slug: "fish-chips", Modified: modified, CoverURL: "/media/3/variants/1600"},
+		{ArticleID: 1, Slug: "no-cover", Modified: modified.Add(-time.Hour)},
+	}
+
+	testCases := []struct {
+		name               string
+		handler            func(app *Controller) http.HandlerFunc
+		path               string
+		params             map[string]string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedHeader     http.Header
+		expectedContains   []string
+		unexpected         []string
+	}{
+		{
+			name:    "One sitemap",
+			handler: func(app *Controller) http.HandlerFunc { return app.Sitemap },
+			path:    "/sitemap.xml",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SitemapPages(sitemap.MaxURLs).Return([]models.SitemapPage{{Number: 1, Modified: modified}}, nil)
+				db.EXPECT().SitemapEntries(1, sitemap.MaxURLs).Return(entries, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type":  {"application/xml; charset=utf-8"},
+				"Last-Modified": {"Fri, 01 Mar 2024 09:30:00 GMT"},
+				"Cache-Control": {"public, max-age=300"},
+			},
+			expectedContains: []string{
+				"<urlset ",
+				"<loc>https://blog.example.com/articles/by-slug/fish-chips</loc>\n    <lastmod>2024-03-01T09:30:00Z</lastmod>",
+				"<image:loc>https://blog.example.com/media/3/variants/1600</image:loc>",
+				"<loc>https://blog.example.com/articles/by-slug/no-cover</loc>\n    <lastmod>2024-03-01T08:30:00Z</lastmod>\n  </url>",
+			},
+		},
+		{
+			name:    "Nothing published",
+			handler: func(app *Controller) http.HandlerFunc { return app.Sitemap },
+			path:    "/sitemap.xml",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SitemapPages(sitemap.MaxURLs).Return([]models.SitemapPage{}, nil)
+				db.EXPECT().SitemapEntries(1, sitemap.MaxURLs).Return([]models.SitemapEntry{}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedContains:   []string{"<urlset "},
+		},
+		{
+			name:    "Sitemap index",
+			handler: func(app *Controller) http.HandlerFunc { return app.Sitemap },
+			path:    "/sitemap.xml",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SitemapPages(sitemap.MaxURLs).Return([]models.SitemapPage{{Number: 1, Modified: modified}, {Number: 2, Modified: modified.Add(time.Hour)}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedHeader:     http.Header{"Last-Modified": {"Fri, 01 Mar 2024 10:30:00 GMT"}},
+			expectedContains: []string{
+				"<sitemapindex ",
+				"<loc>https://blog.example.com/sitemaps/1.xml</loc>\n    <lastmod>2024-03-01T09:30:00Z</lastmod>",
+				"<loc>https://blog.example.com/sitemaps/2.xml</loc>\n    <lastmod>2024-03-01T10:30:00Z</lastmod>",
+			},
+			unexpected: []string{"<urlset "},
+		},
+		{
+			name:    "Page of the index",
+			handler: func(app *Controller) http.HandlerFunc { return app.SitemapPage },
+			path:    "/sitemaps/2.xml",
+			params:  map[string]string{"page": "2"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SitemapEntries(2, sitemap.MaxURLs).Return(entries, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedContains:   []string{"<loc>https://blog.example.com/articles/by-slug/fish-chips</loc>"},
+		},
+		{
+			name:    "Page past the last",
+			handler: func(app *Controller) http.HandlerFunc { return app.SitemapPage },
+			path:    "/sitemaps/9.xml",
+			params:  map[string]string{"page": "9"},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SitemapEntries(9, sitemap.MaxURLs).Return([]models.SitemapEntry{}, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedContains:   []string{`"message":"No sitemap found with this number"`},
+		},
+		{
+			name:               "Page that is not a number",
+			handler:            func(app *Controller) http.HandlerFunc { return app.SitemapPage },
+			path:               "/sitemaps/index.xml",
+			params:             map[string]string{"page": "index"},
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+			app := &Controller{ArticleService: services.NewArticleService(mockDB), Site: models.NewSite("Blog", "https://blog.example.com/")}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, newFeedRequest(tc.path, tc.params, nil))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			for name, values := range tc.expectedHeader {
+				assert.Equal(t, values, w.Header()[name], name)
+			}
+			for _, expected := range tc.expectedContains {
+				assert.Contains(t, w.Body.String(), expected)
+			}
+			for _, unexpected := range tc.unexpected {
+				assert.NotContains(t, w.Body.String(), unexpected)
+			}
+		})
+	}
+}
+
+func TestRobotsTxt(t *testing.T) {
+	app := &Controller{Robots: "User-agent: *\nDisallow: /auth/\n"}
+
+	w := httptest.NewRecorder()
+	app.RobotsTxt(w, httptest.NewRequest("GET", "/robots.txt", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "User-agent: *\nDisallow: /auth/\n", w.Body.String())
+}
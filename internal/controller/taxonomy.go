@@ -0,0 +1,431 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:operation GET /tags ListTags
+// ---
+// summary: List tags.
+// description: Lists the tags sorted by name, each with the number of published articles that have it, paged with limit and offset.
+// parameters:
+// - name: limit
+//   in: query
+//   type: integer
+// - name: offset
+//   in: query
+//   type: integer
+// responses:
+//   200:
+//     $ref: '#/responses/TagListResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ListTags(w http.ResponseWriter, r *http.Request) {
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Offsetonly, nil)
+	}
+	if err != nil {
+		log.Println(appconst.Taglist, err)
+		writeError(w, err)
+		return
+	}
+
+	page, err := app.TaxonomyService.GetTags(params)
+	if err != nil {
+		log.Println(appconst.Taglist, err)
+		writeError(w, err)
+		return
+	}
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Tags
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
+
+// swagger:operation GET /tags/{slug}/articles TagArticles
+// ---
+// summary: List the articles with a tag.
+// description: Lists the articles with a tag, paged, sorted and filtered like GET /articles.
+// parameters:
+// - name: slug
+//   in: path
+//   required: true
+//   type: string
+// - name: limit
+//   in: query
+//   type: integer
+// - name: offset
+//   in: query
+//   type: integer
+// - name: cursor
+//   in: query
+//   type: string
+// - name: sort
+//   in: query
+//   type: string
+// - name: format
+//   in: query
+//   type: string
+//   description: 'Format of the content: markdown, the default, or html rendered from it'
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleListResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) TagArticles(w http.ResponseWriter, r *http.Request) {
+	app.taxonomyArticles(w, r, app.TaxonomyService.GetTagArticles)
+}
+
+// swagger:operation PUT /tags/{slug} RenameTag
+// ---
+// summary: Rename a tag.
+// description: Gives a tag a new name and the slug made from it. The tag keeps its articles. A tag cannot be renamed to the slug of another tag; merge the two instead. Only admins can manage tags.
+// parameters:
+// - name: slug
+//   in: path
+//   required: true
+//   type: string
+// - name: tag
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/TagRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/TagResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) RenameTag(w http.ResponseWriter, r *http.Request) {
+	var request models.TagRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	tag, err := app.TaxonomyService.RenameTag(chi.URLParam(r, "slug"), request, principal(r))
+	if err != nil {
+		log.Println(appconst.Tagnotsaved, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: tag, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation POST /tags/{slug}/merge MergeTag
+// ---
+// summary: Merge a tag into another.
+// description: Moves the articles of a tag to the tag named by into and deletes the first tag. Returns the tag that was kept. Only admins can manage tags.
+// parameters:
+// - name: slug
+//   in: path
+//   required: true
+//   type: string
+// - name: merge
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/TagMergeRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/TagResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) MergeTag(w http.ResponseWriter, r *http.Request) {
+	var request models.TagMergeRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	tag, err := app.TaxonomyService.MergeTag(chi.URLParam(r, "slug"), request, principal(r))
+	if err != nil {
+		log.Println(appconst.Tagsnotmerged, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: tag, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation GET /categories ListCategories
+// ---
+// summary: List categories.
+// description: Lists the category tree. Top level categories are sorted by name and hold the categories below them in children. Each category has the number of published articles in it.
+// responses:
+//   200:
+//     $ref: '#/responses/CategoryListResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := app.TaxonomyService.GetCategories()
+	if err != nil {
+		log.Println(appconst.Categorylist, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: categories, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation GET /categories/{slug}/articles CategoryArticles
+// ---
+// summary: List the articles in a category.
+// description: Lists the articles in a category or in the categories below it, paged, sorted and filtered like GET /articles.
+// parameters:
+// - name: slug
+//   in: path
+//   required: true
+//   type: string
+// - name: limit
+//   in: query
+//   type: integer
+// - name: offset
+//   in: query
+//   type: integer
+// - name: cursor
+//   in: query
+//   type: string
+// - name: sort
+//   in: query
+//   type: string
+// - name: format
+//   in: query
+//   type: string
+//   description: 'Format of the content: markdown, the default, or html rendered from it'
+// responses:
+//   200:
+//     $ref: '#/responses/ArticleListResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) CategoryArticles(w http.ResponseWriter, r *http.Request) {
+	app.taxonomyArticles(w, r, app.TaxonomyService.GetCategoryArticles)
+}
+
+// swagger:operation POST /categories CreateCategory
+// ---
+// summary: Create a category.
+// description: Adds a category at the top level, or below the category in parent_id. Its slug is made from its name. Only admins can manage categories.
+// parameters:
+// - name: category
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/CategoryRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   201:
+//     $ref: '#/responses/CategoryResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var request models.CategoryRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	category, err := app.TaxonomyService.CreateCategory(request, principal(r))
+	if err != nil {
+		log.Println(appconst.Categoryerror, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusCreated, models.Response{Data: category, Status: http.StatusCreated, Message: appconst.Success})
+}
+
+// swagger:operation PUT /categories/{slug} UpdateCategory
+// ---
+// summary: Update a category.
+// description: Renames a category, or moves it with the categories below it under parent_id. A category without parent_id moves to the top level. A category cannot be moved below itself. Only admins can manage categories.
+// parameters:
+// - name: slug
+//   in: path
+//   required: true
+//   type: string
+// - name: category
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/CategoryRequest'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CategoryResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	var request models.CategoryRequest
+	err := utility.ReadJSON(w, r, &request)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	category, err := app.TaxonomyService.UpdateCategory(chi.URLParam(r, "slug"), request, principal(r))
+	if err != nil {
+		log.Println(appconst.Categorynotsaved, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: category, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// swagger:operation DELETE /categories/{slug} DeleteCategory
+// ---
+// summary: Delete a category.
+// description: Deletes a category that has no categories below it. Its articles are kept. Only admins can manage categories.
+// parameters:
+// - name: slug
+//   in: path
+//   required: true
+//   type: string
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/CategoryResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	err := app.TaxonomyService.DeleteCategory(slug, principal(r))
+	if err != nil {
+		log.Println(appconst.Categorynotdelete, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: models.Category{Slug: slug}, Status: http.StatusOK, Message: appconst.Success})
+}
+
+// taxonomyArticles writes the page of articles that list returns for the
+// tag or category in the URL.
+func (app *Controller) taxonomyArticles(w http.ResponseWriter, r *http.Request, list func(string, models.ListParams) (*models.ArticlePage, error)) {
+	params, err := articleListParams(r)
+	var format string
+	if err == nil {
+		format, err = articleFormat(r)
+	}
+	if err != nil {
+		log.Println(appconst.Errorconst, err)
+		writeError(w, err)
+		return
+	}
+	params.Viewer = principal(r)
+
+	page, err := list(chi.URLParam(r, "slug"), params)
+	if err != nil {
+		log.Println(appconst.Errorconst, err)
+		writeError(w, err)
+		return
+	}
+	formatArticles(page.Articles, format)
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Articles
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, r.URL.Query().Has("offset"))
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
@@ -0,0 +1,305 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/services/taxonomy"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSlugRequest builds a request carrying the chi "slug" URL parameter.
+func newSlugRequest(method, target, slug, body string) *http.Request {
+	r := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("slug", slug)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestListTags(t *testing.T) {
+	testCases := []struct {
+		name               string
+		target             string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+		expectedLink       string
+	}{
+		{
+			name:   "Page Of Tags",
+			target: "/tags?limit=1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().Tags(gomock.Any()).Return(&models.TagPage{Tags: []models.Tag{{ID: 1, Name: "Go", Slug: "go", Articles: 3}}, PageInfo: models.PageInfo{Total: 2, HasNext: true}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+			expectedLink:       `</tags?limit=1&offset=1>; rel="next"`,
+		},
+		{
+			name:               "Cursor",
+			target:             "/tags?cursor=abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{TaxonomyService: taxonomy.NewTaxonomyService(mockDB)}
+
+			w := httptest.NewRecorder()
+			app.ListTags(w, httptest.NewRequest("GET", tc.target, nil))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			if tc.expectedMessage != "" {
+				assert.Equal(t, tc.expectedMessage, response.Message)
+			}
+			if tc.expectedLink != "" {
+				assert.Contains(t, w.Header().Get("Link"), tc.expectedLink)
+			}
+		})
+	}
+}
+
+func TestTagArticles(t *testing.T) {
+	testCases := []struct {
+		name               string
+		slug               string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+	}{
+		{
+			name: "Articles With The Tag",
+			slug: "go",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("go").Return(&models.Tag{ID: 1, Name: "Go", Slug: "go"}, nil)
+				db.EXPECT().AllArticles(gomock.Any()).Return(&models.ArticlePage{Articles: []models.Article{{ID: 1, Tags: []string{"Go"}}}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "Unknown Tag",
+			slug: "rust",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("rust").Return(nil, apperrors.NotFound(appconst.Notag, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{TaxonomyService: taxonomy.NewTaxonomyService(mockDB)}
+
+			w := httptest.NewRecorder()
+			app.TagArticles(w, newSlugRequest("GET", "/tags/"+tc.slug+"/articles", tc.slug, ""))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestManageTags(t *testing.T) {
+	testCases := []struct {
+		name               string
+		handler            func(app *Controller) http.HandlerFunc
+		actor              models.Principal
+		body               string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:    "Rename",
+			handler: func(app *Controller) http.HandlerFunc { return app.RenameTag },
+			actor:   admin,
+			body:    `{"name":"Go"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("golang").Return(&models.Tag{ID: 1, Name: "golang", Slug: "golang"}, nil)
+				db.EXPECT().OneTag("go").Return(nil, apperrors.NotFound(appconst.Notag, sql.ErrNoRows))
+				db.EXPECT().RenameTag(&models.Tag{ID: 1, Name: "Go", Slug: "go"}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:    "Merge",
+			handler: func(app *Controller) http.HandlerFunc { return app.MergeTag },
+			actor:   admin,
+			body:    `{"into":"go"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("golang").Return(&models.Tag{ID: 1, Name: "golang", Slug: "golang"}, nil)
+				db.EXPECT().OneTag("go").Return(&models.Tag{ID: 2, Name: "Go", Slug: "go"}, nil).Times(2)
+				db.EXPECT().MergeTags(1, 2).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Not An Admin",
+			handler:            func(app *Controller) http.HandlerFunc { return app.RenameTag },
+			actor:              editor,
+			body:               `{"name":"Go"}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    appconst.Forbidden,
+		},
+		{
+			name:               "Error Parsing JSON",
+			handler:            func(app *Controller) http.HandlerFunc { return app.MergeTag },
+			actor:              admin,
+			body:               `{into}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.JSONparsing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{TaxonomyService: taxonomy.NewTaxonomyService(mockDB)}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, signedIn(newSlugRequest("PUT", "/tags/golang", "golang", tc.body), tc.actor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	parent := 1
+	mockDB.EXPECT().Categories().Return([]models.Category{{ID: 1, Name: "News", Slug: "news"}, {ID: 2, ParentID: &parent, Name: "Tech", Slug: "tech"}}, nil)
+
+	app := &Controller{TaxonomyService: taxonomy.NewTaxonomyService(mockDB)}
+
+	w := httptest.NewRecorder()
+	app.ListCategories(w, httptest.NewRequest("GET", "/categories", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []models.Category `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "tech", response.Data[0].Children[0].Slug)
+}
+
+func TestManageCategories(t *testing.T) {
+	categories := []models.Category{{ID: 1, Name: "News", Slug: "news"}, {ID: 2, Name: "Sport", Slug: "sport"}}
+
+	testCases := []struct {
+		name               string
+		handler            func(app *Controller) http.HandlerFunc
+		method             string
+		body               string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:    "Create",
+			handler: func(app *Controller) http.HandlerFunc { return app.CreateCategory },
+			method:  "POST",
+			body:    `{"name":"Tech","parent_id":1}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().Categories().Return(categories, nil)
+				db.EXPECT().CreateCategory(gomock.Any()).Return(nil)
+			},
+			expectedStatusCode: http.StatusCreated,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:    "Update",
+			handler: func(app *Controller) http.HandlerFunc { return app.UpdateCategory },
+			method:  "PUT",
+			body:    `{"name":"World News"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().Categories().Return(categories, nil)
+				db.EXPECT().UpdateCategory(&models.Category{ID: 1, Name: "World News", Slug: "world-news"}).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:    "Update To A Taken Slug",
+			handler: func(app *Controller) http.HandlerFunc { return app.UpdateCategory },
+			method:  "PUT",
+			body:    `{"name":"Sport"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().Categories().Return(categories, nil)
+			},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    "a category with the slug sport already exists",
+		},
+		{
+			name:    "Delete",
+			handler: func(app *Controller) http.HandlerFunc { return app.DeleteCategory },
+			method:  "DELETE",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().Categories().Return(categories, nil)
+				db.EXPECT().DeleteCategory(1).Return(nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{TaxonomyService: taxonomy.NewTaxonomyService(mockDB)}
+
+			w := httptest.NewRecorder()
+			tc.handler(app)(w, signedIn(newSlugRequest(tc.method, "/categories/news", "news", tc.body), admin))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
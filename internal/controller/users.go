@@ -0,0 +1,127 @@
+package controller
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// swagger:operation GET /users ListUsers
+// ---
+// summary: List users.
+// description: Lists every user by username, paged with limit and offset. Only admins can list users.
+// parameters:
+// - name: limit
+//   in: query
+//   type: integer
+// - name: offset
+//   in: query
+//   type: integer
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/UserListResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) ListUsers(w http.ResponseWriter, r *http.Request) {
+	params, err := listParams(r)
+	if err == nil && params.Cursor != nil {
+		err = apperrors.Validation(appconst.Offsetonly, nil)
+	}
+	if err != nil {
+		log.Println(appconst.Userlist, err)
+		writeError(w, err)
+		return
+	}
+
+	page, err := app.UserService.ListUsers(params, principal(r))
+	if err != nil {
+		log.Println(appconst.Userlist, err)
+		writeError(w, err)
+		return
+	}
+
+	var response models.Response
+	response.Status = http.StatusOK
+	response.Message = appconst.Success
+	response.Data = page.Users
+
+	var links http.Header
+	response.Pagination, links = pagination(r, params, page.PageInfo, true)
+
+	utility.WriteJSON(w, http.StatusOK, response, links)
+}
+
+// swagger:operation PUT /users/{id}/role SetUserRole
+// ---
+// summary: Change the role of a user.
+// description: Makes a user an author, editor or admin. Only admins can change roles, and not their own. The new role applies to access tokens issued after the change.
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   type: integer
+// - name: role
+//   in: body
+//   required: true
+//   schema:
+//     $ref: '#/definitions/RoleChange'
+// security:
+// - bearer: []
+// - apiKey: []
+// responses:
+//   200:
+//     $ref: '#/responses/UserResponse'
+//   400:
+//     $ref: '#/responses/ErrorResponse'
+//   401:
+//     $ref: '#/responses/ErrorResponse'
+//   403:
+//     $ref: '#/responses/ErrorResponse'
+//   404:
+//     $ref: '#/responses/ErrorResponse'
+//   409:
+//     $ref: '#/responses/ErrorResponse'
+//   500:
+//     $ref: '#/responses/ErrorResponse'
+
+func (app *Controller) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		log.Println(appconst.Parsinguser, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.Parsinguser + err.Error()})
+		return
+	}
+
+	var change models.RoleChange
+	err = utility.ReadJSON(w, r, &change)
+	if err != nil {
+		log.Println(appconst.JSONparsing, err)
+		utility.WriteJSON(w, http.StatusBadRequest, models.Response{Data: nil, Status: http.StatusBadRequest, Message: appconst.JSONparsing})
+		return
+	}
+
+	user, err := app.UserService.SetRole(userID, change.Role, principal(r))
+	if err != nil {
+		log.Println(appconst.Rolenotchanged, err)
+		writeError(w, err)
+		return
+	}
+
+	utility.WriteJSON(w, http.StatusOK, models.Response{Data: user, Status: http.StatusOK, Message: appconst.Success})
+}
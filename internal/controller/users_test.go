@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/services/users"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var admin = models.Principal{UserID: 1, Username: "root", Role: models.RoleAdmin}
+
+func TestListUsers(t *testing.T) {
+	testCases := []struct {
+		name               string
+		actor              models.Principal
+		target             string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:   "Admin",
+			actor:  admin,
+			target: "/users?limit=1",
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().AllUsers(gomock.Any()).Return(&models.UserPage{Users: []models.User{{ID: 1, Username: "root", Role: models.RoleAdmin}}, PageInfo: models.PageInfo{Total: 2, HasNext: true}}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:               "Editor",
+			actor:              editor,
+			target:             "/users",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusForbidden,
+			expectedMessage:    appconst.Forbidden,
+		},
+		{
+			name:               "Cursor",
+			actor:              admin,
+			target:             "/users?cursor=abc",
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.Invalidcursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				UserService: users.NewUserService(mockDB, nil),
+			}
+
+			w := httptest.NewRecorder()
+			app.ListUsers(w, signedIn(httptest.NewRequest("GET", tc.target, nil), tc.actor))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+			if w.Code == http.StatusOK {
+				assert.Equal(t, 2, response.Pagination.Total)
+				assert.NotEmpty(t, w.Header().Get("Link"))
+			}
+		})
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		requestBody        string
+		mockDBExpect       func(db *mocks.MockDBInterface)
+		expectedStatusCode int
+		expectedMessage    string
+	}{
+		{
+			name:        "Promote",
+			id:          "3",
+			requestBody: `{"role":"editor"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SetUserRole(3, models.RoleEditor).Return(&models.User{ID: 3, Username: "grace", Role: models.RoleEditor}, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedMessage:    appconst.Success,
+		},
+		{
+			name:        "Unknown User",
+			id:          "9",
+			requestBody: `{"role":"editor"}`,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().SetUserRole(9, models.RoleEditor).Return(nil, apperrors.NotFound(appconst.Nouser, sql.ErrNoRows))
+			},
+			expectedStatusCode: http.StatusNotFound,
+			expectedMessage:    appconst.Nouser,
+		},
+		{
+			name:               "Unknown Role",
+			id:                 "3",
+			requestBody:        `{"role":"owner"}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    "role must be one of: author, editor, admin",
+		},
+		{
+			name:               "Own Role",
+			id:                 "1",
+			requestBody:        `{"role":"author"}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusConflict,
+			expectedMessage:    appconst.Ownrole,
+		},
+		{
+			name:               "Invalid ID",
+			id:                 "abc",
+			requestBody:        `{"role":"editor"}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    `Error parsing user ID: strconv.Atoi: parsing "abc": invalid syntax`,
+		},
+		{
+			name:               "Error Parsing JSON",
+			id:                 "3",
+			requestBody:        `{invalid-json}`,
+			mockDBExpect:       func(db *mocks.MockDBInterface) {},
+			expectedStatusCode: http.StatusBadRequest,
+			expectedMessage:    appconst.JSONparsing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			tc.mockDBExpect(mockDB)
+
+			app := &Controller{
+				UserService: users.NewUserService(mockDB, nil),
+			}
+
+			w := httptest.NewRecorder()
+			app.SetUserRole(w, signedIn(newArticleRequest("PUT", tc.id, tc.requestBody), admin))
+
+			var response models.Response
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedMessage, response.Message)
+		})
+	}
+}
@@ -10,8 +10,18 @@
 // Produces:
 // - application/json
 //
-// Security:
-// - basic
+// SecurityDefinitions:
+//
+//	bearer:
+//	  type: apiKey
+//	  name: Authorization
+//	  in: header
+//	  description: 'Access token from /auth/login, sent as "Authorization: Bearer <token>"'
+//	apiKey:
+//	  type: apiKey
+//	  name: Authorization
+//	  in: header
+//	  description: 'API key from /api-keys, sent as "Authorization: ApiKey <key>"'
 //
 // swagger:meta
 package internal
@@ -0,0 +1,74 @@
+package graph
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"errors"
+	"log"
+)
+
+// Codes of the errors in a response, in their extensions
+const (
+	codeNotFound        = "NOT_FOUND"
+	codeConflict        = "CONFLICT"
+	codeBadInput        = "BAD_USER_INPUT"
+	codeUnauthenticated = "UNAUTHENTICATED"
+	codeForbidden       = "FORBIDDEN"
+	codeUnavailable     = "UNAVAILABLE"
+	codeTooComplex      = "QUERY_TOO_COMPLEX"
+	codeInternal        = "INTERNAL_SERVER_ERROR"
+)
+
+// queryError is an error as GraphQL clients get it, with a code in its
+// extensions saying what kind of error it is.
+type queryError struct {
+	message string
+	code    string
+}
+
+func (e *queryError) Error() string {
+	return e.message
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *queryError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+// errorCode returns the code of a domain error.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		return codeNotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		return codeConflict
+	case errors.Is(err, apperrors.ErrValidation):
+		return codeBadInput
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return codeUnauthenticated
+	case errors.Is(err, apperrors.ErrForbidden):
+		return codeForbidden
+	case errors.Is(err, apperrors.ErrTimeout), errors.Is(err, apperrors.ErrUnavailable):
+		return codeUnavailable
+	default:
+		return codeInternal
+	}
+}
+
+// fieldError turns an error of a service into the error of a field. Like
+// writeError of the REST handlers it only passes on the client-safe message
+// of a domain error, so driver details never leak.
+func fieldError(err error) error {
+	code := errorCode(err)
+	message, ok := apperrors.Message(err)
+	if !ok || code == codeInternal {
+		log.Println(appconst.Graphqlerror, err)
+		message = appconst.Internalerror
+	}
+	return &queryError{message: message, code: code}
+}
+
+// badInput returns the error of an argument that is not valid.
+func badInput(message string) error {
+	return &queryError{message: message, code: codeBadInput}
+}
@@ -0,0 +1,106 @@
+// Package graph serves articles with their authors, tags and comments as a
+// GraphQL schema. Resolvers call the same services as the REST handlers, so
+// the same policies apply, and the authors and comments of the articles in
+// a response are each loaded with one query however many articles there
+// are.
+package graph
+
+import (
+	services "backend/services/articles"
+	"backend/services/comments"
+	"backend/services/taxonomy"
+	"backend/services/users"
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Defaults of Limits
+const (
+	DefaultMaxDepth      = 10
+	DefaultMaxComplexity = 10000
+)
+
+// Services are what the schema resolves fields with.
+type Services struct {
+	Articles services.ArticleServices
+	Users    users.UserServices
+	Comments comments.CommentServices
+	Taxonomy taxonomy.TaxonomyServices
+}
+
+// Limits bound how much work one query may ask for. Queries over either
+// limit are rejected before any field is resolved.
+type Limits struct {
+	// MaxDepth is how deeply fields may be nested
+	MaxDepth int
+	// MaxComplexity is how many fields a query may resolve, counting the
+	// fields under a list as many times as the list may be long
+	MaxComplexity int
+}
+
+// Schema is the GraphQL schema with the services it resolves fields with.
+type Schema struct {
+	schema   graphql.Schema
+	services Services
+	limits   Limits
+}
+
+// Request is a GraphQL request as clients post it.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+	// Extensions some clients send are accepted but not used
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+// NewSchema returns the schema resolving fields with services. Limits that
+// are not set take their default.
+func NewSchema(services Services, limits Limits) (*Schema, error) {
+	if limits.MaxDepth <= 0 {
+		limits.MaxDepth = DefaultMaxDepth
+	}
+	if limits.MaxComplexity <= 0 {
+		limits.MaxComplexity = DefaultMaxComplexity
+	}
+	s := &Schema{services: services, limits: limits}
+
+	schema, err := graphql.NewSchema(s.config())
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute parses, validates and runs a request. The principal in ctx is
+// who the query is run for. Errors are reported in the result, as GraphQL
+// clients expect them.
+func (s *Schema) Execute(ctx context.Context, request Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(request.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&s.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+	if err := s.checkLimits(doc, request.OperationName, request.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(gqlerrors.NewLocatedError(err, nil))}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       withLoaders(ctx, s.services),
+	})
+}
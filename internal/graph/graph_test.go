@@ -0,0 +1,207 @@
+package graph
+
+import (
+	"backend/mocks"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"backend/services/comments"
+	"backend/services/taxonomy"
+	"backend/services/users"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newSchema returns the schema over the services of mockDB.
+func newSchema(t *testing.T, mockDB *mocks.MockDBInterface, limits Limits) *Schema {
+	schema, err := NewSchema(Services{
+		Articles: services.NewArticleService(mockDB),
+		Users:    users.NewUserService(mockDB, nil),
+		Comments: comments.NewCommentService(mockDB, comments.NewHeuristicScorer(nil), comments.DefaultHoldThreshold),
+		Taxonomy: taxonomy.NewTaxonomyService(mockDB),
+	}, limits)
+	assert.NoError(t, err)
+	return schema
+}
+
+func TestExecute(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	parent := 10
+	author := models.Principal{UserID: 1, Username: "ada", Role: models.RoleAuthor}
+	articles := &models.ArticlePage{
+		Articles: []models.Article{
+			{ID: 1, Title: "First", Slug: "first", AuthorID: 1, Status: models.StatusPublished, Tags: []string{"Go Tips"}, CreatedAt: &created},
+			{ID: 2, Title: "Second", Slug: "second", AuthorID: 2, Status: models.StatusPublished},
+			{ID: 3, Title: "Third", Slug: "third", AuthorID: 1, Status: models.StatusPublished},
+		},
+		PageInfo: models.PageInfo{Total: 3},
+	}
+
+	testCases := []struct {
+		name         string
+		request      Request
+		principal    models.Principal
+		mockDBExpect func(db *mocks.MockDBInterface)
+		expected     string
+	}{
+		{
+			name: "articles with authors and comments",
+			request: Request{Query: `{
+				articles(limit: 3) {
+					nodes { id title createdAt tags { name slug } author { username } comments(limit: 2) { id parentId body author { username } } }
+					pageInfo { total hasNext nextCursor }
+				}
+			}`},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().AllArticles(gomock.Any()).DoAndReturn(func(params models.ListParams) (*models.ArticlePage, error) {
+					assert.Equal(t, 3, params.Limit)
+					return articles, nil
+				})
+				// at most one query for each level of the response however
+				// many articles and comments are in it; fields are resolved
+				// in no set order, so the levels may share one
+				users := map[int]models.User{
+					1: {ID: 1, Username: "ada", Email: "ada@example.com"},
+					2: {ID: 2, Username: "grace"},
+					3: {ID: 3, Username: "linus"},
+				}
+				db.EXPECT().UsersByIDs(gomock.Any()).DoAndReturn(func(ids []int) ([]models.User, error) {
+					found := []models.User{}
+					for _, id := range ids {
+						found = append(found, users[id])
+					}
+					return found, nil
+				}).MinTimes(1).MaxTimes(2)
+				db.EXPECT().CommentsByArticles([]int{1, 2, 3}, 2).Return([]models.Comment{
+					{ID: 10, ArticleID: 1, AuthorID: 3, Body: "Nice"},
+					{ID: 11, ArticleID: 1, ParentID: &parent, Body: "", Deleted: true, AuthorID: 2},
+				}, nil)
+			},
+			expected: `{"data": {"articles": {
+				"nodes": [
+					{"id": "1", "title": "First", "createdAt": "2024-03-01T09:30:00Z", "tags": [{"name": "Go Tips", "slug": "go-tips"}], "author": {"username": "ada"}, "comments": [
+						{"id": "10", "parentId": null, "body": "Nice", "author": {"username": "linus"}},
+						{"id": "11", "parentId": "10", "body": "", "author": null}
+					]},
+					{"id": "2", "title": "Second", "createdAt": null, "tags": [], "author": {"username": "grace"}, "comments": []},
+					{"id": "3", "title": "Third", "createdAt": null, "tags": [], "author": {"username": "ada"}, "comments": []}
+				],
+				"pageInfo": {"total": 3, "hasNext": false, "nextCursor": null}
+			}}}`,
+		},
+		{
+			name:    "article by slug",
+			request: Request{Query: `query($slug: String) { article(slug: $slug) { id } }`, Variables: map[string]interface{}{"slug": "first"}},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ArticleBySlug("first").Return(&articles.Articles[0], nil)
+			},
+			expected: `{"data": {"article": {"id": "1"}}}`,
+		},
+		{
+			name:    "hidden article",
+			request: Request{Query: `{ article(id: 4) { id } }`},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(4).Return(&models.Article{ID: 4, AuthorID: 2, Status: models.StatusDraft}, nil)
+			},
+			expected: `{"data": {"article": null}}`,
+		},
+		{
+			name:     "article by id and slug",
+			request:  Request{Query: `{ article(id: 1, slug: "first") { id } }`},
+			expected: `{"data": {"article": null}, "errors": [{"message": "give either id or slug", "locations": [{"line": 1, "column": 3}], "path": ["article"], "extensions": {"code": "BAD_USER_INPUT"}}]}`,
+		},
+		{
+			name:    "author articles",
+			request: Request{Query: `{ author(username: "Ada") { username articles(sort: "-created_at") { nodes { slug } } } }`},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().UserByUsername("Ada").Return(&models.User{ID: 1, Username: "ada", Email: "ada@example.com"}, nil)
+				db.EXPECT().AllArticles(gomock.Any()).DoAndReturn(func(params models.ListParams) (*models.ArticlePage, error) {
+					assert.Equal(t, models.ArticleFilter{Author: "ada"}, params.Filter)
+					assert.Equal(t, models.Sort{Field: models.SortCreatedAt, Desc: true}, params.Sort)
+					return &models.ArticlePage{Articles: articles.Articles[:1]}, nil
+				})
+			},
+			expected: `{"data": {"author": {"username": "ada", "articles": {"nodes": [{"slug": "first"}]}}}}`,
+		},
+		{
+			name:     "invalid limit",
+			request:  Request{Query: `{ articles(limit: 500) { nodes { id } } }`},
+			expected: `{"data": null, "errors": [{"message": "limit must be a number between 1 and 100", "locations": [{"line": 1, "column": 3}], "path": ["articles"], "extensions": {"code": "BAD_USER_INPUT"}}]}`,
+		},
+		{
+			name:    "database error",
+			request: Request{Query: `{ tag(slug: "go") { name } }`},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneTag("go").Return(nil, errors.New("connection refused"))
+			},
+			expected: `{"data": {"tag": null}, "errors": [{"message": "Internal server error", "locations": [{"line": 1, "column": 3}], "path": ["tag"], "extensions": {"code": "INTERNAL_SERVER_ERROR"}}]}`,
+		},
+		{
+			name:      "create article",
+			request:   Request{Query: `mutation { createArticle(input: {title: "Hello", content: "*Hi*", tags: ["Go"]}) { id slug contentHtml author { username } } }`},
+			principal: author,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneUser(1).Return(&models.User{ID: 1, Username: "ada"}, nil)
+				db.EXPECT().CreateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) (int, error) {
+					assert.Equal(t, "hello", article.Slug)
+					assert.Equal(t, []string{"Go"}, article.Tags)
+					return 5, nil
+				})
+				db.EXPECT().OneArticle(5).Return(&models.Article{ID: 5, Slug: "hello", ContentHTML: "<p><em>Hi</em></p>\n", AuthorID: 1, Status: models.StatusDraft}, nil)
+				db.EXPECT().UsersByIDs([]int{1}).Return([]models.User{{ID: 1, Username: "ada"}}, nil)
+			},
+			expected: `{"data": {"createArticle": {"id": "5", "slug": "hello", "contentHtml": "<p><em>Hi</em></p>\n", "author": {"username": "ada"}}}}`,
+		},
+		{
+			name:     "create article anonymously",
+			request:  Request{Query: `mutation { createArticle(input: {title: "Hello", content: "Hi"}) { id } }`},
+			expected: `{"data": null, "errors": [{"message": "sign in to do this", "locations": [{"line": 1, "column": 12}], "path": ["createArticle"], "extensions": {"code": "UNAUTHENTICATED"}}]}`,
+		},
+		{
+			name:      "delete article",
+			request:   Request{Query: `mutation($id: ID!) { deleteArticle(id: $id) }`, Variables: map[string]interface{}{"id": "3"}},
+			principal: author,
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(3).Return(&articles.Articles[2], nil)
+				db.EXPECT().DeleteArticle(3).Return(nil)
+			},
+			expected: `{"data": {"deleteArticle": "3"}}`,
+		},
+		{
+			name:      "update article with invalid id",
+			request:   Request{Query: `mutation { updateArticle(id: "x", input: {title: "Hello", content: "Hi"}) { id } }`},
+			principal: author,
+			expected:  `{"data": null, "errors": [{"message": "id must be an ID", "locations": [{"line": 1, "column": 12}], "path": ["updateArticle"], "extensions": {"code": "BAD_USER_INPUT"}}]}`,
+		},
+		{
+			name:     "unknown field",
+			request:  Request{Query: `{ articles { nodes { password } } }`},
+			expected: `{"data": null, "errors": [{"message": "Cannot query field \"password\" on type \"Article\".", "locations": [{"line": 1, "column": 22}]}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			if tc.mockDBExpect != nil {
+				tc.mockDBExpect(mockDB)
+			}
+
+			ctx := auth.NewContext(context.Background(), tc.principal)
+			result := newSchema(t, mockDB, Limits{}).Execute(ctx, tc.request)
+
+			body, err := json.Marshal(result)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(body))
+		})
+	}
+}
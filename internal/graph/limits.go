@@ -0,0 +1,181 @@
+package graph
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// cost is how much of the limits part of a query takes.
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// costWalker walks the selections of one operation.
+type costWalker struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// checkLimits rejects an operation nested deeper or more complex than the
+// limits allow. Each field counts once, and the fields under a list count
+// once for every item it may hold: as many as its limit argument asks for,
+// or as many tags as an article may have for lists without one.
+// Introspection fields are not counted.
+func (s *Schema) checkLimits(doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	w := &costWalker{schema: &s.schema, fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			w.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		// Execute reports the missing operation
+		return nil
+	}
+
+	root := s.schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = s.schema.MutationType()
+	}
+
+	c := w.selections(operation.SelectionSet, root, 1, 0)
+	if c.depth > s.limits.MaxDepth {
+		return &queryError{message: fmt.Sprintf(appconst.Querydepth, s.limits.MaxDepth), code: codeTooComplex}
+	}
+	if c.complexity > s.limits.MaxComplexity {
+		return &queryError{message: fmt.Sprintf(appconst.Querycomplexity, c.complexity, s.limits.MaxComplexity), code: codeTooComplex}
+	}
+	return nil
+}
+
+// selections returns the cost of a selection set on parent whose fields are
+// at depth. Lists selected in it hold size items, or the default when size
+// is 0.
+func (w *costWalker) selections(set *ast.SelectionSet, parent graphql.Type, depth, size int) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = w.field(selection, parent, depth, size)
+		case *ast.InlineFragment:
+			on := parent
+			if selection.TypeCondition != nil {
+				on = w.schema.Type(selection.TypeCondition.Name.Value)
+			}
+			c = w.selections(selection.SelectionSet, on, depth, size)
+		case *ast.FragmentSpread:
+			fragment, ok := w.fragments[selection.Name.Value]
+			if !ok {
+				continue
+			}
+			c = w.selections(fragment.SelectionSet, w.schema.Type(fragment.TypeCondition.Name.Value), depth, size)
+		}
+
+		total.complexity += c.complexity
+		if c.depth > total.depth {
+			total.depth = c.depth
+		}
+	}
+	return total
+}
+
+func (w *costWalker) field(field *ast.Field, parent graphql.Type, depth, size int) cost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+	object, ok := parent.(*graphql.Object)
+	if !ok {
+		return cost{depth: depth, complexity: 1}
+	}
+	definition, ok := object.Fields()[field.Name.Value]
+	if !ok {
+		return cost{depth: depth, complexity: 1}
+	}
+
+	items := 1
+	childSize := 0
+	limit, hasLimit := w.limit(field, definition)
+	switch {
+	case isList(definition.Type) && hasLimit:
+		items = limit
+	case isList(definition.Type) && size > 0:
+		items = size
+	case isList(definition.Type):
+		items = models.MaxArticleTags
+	case hasLimit:
+		// a page of a list, whose nodes are as many as the limit
+		childSize = limit
+	}
+
+	children := w.selections(field.SelectionSet, graphql.GetNamed(definition.Type).(graphql.Type), depth+1, childSize)
+	c := cost{depth: depth, complexity: 1 + items*children.complexity}
+	if children.depth > c.depth {
+		c.depth = children.depth
+	}
+	return c
+}
+
+// limit returns how many items the limit argument of a field asks for, if
+// the field has one. Values the field rejects count as the largest page.
+func (w *costWalker) limit(field *ast.Field, definition *graphql.FieldDefinition) (int, bool) {
+	var argument *graphql.Argument
+	for _, arg := range definition.Args {
+		if arg.Name() == "limit" {
+			argument = arg
+		}
+	}
+	if argument == nil {
+		return 0, false
+	}
+
+	value, _ := argument.DefaultValue.(int)
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			value, _ = strconv.Atoi(v.Value)
+		case *ast.Variable:
+			switch variable := w.variables[v.Name.Value].(type) {
+			case int:
+				value = variable
+			case float64:
+				value = int(variable)
+			}
+		}
+	}
+
+	if value < 1 || value > models.MaxPageSize {
+		value = models.MaxPageSize
+	}
+	return value, true
+}
+
+func isList(t graphql.Type) bool {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	_, ok := t.(*graphql.List)
+	return ok
+}
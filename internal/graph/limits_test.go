@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"backend/mocks"
+	"backend/pkg/models"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+	testCases := []struct {
+		name          string
+		request       Request
+		listsArticles bool
+		expectedError string
+	}{
+		{
+			name:          "within limits",
+			request:       Request{Query: `{ articles { nodes { id } } }`},
+			listsArticles: true,
+		},
+		{
+			name:          "too deep",
+			request:       Request{Query: `{ articles { nodes { author { username } } } }`},
+			expectedError: "the query is nested more than 3 levels deep",
+		},
+		{
+			name:          "too complex",
+			request:       Request{Query: `query($n: Int) { articles(limit: $n) { nodes { id title } } }`, Variables: map[string]interface{}{"n": float64(20)}},
+			expectedError: "the query has a complexity of 42, at most 30 is allowed",
+		},
+		{
+			name:          "too complex in a fragment",
+			request:       Request{Query: `{ ...page } fragment page on Query { articles(limit: 20) { nodes { id title } } }`},
+			expectedError: "the query has a complexity of 42, at most 30 is allowed",
+		},
+		{
+			name:          "list without a limit",
+			request:       Request{Query: `{ article(slug: "first") { tags { name slug } } }`},
+			expectedError: "the query has a complexity of 42, at most 30 is allowed",
+		},
+		{
+			name:    "introspection",
+			request: Request{Query: `{ __schema { types { name fields { name type { name } } } } }`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			if tc.listsArticles {
+				mockDB.EXPECT().AllArticles(gomock.Any()).Return(&models.ArticlePage{}, nil)
+			}
+
+			result := newSchema(t, mockDB, Limits{MaxDepth: 3, MaxComplexity: 30}).Execute(context.Background(), tc.request)
+
+			if tc.expectedError == "" {
+				assert.Empty(t, result.Errors)
+				assert.NotNil(t, result.Data)
+				return
+			}
+			assert.Nil(t, result.Data)
+			if assert.Len(t, result.Errors, 1) {
+				assert.Equal(t, tc.expectedError, result.Errors[0].Message)
+				assert.Equal(t, map[string]interface{}{"code": "QUERY_TOO_COMPLEX"}, result.Errors[0].Extensions)
+			}
+		})
+	}
+}
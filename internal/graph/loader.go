@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"backend/pkg/models"
+	"context"
+	"sync"
+)
+
+// loader batches the loads of one kind of value while a query runs. Fields
+// ask for their key and get a thunk, which graphql-go calls only once the
+// fields beside them have asked for theirs too; the first thunk called
+// fetches every key asked for so far in one go. Values are kept for the
+// rest of the request.
+type loader struct {
+	fetch func(keys []int) (map[int]interface{}, error)
+
+	mu      sync.Mutex
+	pending []int
+	queued  map[int]bool
+	values  map[int]interface{}
+	errs    map[int]error
+}
+
+func newLoader(fetch func(keys []int) (map[int]interface{}, error)) *loader {
+	return &loader{
+		fetch:  fetch,
+		queued: map[int]bool{},
+		values: map[int]interface{}{},
+		errs:   map[int]error{},
+	}
+}
+
+// load asks for the value of key. A key that is not fetched resolves to
+// nil.
+func (l *loader) load(key int) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(keys)
+			if err != nil {
+				err = fieldError(err)
+			}
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+					continue
+				}
+				l.values[k] = values[k]
+			}
+		}
+		return l.values[key], l.errs[key]
+	}
+}
+
+// loaders are the loaders of one request.
+type loaders struct {
+	services Services
+	authors  *loader
+
+	mu sync.Mutex
+	// comments are loaded by how many comments of each article are asked
+	// for, since that is part of the query fetching them
+	comments map[int]*loader
+}
+
+type loadersKey struct{}
+
+// withLoaders returns a copy of ctx carrying new loaders for a request.
+func withLoaders(ctx context.Context, services Services) context.Context {
+	l := &loaders{services: services, comments: map[int]*loader{}}
+	l.authors = newLoader(func(ids []int) (map[int]interface{}, error) {
+		authors, err := services.Users.GetAuthors(ids)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int]interface{}, len(authors))
+		for _, author := range authors {
+			values[author.ID] = author
+		}
+		return values, nil
+	})
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// author loads the user with an ID as a models.User.
+func (l *loaders) author(id int) func() (interface{}, error) {
+	return l.authors.load(id)
+}
+
+// articleComments loads the first limit comments on an article as a
+// []models.Comment.
+func (l *loaders) articleComments(articleID, limit int) func() (interface{}, error) {
+	l.mu.Lock()
+	commentLoader, ok := l.comments[limit]
+	if !ok {
+		commentLoader = newLoader(func(ids []int) (map[int]interface{}, error) {
+			byArticle, err := l.services.Comments.GetArticlesComments(ids, limit)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[int]interface{}, len(ids))
+			for _, id := range ids {
+				comments := byArticle[id]
+				if comments == nil {
+					comments = []models.Comment{}
+				}
+				values[id] = comments
+			}
+			return values, nil
+		})
+		l.comments[limit] = commentLoader
+	}
+	l.mu.Unlock()
+	return commentLoader.load(articleID)
+}
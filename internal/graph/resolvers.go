@@ -0,0 +1,381 @@
+package graph
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+func (s *Schema) config() graphql.SchemaConfig {
+	t := s.types()
+
+	articleArgs := pageArgs()
+	for name, arg := range map[string]*graphql.ArgumentConfig{
+		"author":   {Type: graphql.String, Description: "Username of the author"},
+		"tag":      {Type: graphql.String, Description: "Name or slug of a tag"},
+		"category": {Type: graphql.String, Description: "Slug of a category, including the categories below it"},
+		"status":   {Type: graphql.String, Description: "Workflow status, for viewers who see unpublished articles"},
+	} {
+		articleArgs[name] = arg
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"article": {
+				Type:        t.article,
+				Description: "The article with an ID or a current or old slug, or null if the viewer may not see it.",
+				Args: graphql.FieldConfigArgument{
+					"id":   {Type: graphql.ID},
+					"slug": {Type: graphql.String},
+				},
+				Resolve: s.article,
+			},
+			"articles": {
+				Type:        graphql.NewNonNull(t.articleConnection),
+				Description: "A page of the articles the viewer may see, as the article listing returns them.",
+				Args:        articleArgs,
+				Resolve:     s.articles,
+			},
+			"author": {
+				Type: t.author,
+				Args: graphql.FieldConfigArgument{
+					"username": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.author,
+			},
+			"tag": {
+				Type: t.tag,
+				Args: graphql.FieldConfigArgument{
+					"slug": {Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.tag,
+			},
+			"tags": {
+				Type:        graphql.NewNonNull(t.tagConnection),
+				Description: "A page of the tags sorted by name.",
+				Args: graphql.FieldConfigArgument{
+					"limit":  {Type: graphql.Int, DefaultValue: models.DefaultPageSize},
+					"offset": {Type: graphql.Int},
+				},
+				Resolve: s.tags,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createArticle": {
+				Type:        graphql.NewNonNull(t.article),
+				Description: "Saves a new draft written by the viewer.",
+				Args: graphql.FieldConfigArgument{
+					"input": {Type: graphql.NewNonNull(t.articleInput)},
+				},
+				Resolve: s.createArticle,
+			},
+			"updateArticle": {
+				Type:        graphql.NewNonNull(t.article),
+				Description: "Replaces every field of an article.",
+				Args: graphql.FieldConfigArgument{
+					"id":    {Type: graphql.NewNonNull(graphql.ID)},
+					"input": {Type: graphql.NewNonNull(t.articleInput)},
+				},
+				Resolve: s.updateArticle,
+			},
+			"deleteArticle": {
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Deletes an article and returns its ID.",
+				Args: graphql.FieldConfigArgument{
+					"id": {Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.deleteArticle,
+			},
+		},
+	})
+
+	return graphql.SchemaConfig{Query: query, Mutation: mutation}
+}
+
+func (s *Schema) article(p graphql.ResolveParams) (interface{}, error) {
+	viewer := auth.FromContext(p.Context)
+	slug, bySlug := p.Args["slug"].(string)
+	_, byID := p.Args["id"]
+	if byID == bySlug {
+		return nil, badInput(appconst.Articleselector)
+	}
+
+	var article *models.Article
+	var err error
+	if bySlug {
+		article, err = s.services.Articles.GetArticleBySlug(slug, viewer)
+	} else {
+		id, idErr := idArg(p.Args, "id")
+		if idErr != nil {
+			return nil, idErr
+		}
+		article, err = s.services.Articles.GetArticleByID(id, viewer)
+	}
+	return articleResult(article, err)
+}
+
+func (s *Schema) articles(p graphql.ResolveParams) (interface{}, error) {
+	params, err := listArgs(p)
+	if err != nil {
+		return nil, err
+	}
+
+	params.Filter.Author, _ = p.Args["author"].(string)
+	tag, _ := p.Args["tag"].(string)
+	params.Filter.Tag = utility.Slugify(tag)
+	params.Filter.Category, _ = p.Args["category"].(string)
+	if status, ok := p.Args["status"].(string); ok {
+		if !contains(models.ArticleStatuses, status) {
+			return nil, badInput(fmt.Sprintf(appconst.Invalidstatus, strings.Join(models.ArticleStatuses, ", ")))
+		}
+		params.Filter.Status = status
+	}
+
+	return articlePage(s.services.Articles.GetAllArticles(params))
+}
+
+func (s *Schema) author(p graphql.ResolveParams) (interface{}, error) {
+	user, err := s.services.Users.GetAuthor(p.Args["username"].(string))
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return *user, nil
+}
+
+func (s *Schema) authorArticles(p graphql.ResolveParams) (interface{}, error) {
+	params, err := listArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	params.Filter.Author = p.Source.(models.User).Username
+	return articlePage(s.services.Articles.GetAllArticles(params))
+}
+
+func (s *Schema) tag(p graphql.ResolveParams) (interface{}, error) {
+	tag, err := s.services.Taxonomy.GetTag(utility.Slugify(p.Args["slug"].(string)))
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return *tag, nil
+}
+
+func (s *Schema) tags(p graphql.ResolveParams) (interface{}, error) {
+	params, err := listArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := s.services.Taxonomy.GetTags(params)
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return page{nodes: tags.Tags, info: tags.PageInfo}, nil
+}
+
+func (s *Schema) tagArticles(p graphql.ResolveParams) (interface{}, error) {
+	params, err := listArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	return articlePage(s.services.Taxonomy.GetTagArticles(p.Source.(models.Tag).Slug, params))
+}
+
+func (s *Schema) createArticle(p graphql.ResolveParams) (interface{}, error) {
+	actor := auth.FromContext(p.Context)
+	article, err := articleInput(p.Args["input"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.services.Articles.CreateArticle(article, actor)
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return articleResult(s.services.Articles.GetArticleByID(id, actor))
+}
+
+func (s *Schema) updateArticle(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	article, err := articleInput(p.Args["input"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	article, err = s.services.Articles.UpdateArticle(id, article, auth.FromContext(p.Context))
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return *article, nil
+}
+
+func (s *Schema) deleteArticle(p graphql.ResolveParams) (interface{}, error) {
+	articleID, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.services.Articles.DeleteArticle(articleID, auth.FromContext(p.Context)); err != nil {
+		return nil, fieldError(err)
+	}
+	return id(articleID), nil
+}
+
+// articleResult resolves an article a service returned, an article that is
+// not found resolving to null.
+func articleResult(article *models.Article, err error) (interface{}, error) {
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return *article, nil
+}
+
+// articlePage resolves a page of articles a service returned.
+func articlePage(articles *models.ArticlePage, err error) (interface{}, error) {
+	if err != nil {
+		return nil, fieldError(err)
+	}
+	return page{nodes: articles.Articles, info: articles.PageInfo}, nil
+}
+
+// listArgs reads the paging and sort arguments of a field as the article
+// listing reads its query parameters, for the viewer of the request.
+func listArgs(p graphql.ResolveParams) (models.ListParams, error) {
+	params := models.ListParams{Viewer: auth.FromContext(p.Context)}
+
+	limit, err := limitArg(p.Args)
+	if err != nil {
+		return params, err
+	}
+	params.Limit = limit
+
+	if offset, ok := p.Args["offset"].(int); ok {
+		if offset < 0 {
+			return params, badInput(appconst.Invalidoffset)
+		}
+		params.Offset = offset
+	}
+
+	if value, ok := p.Args["sort"].(string); ok {
+		sort, ok := models.ParseSort(value)
+		if !ok {
+			return params, badInput(fmt.Sprintf(appconst.Invalidsort, strings.Join(models.ArticleSortFields, ", ")))
+		}
+		params.Sort = sort
+	}
+
+	if value, ok := p.Args["cursor"].(string); ok {
+		if _, ok := p.Args["offset"]; ok {
+			return params, badInput(appconst.Cursorwithoffset)
+		}
+		cursor, err := models.DecodeCursor(value)
+		if err != nil {
+			return params, badInput(appconst.Invalidcursor)
+		}
+		params.Cursor = cursor
+	}
+
+	params.Normalize()
+	if params.Cursor != nil && params.Cursor.Sort != params.Sort.String() {
+		return params, badInput(appconst.Cursorsort)
+	}
+	return params, nil
+}
+
+// limitArg reads the limit argument, which takes its default when it is
+// left out.
+func limitArg(args map[string]interface{}) (int, error) {
+	limit, ok := args["limit"].(int)
+	if !ok {
+		return models.DefaultPageSize, nil
+	}
+	if limit < 1 || limit > models.MaxPageSize {
+		return 0, badInput(fmt.Sprintf(appconst.Invalidlimit, models.MaxPageSize))
+	}
+	return limit, nil
+}
+
+// idArg reads an argument of the ID type, which clients may give as a
+// string or a number.
+func idArg(args map[string]interface{}, name string) (int, error) {
+	value, _ := args[name].(string)
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 1 {
+		return 0, badInput(fmt.Sprintf(appconst.Invalidid, name))
+	}
+	return id, nil
+}
+
+// articleInput reads an ArticleInput into an article.
+func articleInput(input map[string]interface{}) (*models.Article, error) {
+	article := &models.Article{}
+	article.Title, _ = input["title"].(string)
+	article.Content, _ = input["content"].(string)
+	article.Tags = stringList(input["tags"])
+	article.Categories = stringList(input["categories"])
+
+	if _, ok := input["coverId"]; ok {
+		coverID, err := idArg(input, "coverId")
+		if err != nil {
+			return nil, err
+		}
+		article.CoverID = &coverID
+	}
+	if _, ok := input["authorId"]; ok {
+		authorID, err := idArg(input, "authorId")
+		if err != nil {
+			return nil, err
+		}
+		article.AuthorID = authorID
+	}
+	if publishAt, ok := input["publishAt"].(time.Time); ok {
+		article.PublishAt = &publishAt
+	}
+	return article, nil
+}
+
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	if items == nil {
+		return nil
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
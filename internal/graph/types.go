@@ -0,0 +1,273 @@
+package graph
+
+import (
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+)
+
+// types are the object types of the schema. Fields resolve from the model
+// they are named after, held by value.
+type types struct {
+	article           *graphql.Object
+	author            *graphql.Object
+	tag               *graphql.Object
+	comment           *graphql.Object
+	pageInfo          *graphql.Object
+	articleConnection *graphql.Object
+	tagConnection     *graphql.Object
+	articleInput      *graphql.InputObject
+}
+
+// pageArgs are the arguments of fields returning a page of a list, as the
+// REST listings take them.
+func pageArgs() graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit":  {Type: graphql.Int, DefaultValue: models.DefaultPageSize},
+		"offset": {Type: graphql.Int},
+		"cursor": {Type: graphql.String},
+		"sort":   {Type: graphql.String},
+	}
+}
+
+func (s *Schema) types() *types {
+	t := &types{}
+
+	t.pageInfo = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PageInfo",
+		Description: "Where a page is in its list. Pass a cursor back as cursor to get the page next to it.",
+		Fields: graphql.Fields{
+			"total":      {Type: graphql.NewNonNull(graphql.Int), Resolve: pageField(func(p models.PageInfo) interface{} { return p.Total })},
+			"hasNext":    {Type: graphql.NewNonNull(graphql.Boolean), Resolve: pageField(func(p models.PageInfo) interface{} { return p.HasNext })},
+			"hasPrev":    {Type: graphql.NewNonNull(graphql.Boolean), Resolve: pageField(func(p models.PageInfo) interface{} { return p.HasPrev })},
+			"nextCursor": {Type: graphql.String, Resolve: pageField(func(p models.PageInfo) interface{} { return optional(p.NextCursor) })},
+			"prevCursor": {Type: graphql.String, Resolve: pageField(func(p models.PageInfo) interface{} { return optional(p.PrevCursor) })},
+		},
+	})
+
+	t.author = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Author",
+		Description: "A user who writes articles.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: authorField(func(u models.User) interface{} { return u.ID })},
+				"username": {Type: graphql.NewNonNull(graphql.String), Resolve: authorField(func(u models.User) interface{} { return u.Username })},
+				"articles": {
+					Type:        graphql.NewNonNull(t.articleConnection),
+					Description: "The articles of the author the viewer may see.",
+					Args:        pageArgs(),
+					Resolve:     s.authorArticles,
+				},
+			}
+		}),
+	})
+
+	t.tag = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Tag",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name": {Type: graphql.NewNonNull(graphql.String), Resolve: tagField(func(tag models.Tag) interface{} { return tag.Name })},
+				"slug": {Type: graphql.NewNonNull(graphql.String), Resolve: tagField(func(tag models.Tag) interface{} { return tag.Slug })},
+				"articles": {
+					Type:        graphql.NewNonNull(t.articleConnection),
+					Description: "The articles with the tag the viewer may see.",
+					Args:        pageArgs(),
+					Resolve:     s.tagArticles,
+				},
+			}
+		}),
+	})
+
+	t.comment = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Comment",
+		Description: "An approved comment on an article. Replies name the comment they answer as parentId.",
+		Fields: graphql.Fields{
+			"id": {Type: graphql.NewNonNull(graphql.ID), Resolve: commentField(func(c models.Comment) interface{} { return c.ID })},
+			"parentId": {Type: graphql.ID, Resolve: commentField(func(c models.Comment) interface{} {
+				if c.ParentID == nil {
+					return nil
+				}
+				return *c.ParentID
+			})},
+			"depth":     {Type: graphql.NewNonNull(graphql.Int), Resolve: commentField(func(c models.Comment) interface{} { return c.Depth })},
+			"body":      {Type: graphql.NewNonNull(graphql.String), Resolve: commentField(func(c models.Comment) interface{} { return c.Body })},
+			"deleted":   {Type: graphql.NewNonNull(graphql.Boolean), Resolve: commentField(func(c models.Comment) interface{} { return c.Deleted })},
+			"createdAt": {Type: graphql.DateTime, Resolve: commentField(func(c models.Comment) interface{} { return c.CreatedAt })},
+			"updatedAt": {Type: graphql.DateTime, Resolve: commentField(func(c models.Comment) interface{} { return c.UpdatedAt })},
+			"author": {
+				Type:        t.author,
+				Description: "Who wrote the comment, which is null once it is deleted.",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					comment := p.Source.(models.Comment)
+					if comment.AuthorID == 0 {
+						return nil, nil
+					}
+					return loadersFrom(p.Context).author(comment.AuthorID), nil
+				},
+			},
+		},
+	})
+
+	t.article = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Article",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.ID), Resolve: articleField(func(a models.Article) interface{} { return a.ID })},
+			"title":       {Type: graphql.NewNonNull(graphql.String), Resolve: articleField(func(a models.Article) interface{} { return a.Title })},
+			"slug":        {Type: graphql.NewNonNull(graphql.String), Resolve: articleField(func(a models.Article) interface{} { return a.Slug })},
+			"content":     {Type: graphql.NewNonNull(graphql.String), Description: "The Markdown source of the article.", Resolve: articleField(func(a models.Article) interface{} { return a.Content })},
+			"contentHtml": {Type: graphql.NewNonNull(graphql.String), Description: "The article rendered to sanitized HTML.", Resolve: articleField(func(a models.Article) interface{} { return a.ContentHTML })},
+			"status":      {Type: graphql.NewNonNull(graphql.String), Resolve: articleField(func(a models.Article) interface{} { return a.Status })},
+			"categories":  {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))), Description: "Slugs of the categories of the article.", Resolve: articleField(func(a models.Article) interface{} { return nonNil(a.Categories) })},
+			"coverUrl":    {Type: graphql.String, Resolve: articleField(coverURL)},
+			"revision":    {Type: graphql.NewNonNull(graphql.Int), Resolve: articleField(func(a models.Article) interface{} { return a.Revision })},
+			"createdAt":   {Type: graphql.DateTime, Resolve: articleField(func(a models.Article) interface{} { return a.CreatedAt })},
+			"updatedAt":   {Type: graphql.DateTime, Resolve: articleField(func(a models.Article) interface{} { return a.UpdatedAt })},
+			"publishedAt": {Type: graphql.DateTime, Resolve: articleField(func(a models.Article) interface{} { return a.PublishedAt })},
+			"publishAt":   {Type: graphql.DateTime, Resolve: articleField(func(a models.Article) interface{} { return a.PublishAt })},
+			"author": {
+				Type: t.author,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					article := p.Source.(models.Article)
+					if article.AuthorID == 0 {
+						return nil, nil
+					}
+					return loadersFrom(p.Context).author(article.AuthorID), nil
+				},
+			},
+			"tags": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t.tag))),
+				Resolve: articleField(func(a models.Article) interface{} {
+					tags := make([]models.Tag, len(a.Tags))
+					for i, name := range a.Tags {
+						tags[i] = models.Tag{Name: name, Slug: utility.Slugify(name)}
+					}
+					return tags
+				}),
+			},
+			"comments": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t.comment))),
+				Description: "The first approved comments on the article, in the order they were made.",
+				Args: graphql.FieldConfigArgument{
+					"limit": {Type: graphql.Int, DefaultValue: models.DefaultPageSize},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, err := limitArg(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return loadersFrom(p.Context).articleComments(p.Source.(models.Article).ID, limit), nil
+				},
+			},
+		},
+	})
+
+	t.articleConnection = connection("ArticleConnection", t.article, t.pageInfo)
+	t.tagConnection = connection("TagConnection", t.tag, t.pageInfo)
+
+	t.articleInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "ArticleInput",
+		Description: "Every field of an article. Fields left out are cleared when an article is updated.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":      {Type: graphql.NewNonNull(graphql.String)},
+			"content":    {Type: graphql.NewNonNull(graphql.String), Description: "Markdown source of the article"},
+			"tags":       {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Names of the tags of the article"},
+			"categories": {Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Slugs of the categories of the article"},
+			"coverId":    {Type: graphql.ID, Description: "ID of an uploaded image"},
+			"authorId":   {Type: graphql.ID, Description: "ID of the author an editor hands the article to"},
+			"publishAt":  {Type: graphql.DateTime, Description: "Time to publish the article once it has been submitted for review"},
+		},
+	})
+
+	return t
+}
+
+// connection returns the type of a page of nodes.
+func connection(name string, node, pageInfo *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"nodes": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(node))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(page).nodes, nil
+				},
+			},
+			"pageInfo": {
+				Type: graphql.NewNonNull(pageInfo),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(page).info, nil
+				},
+			},
+		},
+	})
+}
+
+// page is a page of a list as connections resolve from it.
+type page struct {
+	nodes interface{}
+	info  models.PageInfo
+}
+
+func articleField(get func(models.Article) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(models.Article)), nil
+	}
+}
+
+func authorField(get func(models.User) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(models.User)), nil
+	}
+}
+
+func tagField(get func(models.Tag) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(models.Tag)), nil
+	}
+}
+
+func commentField(get func(models.Comment) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(models.Comment)), nil
+	}
+}
+
+func pageField(get func(models.PageInfo) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return get(p.Source.(models.PageInfo)), nil
+	}
+}
+
+// coverURL returns where the cover of an article is served from: its
+// widest variant, or the uploaded image until its variants are made.
+func coverURL(article models.Article) interface{} {
+	if article.Cover == nil {
+		return nil
+	}
+	if len(article.Cover.Variants) == 0 {
+		return article.Cover.URL
+	}
+	return article.Cover.Variants[len(article.Cover.Variants)-1].URL
+}
+
+// optional returns nil for an empty string, which resolves to null.
+func optional(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// id formats an ID as the ID type serializes it.
+func id(value int) string {
+	return strconv.Itoa(value)
+}
@@ -0,0 +1,95 @@
+package routes
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// KeyVerifier looks up the principal an API key acts for.
+type KeyVerifier interface {
+	VerifyAPIKey(key string) (models.Principal, error)
+}
+
+// authenticate reads the bearer token or API key of a request, if it has
+// one, and puts the principal it was issued to in the request context.
+// Requests without an Authorization header continue anonymously; a token
+// that is malformed, expired or badly signed, or a key that is unknown or
+// revoked, is rejected.
+func (app *Application) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, credentials, _ := strings.Cut(header, " ")
+		credentials = strings.TrimSpace(credentials)
+		if strings.EqualFold(scheme, "ApiKey") && app.APIKeys != nil {
+			app.authenticateKey(next, w, r, credentials)
+			return
+		}
+		if !strings.EqualFold(scheme, "Bearer") || credentials == "" || app.Tokens == nil {
+			unauthorized(w, appconst.Invalidtoken)
+			return
+		}
+		principal, err := app.Tokens.Verify(credentials)
+		if err != nil {
+			log.Println(appconst.Invalidtoken, err)
+			unauthorized(w, appconst.Invalidtoken)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+	})
+}
+
+// authenticateKey serves a request made with an API key as the user the
+// key acts for, limited to the scopes of the key.
+func (app *Application) authenticateKey(next http.Handler, w http.ResponseWriter, r *http.Request, key string) {
+	principal, err := app.APIKeys.VerifyAPIKey(key)
+	if errors.Is(err, apperrors.ErrUnauthorized) {
+		log.Println(appconst.Invalidapikey, err)
+		unauthorized(w, appconst.Invalidapikey)
+		return
+	}
+	if err != nil {
+		log.Println(appconst.Invalidapikey, err)
+		utility.WriteJSON(w, http.StatusInternalServerError, models.Response{Data: nil, Status: http.StatusInternalServerError, Message: appconst.Internalerror})
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+}
+
+// requireAuth rejects anonymous requests.
+func requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth.FromContext(r.Context()).Anonymous() {
+			unauthorized(w, appconst.Unauthenticated)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// unauthorized writes a 401 asking for a bearer token, as RFC 6750
+// describes, or for another API key when the one sent was rejected.
+func unauthorized(w http.ResponseWriter, message string) {
+	challenge := `Bearer`
+	switch message {
+	case appconst.Invalidtoken:
+		challenge = `Bearer error="invalid_token"`
+	case appconst.Invalidapikey:
+		challenge = `ApiKey error="invalid_token"`
+	}
+	w.Header().Set("WWW-Authenticate", challenge)
+	utility.WriteJSON(w, http.StatusUnauthorized, models.Response{Data: nil, Status: http.StatusUnauthorized, Message: message})
+}
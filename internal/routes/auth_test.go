@@ -0,0 +1,204 @@
+package routes
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// signedInApp returns an application that verifies tokens, and a token of
+// a signed in user.
+func signedInApp(t *testing.T) (*Application, string) {
+	tokens, err := auth.NewTokens(auth.Config{Algorithm: auth.HS256, Secret: []byte("test secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	assert.NoError(t, err)
+	token, _, err := tokens.Issue(&models.User{ID: 7, Username: "ada"})
+	assert.NoError(t, err)
+	return &Application{Tokens: tokens, APIKeys: testKeys{}}, token
+}
+
+// testKeys knows a single API key, "ak_0123abcd_secret", and fails to look
+// up "ak_0123abcd_broken"
+type testKeys struct{}
+
+func (testKeys) VerifyAPIKey(key string) (models.Principal, error) {
+	switch key {
+	case "ak_0123abcd_secret":
+		return models.Principal{UserID: 8, Username: "ci", Scopes: []string{models.ScopeArticlesRead}}, nil
+	case "ak_0123abcd_broken":
+		return models.Principal{}, errors.New("connection refused")
+	}
+	return models.Principal{}, apperrors.Unauthorized(appconst.Invalidapikey, nil)
+}
+
+func TestAuthenticate(t *testing.T) {
+	app, token := signedInApp(t)
+
+	// whoami writes the principal the middleware put in the context
+	whoami := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := auth.FromContext(r.Context())
+		fmt.Fprintf(w, "%d %s", principal.UserID, principal.Username)
+	})
+
+	testCases := []struct {
+		name              string
+		authorization     string
+		handler           http.Handler
+		expectedCode      int
+		expectedBody      string
+		expectedChallenge string
+	}{
+		{
+			name:         "Anonymous request",
+			handler:      app.authenticate(whoami),
+			expectedCode: http.StatusOK,
+			expectedBody: "0 ",
+		},
+		{
+			name:          "Valid token",
+			authorization: "Bearer " + token,
+			handler:       app.authenticate(whoami),
+			expectedCode:  http.StatusOK,
+			expectedBody:  "7 ada",
+		},
+		{
+			name:          "Scheme is case insensitive",
+			authorization: "bearer " + token,
+			handler:       app.authenticate(whoami),
+			expectedCode:  http.StatusOK,
+			expectedBody:  "7 ada",
+		},
+		{
+			name:              "Invalid token",
+			authorization:     "Bearer " + token + "x",
+			handler:           app.authenticate(whoami),
+			expectedCode:      http.StatusUnauthorized,
+			expectedBody:      `{"status":401,"message":"access token is invalid or has expired","data":null}`,
+			expectedChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:          "Valid API key",
+			authorization: "ApiKey ak_0123abcd_secret",
+			handler:       app.authenticate(whoami),
+			expectedCode:  http.StatusOK,
+			expectedBody:  "8 ci",
+		},
+		{
+			name:              "Revoked API key",
+			authorization:     "apikey ak_0123abcd_revoked",
+			handler:           app.authenticate(whoami),
+			expectedCode:      http.StatusUnauthorized,
+			expectedBody:      `{"status":401,"message":"API key is invalid or has been revoked","data":null}`,
+			expectedChallenge: `ApiKey error="invalid_token"`,
+		},
+		{
+			name:          "API key lookup fails",
+			authorization: "ApiKey ak_0123abcd_broken",
+			handler:       app.authenticate(whoami),
+			expectedCode:  http.StatusInternalServerError,
+			expectedBody:  `{"status":500,"message":"Internal server error","data":null}`,
+		},
+		{
+			name:          "API key on a protected route",
+			authorization: "ApiKey ak_0123abcd_secret",
+			handler:       app.authenticate(requireAuth(whoami)),
+			expectedCode:  http.StatusOK,
+			expectedBody:  "8 ci",
+		},
+		{
+			name:              "Other scheme",
+			authorization:     "Basic YWRhOnNlY3JldA==",
+			handler:           app.authenticate(whoami),
+			expectedCode:      http.StatusUnauthorized,
+			expectedBody:      `{"status":401,"message":"access token is invalid or has expired","data":null}`,
+			expectedChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:              "Anonymous request to a protected route",
+			handler:           app.authenticate(requireAuth(whoami)),
+			expectedCode:      http.StatusUnauthorized,
+			expectedBody:      `{"status":401,"message":"sign in to do this","data":null}`,
+			expectedChallenge: "Bearer",
+		},
+		{
+			name:          "Signed in request to a protected route",
+			authorization: "Bearer " + token,
+			handler:       app.authenticate(requireAuth(whoami)),
+			expectedCode:  http.StatusOK,
+			expectedBody:  "7 ada",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			tc.handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+			assert.Equal(t, tc.expectedBody, recorder.Body.String())
+			assert.Equal(t, tc.expectedChallenge, recorder.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestRoutes_WritesNeedAuthentication(t *testing.T) {
+	testCases := []struct {
+		method string
+		path   string
+	}{
+		{method: "GET", path: "/articles/scheduled"},
+		{method: "GET", path: "/articles/1/revisions"},
+		{method: "GET", path: "/articles/1/revisions/1"},
+		{method: "GET", path: "/articles/1/revisions/1/diff"},
+		{method: "POST", path: "/articles"},
+		{method: "PUT", path: "/articles/1"},
+		{method: "PATCH", path: "/articles/1"},
+		{method: "DELETE", path: "/articles/1"},
+		{method: "POST", path: "/articles/1/submit"},
+		{method: "POST", path: "/articles/1/publish"},
+		{method: "POST", path: "/articles/1/unpublish"},
+		{method: "POST", path: "/articles/1/archive"},
+		{method: "POST", path: "/articles/1/revisions/1/restore"},
+		{method: "POST", path: "/articles/1/comments"},
+		{method: "PUT", path: "/articles/1/comments/1"},
+		{method: "DELETE", path: "/articles/1/comments/1"},
+		{method: "GET", path: "/moderation/comments"},
+		{method: "POST", path: "/moderation/comments/1/approve"},
+		{method: "POST", path: "/moderation/comments/1/reject"},
+		{method: "POST", path: "/moderation/comments/1/spam"},
+		{method: "PUT", path: "/tags/go"},
+		{method: "POST", path: "/tags/go/merge"},
+		{method: "POST", path: "/categories"},
+		{method: "PUT", path: "/categories/news"},
+		{method: "DELETE", path: "/categories/news"},
+		{method: "GET", path: "/users"},
+		{method: "PUT", path: "/users/1/role"},
+		{method: "POST", path: "/api-keys"},
+		{method: "GET", path: "/api-keys"},
+		{method: "DELETE", path: "/api-keys/1"},
+	}
+
+	app, _ := signedInApp(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			recorder := httptest.NewRecorder()
+			app.Routes().ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+		})
+	}
+}
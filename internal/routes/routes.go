@@ -2,6 +2,7 @@ package routes
 
 import (
 	"backend/internal/controller"
+	"backend/pkg/auth"
 	"backend/pkg/repository/dbrepo"
 	services "backend/services/articles"
 	"net/http"
@@ -18,6 +19,8 @@ type Application struct {
 	Utility        controller.UtilityInterface
 	ArticleService *services.ArticleService
 	Handler        controller.Controller
+	Tokens         *auth.Tokens
+	APIKeys        KeyVerifier
 }
 
 func (app *Application) Routes() http.Handler {
@@ -26,7 +29,7 @@ func (app *Application) Routes() http.Handler {
 	// Create a new CORS middleware instance with your desired options.
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"}, // Replace with allowed origins
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"*"},
 		AllowCredentials: true,
 		MaxAge:           600,
@@ -34,10 +37,68 @@ func (app *Application) Routes() http.Handler {
 	// Use the CORS middleware
 	mux.Use(c.Handler)
 	mux.Use(middleware.Recoverer)
+	mux.Use(app.authenticate)
 	mux.Get("/", app.Handler.HealthCheck)
 	mux.Get("/articles", app.Handler.AllArticle)
+	mux.Get("/articles/search", app.Handler.SearchArticles)
 	mux.Get("/articles/{id}", app.Handler.GetArticle)
-	mux.Post("/articles", app.Handler.InsertArticle)
+	mux.Get("/articles/by-slug/{slug}", app.Handler.GetArticleBySlug)
+	mux.Get("/articles/{id}/comments", app.Handler.ArticleComments)
+	mux.Get("/tags", app.Handler.ListTags)
+	mux.Get("/tags/{slug}/articles", app.Handler.TagArticles)
+	mux.Get("/tags/{slug}/feed.{format}", app.Handler.TagFeed)
+	mux.Get("/categories", app.Handler.ListCategories)
+	mux.Get("/categories/{slug}/articles", app.Handler.CategoryArticles)
+	mux.Get("/feed.{format}", app.Handler.Feed)
+	mux.Get("/authors/{username}/feed.{format}", app.Handler.AuthorFeed)
+	mux.Get("/sitemap.xml", app.Handler.Sitemap)
+	mux.Get("/sitemaps/{page}.xml", app.Handler.SitemapPage)
+	mux.Get("/robots.txt", app.Handler.RobotsTxt)
+	mux.Get("/media/{id}", app.Handler.GetMedia)
+	mux.Get("/media/{id}/variants/{width}", app.Handler.GetMediaVariant)
+	mux.Post("/auth/register", app.Handler.Register)
+	mux.Post("/auth/login", app.Handler.Login)
+	mux.Post("/auth/refresh", app.Handler.Refresh)
+	mux.Post("/auth/logout", app.Handler.Logout)
+	// Mutations are authorized by the services they call, like the REST
+	// endpoints below
+	mux.Post("/graphql", app.Handler.GraphQL)
+
+	// Every change needs a signed in user
+	mux.Group(func(mux chi.Router) {
+		mux.Use(requireAuth)
+		mux.Get("/articles/scheduled", app.Handler.ScheduledArticles)
+		mux.Get("/articles/{id}/revisions", app.Handler.ArticleRevisions)
+		mux.Get("/articles/{id}/revisions/{rev}", app.Handler.GetRevision)
+		mux.Get("/articles/{id}/revisions/{rev}/diff", app.Handler.DiffRevisions)
+		mux.Post("/articles", app.Handler.InsertArticle)
+		mux.Put("/articles/{id}", app.Handler.UpdateArticle)
+		mux.Patch("/articles/{id}", app.Handler.PatchArticle)
+		mux.Delete("/articles/{id}", app.Handler.DeleteArticle)
+		mux.Post("/articles/{id}/submit", app.Handler.SubmitArticle)
+		mux.Post("/articles/{id}/publish", app.Handler.PublishArticle)
+		mux.Post("/articles/{id}/unpublish", app.Handler.UnpublishArticle)
+		mux.Post("/articles/{id}/archive", app.Handler.ArchiveArticle)
+		mux.Post("/articles/{id}/revisions/{rev}/restore", app.Handler.RestoreRevision)
+		mux.Post("/articles/{id}/comments", app.Handler.PostComment)
+		mux.Put("/articles/{id}/comments/{comment}", app.Handler.EditComment)
+		mux.Delete("/articles/{id}/comments/{comment}", app.Handler.DeleteComment)
+		mux.Get("/moderation/comments", app.Handler.CommentQueue)
+		mux.Post("/moderation/comments/{id}/approve", app.Handler.ApproveComment)
+		mux.Post("/moderation/comments/{id}/reject", app.Handler.RejectComment)
+		mux.Post("/moderation/comments/{id}/spam", app.Handler.SpamComment)
+		mux.Put("/tags/{slug}", app.Handler.RenameTag)
+		mux.Post("/tags/{slug}/merge", app.Handler.MergeTag)
+		mux.Post("/categories", app.Handler.CreateCategory)
+		mux.Put("/categories/{slug}", app.Handler.UpdateCategory)
+		mux.Delete("/categories/{slug}", app.Handler.DeleteCategory)
+		mux.Post("/media", app.Handler.UploadMedia)
+		mux.Get("/users", app.Handler.ListUsers)
+		mux.Put("/users/{id}/role", app.Handler.SetUserRole)
+		mux.Post("/api-keys", app.Handler.CreateAPIKey)
+		mux.Get("/api-keys", app.Handler.ListAPIKeys)
+		mux.Delete("/api-keys/{id}", app.Handler.RevokeAPIKey)
+	})
 
 	return mux
 }
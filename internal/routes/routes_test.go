@@ -31,8 +31,58 @@ func TestRoutes(t *testing.T) {
 	router := chi.NewRouter()
 	router.Use(middleware.Recoverer)
 	router.Get("/articles", mockApp.AllArticle)
+	router.Get("/articles/search", mockApp.SearchArticles)
+	router.Get("/articles/scheduled", mockApp.ScheduledArticles)
 	router.Get("/articles/{id}", mockApp.GetArticle)
+	router.Get("/articles/by-slug/{slug}", mockApp.GetArticleBySlug)
 	router.Post("/articles", mockApp.InsertArticle)
+	router.Put("/articles/{id}", mockApp.UpdateArticle)
+	router.Patch("/articles/{id}", mockApp.PatchArticle)
+	router.Delete("/articles/{id}", mockApp.DeleteArticle)
+	router.Post("/articles/{id}/submit", mockApp.SubmitArticle)
+	router.Post("/articles/{id}/publish", mockApp.PublishArticle)
+	router.Post("/articles/{id}/unpublish", mockApp.UnpublishArticle)
+	router.Post("/articles/{id}/archive", mockApp.ArchiveArticle)
+	router.Get("/articles/{id}/revisions", mockApp.ArticleRevisions)
+	router.Get("/articles/{id}/revisions/{rev}", mockApp.GetRevision)
+	router.Get("/articles/{id}/revisions/{rev}/diff", mockApp.DiffRevisions)
+	router.Post("/articles/{id}/revisions/{rev}/restore", mockApp.RestoreRevision)
+	router.Get("/articles/{id}/comments", mockApp.ArticleComments)
+	router.Post("/articles/{id}/comments", mockApp.PostComment)
+	router.Put("/articles/{id}/comments/{comment}", mockApp.EditComment)
+	router.Delete("/articles/{id}/comments/{comment}", mockApp.DeleteComment)
+	router.Get("/moderation/comments", mockApp.CommentQueue)
+	router.Post("/moderation/comments/{id}/approve", mockApp.ApproveComment)
+	router.Post("/moderation/comments/{id}/reject", mockApp.RejectComment)
+	router.Post("/moderation/comments/{id}/spam", mockApp.SpamComment)
+	router.Get("/tags", mockApp.ListTags)
+	router.Get("/tags/{slug}/articles", mockApp.TagArticles)
+	router.Put("/tags/{slug}", mockApp.RenameTag)
+	router.Post("/tags/{slug}/merge", mockApp.MergeTag)
+	router.Get("/tags/{slug}/feed.{format}", mockApp.TagFeed)
+	router.Get("/categories", mockApp.ListCategories)
+	router.Get("/categories/{slug}/articles", mockApp.CategoryArticles)
+	router.Post("/categories", mockApp.CreateCategory)
+	router.Put("/categories/{slug}", mockApp.UpdateCategory)
+	router.Delete("/categories/{slug}", mockApp.DeleteCategory)
+	router.Post("/media", mockApp.UploadMedia)
+	router.Get("/feed.{format}", mockApp.Feed)
+	router.Get("/authors/{username}/feed.{format}", mockApp.AuthorFeed)
+	router.Get("/sitemap.xml", mockApp.Sitemap)
+	router.Get("/sitemaps/{page}.xml", mockApp.SitemapPage)
+	router.Get("/robots.txt", mockApp.RobotsTxt)
+	router.Get("/media/{id}", mockApp.GetMedia)
+	router.Get("/media/{id}/variants/{width}", mockApp.GetMediaVariant)
+	router.Post("/auth/register", mockApp.Register)
+	router.Post("/auth/login", mockApp.Login)
+	router.Post("/auth/refresh", mockApp.Refresh)
+	router.Post("/auth/logout", mockApp.Logout)
+	router.Post("/graphql", mockApp.GraphQL)
+	router.Get("/users", mockApp.ListUsers)
+	router.Put("/users/{id}/role", mockApp.SetUserRole)
+	router.Post("/api-keys", mockApp.CreateAPIKey)
+	router.Get("/api-keys", mockApp.ListAPIKeys)
+	router.Delete("/api-keys/{id}", mockApp.RevokeAPIKey)
 
 	// Serve the request
 	router.ServeHTTP(recorder, req)
@@ -165,11 +215,90 @@ func TestRoutes_InsertArticle(t *testing.T) {
 	}
 
 	// Create an instance of the actual application
-	app := &Application{}
+	app, token := signedInApp(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			recorder := httptest.NewRecorder()
+			router := app.Routes()
+			router.ServeHTTP(recorder, req)
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+		})
+	}
+}
+
+func TestRoutes_ModifyArticle(t *testing.T) {
+	testCases := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "Negative test case for UpdateArticle",
+			method:       "PUT",
+			path:         "/articles/abc",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for PatchArticle",
+			method:       "PATCH",
+			path:         "/articles/abc",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for SearchArticles",
+			method:       "GET",
+			path:         "/articles/search?limit=0",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for ScheduledArticles",
+			method:       "GET",
+			path:         "/articles/scheduled?offset=-1",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for DeleteArticle",
+			method:       "DELETE",
+			path:         "/articles/abc",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for SubmitArticle",
+			method:       "POST",
+			path:         "/articles/abc/submit",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for PublishArticle",
+			method:       "POST",
+			path:         "/articles/abc/publish",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for UnpublishArticle",
+			method:       "POST",
+			path:         "/articles/abc/unpublish",
+			expectedCode: 400,
+		},
+		{
+			name:         "Negative test case for ArchiveArticle",
+			method:       "POST",
+			path:         "/articles/abc/archive",
+			expectedCode: 400,
+		},
+	}
+
+	// Create an instance of the actual application
+	app, token := signedInApp(t)
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
 			recorder := httptest.NewRecorder()
 			router := app.Routes()
 			router.ServeHTTP(recorder, req)
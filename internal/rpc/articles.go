@@ -0,0 +1,220 @@
+package rpc
+
+import (
+	articlesv1 "backend/api/articles/v1"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"backend/pkg/utility"
+	services "backend/services/articles"
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// articleServer implements articles.v1.ArticleService over the article
+// service, which authorizes every call as it does for the REST endpoints.
+type articleServer struct {
+	articlesv1.UnimplementedArticleServiceServer
+	articles services.ArticleServices
+	// stopping is closed when the server shuts down, ending the watches
+	stopping <-chan struct{}
+}
+
+func (s *articleServer) GetArticle(ctx context.Context, req *articlesv1.GetArticleRequest) (*articlesv1.GetArticleResponse, error) {
+	viewer := auth.FromContext(ctx)
+
+	var article *models.Article
+	var err error
+	switch key := req.Key.(type) {
+	case *articlesv1.GetArticleRequest_Id:
+		id, idErr := toID(key.Id, "id")
+		if idErr != nil {
+			return nil, idErr
+		}
+		article, err = s.articles.GetArticleByID(id, viewer)
+	case *articlesv1.GetArticleRequest_Slug:
+		article, err = s.articles.GetArticleBySlug(key.Slug, viewer)
+	default:
+		return nil, badInput(appconst.Articleselector)
+	}
+	if err != nil {
+		return nil, callError(err)
+	}
+	return &articlesv1.GetArticleResponse{Article: toArticle(article)}, nil
+}
+
+func (s *articleServer) ListArticles(ctx context.Context, req *articlesv1.ListArticlesRequest) (*articlesv1.ListArticlesResponse, error) {
+	params, err := listParams(req)
+	if err != nil {
+		return nil, err
+	}
+	params.Viewer = auth.FromContext(ctx)
+
+	page, err := s.articles.GetAllArticles(params)
+	if err != nil {
+		return nil, callError(err)
+	}
+
+	response := &articlesv1.ListArticlesResponse{
+		Articles:  make([]*articlesv1.Article, len(page.Articles)),
+		TotalSize: int32(page.PageInfo.Total),
+	}
+	for i := range page.Articles {
+		response.Articles[i] = toArticle(&page.Articles[i])
+	}
+	if page.PageInfo.HasNext {
+		response.NextPageToken = page.PageInfo.NextCursor
+	}
+	if page.PageInfo.HasPrev {
+		response.PrevPageToken = page.PageInfo.PrevCursor
+	}
+	return response, nil
+}
+
+func (s *articleServer) CreateArticle(ctx context.Context, req *articlesv1.CreateArticleRequest) (*articlesv1.CreateArticleResponse, error) {
+	actor := auth.FromContext(ctx)
+	article, err := fromInput(req.Article)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.articles.CreateArticle(article, actor)
+	if err != nil {
+		return nil, callError(err)
+	}
+	article, err = s.articles.GetArticleByID(id, actor)
+	if err != nil {
+		return nil, callError(err)
+	}
+	return &articlesv1.CreateArticleResponse{Article: toArticle(article)}, nil
+}
+
+func (s *articleServer) UpdateArticle(ctx context.Context, req *articlesv1.UpdateArticleRequest) (*articlesv1.UpdateArticleResponse, error) {
+	id, err := toID(req.Id, "id")
+	if err != nil {
+		return nil, err
+	}
+	article, err := fromInput(req.Article)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err = s.articles.UpdateArticle(id, article, auth.FromContext(ctx))
+	if err != nil {
+		return nil, callError(err)
+	}
+	return &articlesv1.UpdateArticleResponse{Article: toArticle(article)}, nil
+}
+
+func (s *articleServer) DeleteArticle(ctx context.Context, req *articlesv1.DeleteArticleRequest) (*articlesv1.DeleteArticleResponse, error) {
+	id, err := toID(req.Id, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.articles.DeleteArticle(id, auth.FromContext(ctx)); err != nil {
+		return nil, callError(err)
+	}
+	return &articlesv1.DeleteArticleResponse{}, nil
+}
+
+// WatchArticles sends the changes to the articles the caller may see until
+// the caller goes away, falls behind or the server shuts down. Whether the
+// caller may see an article is decided on the article as it is in the
+// event, so a watcher sees an article being unpublished but none of the
+// changes to it after that.
+func (s *articleServer) WatchArticles(_ *articlesv1.WatchArticlesRequest, stream articlesv1.ArticleService_WatchArticlesServer) error {
+	viewer := auth.FromContext(stream.Context())
+	events, stop := s.articles.WatchArticles()
+	defer stop()
+
+	// Send the headers right away so callers know when changes are being
+	// watched
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.stopping:
+			return status.Error(codes.Unavailable, appconst.Serverstopping)
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, appconst.Watchbehind)
+			}
+			if !policy.Can(viewer, policy.ViewArticle, &event.Article) {
+				continue
+			}
+			err := stream.Send(&articlesv1.WatchArticlesResponse{Type: eventTypes[event.Type], Article: toArticle(&event.Article)})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// listParams reads the paging, sort and filter fields of a list request as
+// the article listing reads its query parameters.
+func listParams(req *articlesv1.ListArticlesRequest) (models.ListParams, error) {
+	var params models.ListParams
+
+	if req.PageSize != 0 {
+		if req.PageSize < 1 || req.PageSize > models.MaxPageSize {
+			return params, badInput(fmt.Sprintf(appconst.Invalidlimit, models.MaxPageSize))
+		}
+		params.Limit = int(req.PageSize)
+	}
+
+	if req.OrderBy != "" {
+		sort, ok := models.ParseSort(req.OrderBy)
+		if !ok {
+			return params, badInput(fmt.Sprintf(appconst.Invalidsort, strings.Join(models.ArticleSortFields, ", ")))
+		}
+		params.Sort = sort
+	}
+
+	if req.PageToken != "" {
+		cursor, err := models.DecodeCursor(req.PageToken)
+		if err != nil {
+			return params, badInput(appconst.Invalidcursor)
+		}
+		params.Cursor = cursor
+	}
+
+	params.Normalize()
+	if params.Cursor != nil && params.Cursor.Sort != params.Sort.String() {
+		return params, badInput(appconst.Cursorsort)
+	}
+
+	params.Filter.Author = req.Author
+	params.Filter.Tag = utility.Slugify(req.Tag)
+	params.Filter.Category = req.Category
+
+	var err error
+	if params.Filter.CreatedAfter, err = fromTimestamp(req.CreatedAfter, "created_after"); err != nil {
+		return params, err
+	}
+	if params.Filter.CreatedBefore, err = fromTimestamp(req.CreatedBefore, "created_before"); err != nil {
+		return params, err
+	}
+
+	if req.Status != articlesv1.Status_STATUS_UNSPECIFIED {
+		for name, value := range statuses {
+			if value == req.Status {
+				params.Filter.Status = name
+			}
+		}
+		if params.Filter.Status == "" {
+			return params, badInput(fmt.Sprintf(appconst.Invalidstatus, strings.Join(models.ArticleStatuses, ", ")))
+		}
+	}
+
+	return params, nil
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// KeyVerifier looks up the principal an API key acts for.
+type KeyVerifier interface {
+	VerifyAPIKey(key string) (models.Principal, error)
+}
+
+// authenticator reads the credentials of calls the way the REST API reads
+// the Authorization header.
+type authenticator struct {
+	tokens *auth.Tokens
+	keys   KeyVerifier
+}
+
+// principal returns the principal the bearer token or API key in the
+// authorization metadata of a call was issued to. Calls without one are
+// anonymous; a token that is malformed, expired or badly signed, or a key
+// that is unknown or revoked, is rejected.
+func (a *authenticator) principal(ctx context.Context) (models.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || values[0] == "" {
+		return models.Principal{}, nil
+	}
+
+	scheme, credentials, _ := strings.Cut(values[0], " ")
+	credentials = strings.TrimSpace(credentials)
+	if strings.EqualFold(scheme, "ApiKey") && a.keys != nil {
+		principal, err := a.keys.VerifyAPIKey(credentials)
+		if errors.Is(err, apperrors.ErrUnauthorized) {
+			log.Println(appconst.Invalidapikey, err)
+			return principal, status.Error(codes.Unauthenticated, appconst.Invalidapikey)
+		}
+		if err != nil {
+			log.Println(appconst.Invalidapikey, err)
+			return principal, status.Error(codes.Internal, appconst.Internalerror)
+		}
+		return principal, nil
+	}
+	if !strings.EqualFold(scheme, "Bearer") || credentials == "" || a.tokens == nil {
+		return models.Principal{}, status.Error(codes.Unauthenticated, appconst.Invalidtoken)
+	}
+	principal, err := a.tokens.Verify(credentials)
+	if err != nil {
+		log.Println(appconst.Invalidtoken, err)
+		return principal, status.Error(codes.Unauthenticated, appconst.Invalidtoken)
+	}
+	return principal, nil
+}
+
+// unary authenticates unary calls, putting the principal in the context
+// of the handler.
+func (a *authenticator) unary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	principal, err := a.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(auth.NewContext(ctx, principal), req)
+}
+
+// stream authenticates streaming calls like unary does unary ones.
+func (a *authenticator) stream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	principal, err := a.principal(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: auth.NewContext(ss.Context(), principal)})
+}
+
+// authenticatedStream is a server stream carrying the principal of the
+// call in its context.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
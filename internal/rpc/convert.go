@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	articlesv1 "backend/api/articles/v1"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// statuses maps the article statuses to their enum values
+var statuses = map[string]articlesv1.Status{
+	models.StatusDraft:     articlesv1.Status_STATUS_DRAFT,
+	models.StatusInReview:  articlesv1.Status_STATUS_IN_REVIEW,
+	models.StatusPublished: articlesv1.Status_STATUS_PUBLISHED,
+	models.StatusArchived:  articlesv1.Status_STATUS_ARCHIVED,
+}
+
+// eventTypes maps the kinds of article events to their enum values
+var eventTypes = map[string]articlesv1.WatchArticlesResponse_EventType{
+	models.EventCreated: articlesv1.WatchArticlesResponse_EVENT_TYPE_CREATED,
+	models.EventUpdated: articlesv1.WatchArticlesResponse_EVENT_TYPE_UPDATED,
+	models.EventDeleted: articlesv1.WatchArticlesResponse_EVENT_TYPE_DELETED,
+}
+
+// toArticle returns the message of an article.
+func toArticle(article *models.Article) *articlesv1.Article {
+	message := &articlesv1.Article{
+		Id:          int64(article.ID),
+		Title:       article.Title,
+		Slug:        article.Slug,
+		Content:     article.Content,
+		ContentHtml: article.ContentHTML,
+		Author:      article.Author,
+		AuthorId:    int64(article.AuthorID),
+		Status:      statuses[article.Status],
+		CreatedAt:   toTimestamp(article.CreatedAt),
+		UpdatedAt:   toTimestamp(article.UpdatedAt),
+		PublishedAt: toTimestamp(article.PublishedAt),
+		PublishAt:   toTimestamp(article.PublishAt),
+		CreatedBy:   article.CreatedBy,
+		UpdatedBy:   article.UpdatedBy,
+		Revision:    int32(article.Revision),
+		Tags:        article.Tags,
+		Categories:  article.Categories,
+	}
+	if article.CoverID != nil {
+		cover := int64(*article.CoverID)
+		message.CoverId = &cover
+	}
+	return message
+}
+
+// fromInput returns the article a caller wrote.
+func fromInput(input *articlesv1.ArticleInput) (*models.Article, error) {
+	if input == nil {
+		return &models.Article{}, nil
+	}
+	article := &models.Article{
+		Title:      input.Title,
+		Content:    input.Content,
+		Tags:       input.Tags,
+		Categories: input.Categories,
+	}
+
+	if input.AuthorId != 0 {
+		authorID, err := toID(input.AuthorId, "author_id")
+		if err != nil {
+			return nil, err
+		}
+		article.AuthorID = authorID
+	}
+	if input.CoverId != nil {
+		coverID, err := toID(*input.CoverId, "cover_id")
+		if err != nil {
+			return nil, err
+		}
+		article.CoverID = &coverID
+	}
+
+	publishAt, err := fromTimestamp(input.PublishAt, "publish_at")
+	if err != nil {
+		return nil, err
+	}
+	article.PublishAt = publishAt
+	return article, nil
+}
+
+// toID reads an ID field of a request.
+func toID(value int64, name string) (int, error) {
+	id := int(value)
+	if value < 1 || int64(id) != value {
+		return 0, badInput(fmt.Sprintf(appconst.Invalidid, name))
+	}
+	return id, nil
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// fromTimestamp reads an optional timestamp field of a request.
+func fromTimestamp(ts *timestamppb.Timestamp, name string) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, badInput(fmt.Sprintf(appconst.Invalidtimestamp, name))
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorCode returns the status code of a domain error.
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		return codes.FailedPrecondition
+	case errors.Is(err, apperrors.ErrValidation), errors.Is(err, apperrors.ErrTooLarge), errors.Is(err, apperrors.ErrUnsupported):
+		return codes.InvalidArgument
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return codes.Unauthenticated
+	case errors.Is(err, apperrors.ErrForbidden):
+		return codes.PermissionDenied
+	case errors.Is(err, apperrors.ErrTimeout):
+		return codes.DeadlineExceeded
+	case errors.Is(err, apperrors.ErrUnavailable):
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// callError turns an error of a service into the status of a call. Like
+// writeError of the REST handlers it only passes on the client-safe message
+// of a domain error, so driver details never leak.
+func callError(err error) error {
+	code := errorCode(err)
+	message, ok := apperrors.Message(err)
+	if !ok || code == codes.Internal {
+		log.Println(appconst.Grpcerror, err)
+		code = codes.Internal
+		message = appconst.Internalerror
+	}
+	return status.Error(code, message)
+}
+
+// badInput returns the status of a request field that is not valid.
+func badInput(message string) error {
+	return status.Error(codes.InvalidArgument, message)
+}
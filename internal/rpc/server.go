@@ -0,0 +1,74 @@
+// Package rpc serves the articles over gRPC, for internal services that
+// want typed access to them rather than JSON over HTTP.
+package rpc
+
+import (
+	articlesv1 "backend/api/articles/v1"
+	"backend/pkg/auth"
+	services "backend/services/articles"
+	"context"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is a gRPC server serving articles.v1.ArticleService, the standard
+// health checking protocol and server reflection, so tools like grpcurl
+// can call it without the proto files.
+type Server struct {
+	grpc     *grpc.Server
+	health   *health.Server
+	stopping chan struct{}
+	once     sync.Once
+}
+
+// NewServer returns a server for the article service. Calls are
+// authenticated with the access tokens and API keys of the REST API.
+func NewServer(articles services.ArticleServices, tokens *auth.Tokens, keys KeyVerifier) *Server {
+	authn := &authenticator{tokens: tokens, keys: keys}
+	s := &Server{
+		grpc: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(authn.unary),
+			grpc.ChainStreamInterceptor(authn.stream),
+		),
+		health:   health.NewServer(),
+		stopping: make(chan struct{}),
+	}
+
+	articlesv1.RegisterArticleServiceServer(s.grpc, &articleServer{articles: articles, stopping: s.stopping})
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	s.health.SetServingStatus(articlesv1.ArticleService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return s
+}
+
+// Serve accepts connections on lis until Shutdown is called.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown reports the server as not serving, ends the watches and waits
+// for the calls in flight to finish. When ctx is done first the remaining
+// calls are cancelled.
+func (s *Server) Shutdown(ctx context.Context) {
+	s.once.Do(func() {
+		s.health.Shutdown()
+		close(s.stopping)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.grpc.Stop()
+		<-done
+	}
+}
@@ -0,0 +1,382 @@
+package rpc
+
+import (
+	articlesv1 "backend/api/articles/v1"
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	services "backend/services/articles"
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testKeys knows a single API key, "ak_0123abcd_secret", and fails to look
+// up "ak_0123abcd_broken"
+type testKeys struct{}
+
+func (testKeys) VerifyAPIKey(key string) (models.Principal, error) {
+	switch key {
+	case "ak_0123abcd_secret":
+		return models.Principal{UserID: 8, Username: "ci", Role: models.RoleEditor, Scopes: []string{models.ScopeArticlesRead}}, nil
+	case "ak_0123abcd_broken":
+		return models.Principal{}, errors.New("connection refused")
+	}
+	return models.Principal{}, apperrors.Unauthorized(appconst.Invalidapikey, nil)
+}
+
+// testServer serves articles over an in-memory listener. It returns a
+// connection to it, the server and a token of the author ada.
+func testServer(t *testing.T, articles services.ArticleServices) (*grpc.ClientConn, *Server, string) {
+	tokens, err := auth.NewTokens(auth.Config{Algorithm: auth.HS256, Secret: []byte("test secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	assert.NoError(t, err)
+	token, _, err := tokens.Issue(&models.User{ID: 7, Username: "ada", Role: models.RoleAuthor})
+	assert.NoError(t, err)
+
+	lis := bufconn.Listen(1 << 20)
+	server := NewServer(articles, tokens, testKeys{})
+	go server.Serve(lis)
+	t.Cleanup(func() { server.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn, server, token
+}
+
+// withAuthorization returns a context sending authorization metadata.
+func withAuthorization(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", authorization)
+}
+
+func TestArticleService(t *testing.T) {
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	cover := 3
+	published := &models.Article{ID: 1, Title: "First", Slug: "first", AuthorID: 7, Author: "ada", Status: models.StatusPublished, Tags: []string{"Go"}, CreatedAt: &created, CoverID: &cover}
+	draft := &models.Article{ID: 2, Title: "Draft", Slug: "draft", AuthorID: 9, Status: models.StatusDraft}
+
+	testCases := []struct {
+		name          string
+		authorization string // "token" is replaced by the token of ada
+		call          func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error)
+		mockDBExpect  func(db *mocks.MockDBInterface)
+		expected      interface{}
+		expectedCode  codes.Code
+		expectedError string
+	}{
+		{
+			name: "Get by ID",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				res, err := client.GetArticle(ctx, &articlesv1.GetArticleRequest{Key: &articlesv1.GetArticleRequest_Id{Id: 1}})
+				return res.GetArticle(), err
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+			},
+			expected: &articlesv1.Article{Id: 1, Title: "First", Slug: "first", AuthorId: 7, Author: "ada", Status: articlesv1.Status_STATUS_PUBLISHED, Tags: []string{"Go"}, CreatedAt: timestamppb.New(created), CoverId: proto.Int64(3)},
+		},
+		{
+			name: "Get by slug",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				res, err := client.GetArticle(ctx, &articlesv1.GetArticleRequest{Key: &articlesv1.GetArticleRequest_Slug{Slug: "first"}})
+				return res.GetArticle().GetSlug(), err
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().ArticleBySlug("first").Return(published, nil)
+			},
+			expected: "first",
+		},
+		{
+			name: "Get hidden article",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.GetArticle(ctx, &articlesv1.GetArticleRequest{Key: &articlesv1.GetArticleRequest_Id{Id: 2}})
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(2).Return(draft, nil)
+			},
+			expectedCode:  codes.NotFound,
+			expectedError: appconst.NoArticleforid,
+		},
+		{
+			name:          "Get hidden article with an API key",
+			authorization: "ApiKey ak_0123abcd_secret",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				res, err := client.GetArticle(ctx, &articlesv1.GetArticleRequest{Key: &articlesv1.GetArticleRequest_Id{Id: 2}})
+				return res.GetArticle().GetStatus(), err
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(2).Return(draft, nil)
+			},
+			expected: articlesv1.Status_STATUS_DRAFT,
+		},
+		{
+			name: "Get without a key",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.GetArticle(ctx, &articlesv1.GetArticleRequest{})
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: appconst.Articleselector,
+		},
+		{
+			name: "List",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				res, err := client.ListArticles(ctx, &articlesv1.ListArticlesRequest{PageSize: 1, OrderBy: "-created_at", Tag: "Go Tips", Status: articlesv1.Status_STATUS_PUBLISHED})
+				return []interface{}{len(res.GetArticles()), res.GetNextPageToken(), res.GetPrevPageToken(), res.GetTotalSize()}, err
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().AllArticles(gomock.Any()).DoAndReturn(func(params models.ListParams) (*models.ArticlePage, error) {
+					assert.Equal(t, 1, params.Limit)
+					assert.Equal(t, models.Sort{Field: models.SortCreatedAt, Desc: true}, params.Sort)
+					assert.Equal(t, models.ArticleFilter{Tag: "go-tips", Status: models.StatusPublished}, params.Filter)
+					assert.False(t, params.AllStatuses)
+					return &models.ArticlePage{Articles: []models.Article{*published}, PageInfo: models.PageInfo{Total: 2, HasNext: true, NextCursor: "next", PrevCursor: "prev"}}, nil
+				})
+			},
+			expected: []interface{}{1, "next", "", int32(2)},
+		},
+		{
+			name: "List too many",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.ListArticles(ctx, &articlesv1.ListArticlesRequest{PageSize: 500})
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: "limit must be a number between 1 and 100",
+		},
+		{
+			name: "List with an invalid page token",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.ListArticles(ctx, &articlesv1.ListArticlesRequest{PageToken: "???"})
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: appconst.Invalidcursor,
+		},
+		{
+			name: "Database error",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.ListArticles(ctx, &articlesv1.ListArticlesRequest{})
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().AllArticles(gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			expectedCode:  codes.Internal,
+			expectedError: appconst.Internalerror,
+		},
+		{
+			name:          "Create",
+			authorization: "Bearer token",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				res, err := client.CreateArticle(ctx, &articlesv1.CreateArticleRequest{Article: &articlesv1.ArticleInput{Title: "Hello", Content: "*Hi*", Tags: []string{"Go"}}})
+				return []interface{}{res.GetArticle().GetId(), res.GetArticle().GetStatus()}, err
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "ada"}, nil)
+				db.EXPECT().CreateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) (int, error) {
+					assert.Equal(t, "hello", article.Slug)
+					assert.Equal(t, "<p><em>Hi</em></p>\n", article.ContentHTML)
+					assert.Equal(t, "ada", article.CreatedBy)
+					return 5, nil
+				})
+				db.EXPECT().OneArticle(5).Return(&models.Article{ID: 5, AuthorID: 7, Status: models.StatusDraft}, nil)
+			},
+			expected: []interface{}{int64(5), articlesv1.Status_STATUS_DRAFT},
+		},
+		{
+			name: "Create anonymously",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.CreateArticle(ctx, &articlesv1.CreateArticleRequest{Article: &articlesv1.ArticleInput{Title: "Hello"}})
+			},
+			expectedCode:  codes.Unauthenticated,
+			expectedError: appconst.Unauthenticated,
+		},
+		{
+			name:          "Update someone else's article",
+			authorization: "Bearer token",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.UpdateArticle(ctx, &articlesv1.UpdateArticleRequest{Id: 3, Article: &articlesv1.ArticleInput{Title: "Hello"}})
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(3).Return(&models.Article{ID: 3, AuthorID: 9, Status: models.StatusPublished}, nil)
+			},
+			expectedCode:  codes.PermissionDenied,
+			expectedError: appconst.Forbidden,
+		},
+		{
+			name:          "Update with an invalid ID",
+			authorization: "Bearer token",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.UpdateArticle(ctx, &articlesv1.UpdateArticleRequest{Article: &articlesv1.ArticleInput{Title: "Hello"}})
+			},
+			expectedCode:  codes.InvalidArgument,
+			expectedError: "id must be an ID",
+		},
+		{
+			name:          "Delete",
+			authorization: "Bearer token",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				_, err := client.DeleteArticle(ctx, &articlesv1.DeleteArticleRequest{Id: 1})
+				return nil, err
+			},
+			mockDBExpect: func(db *mocks.MockDBInterface) {
+				db.EXPECT().OneArticle(1).Return(published, nil)
+				db.EXPECT().DeleteArticle(1).Return(nil)
+			},
+		},
+		{
+			name:          "Invalid token",
+			authorization: "Bearer not-a-token",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.ListArticles(ctx, &articlesv1.ListArticlesRequest{})
+			},
+			expectedCode:  codes.Unauthenticated,
+			expectedError: appconst.Invalidtoken,
+		},
+		{
+			name:          "Revoked API key",
+			authorization: "ApiKey ak_0123abcd_revoked",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.ListArticles(ctx, &articlesv1.ListArticlesRequest{})
+			},
+			expectedCode:  codes.Unauthenticated,
+			expectedError: appconst.Invalidapikey,
+		},
+		{
+			name:          "API key lookup failure",
+			authorization: "ApiKey ak_0123abcd_broken",
+			call: func(client articlesv1.ArticleServiceClient, ctx context.Context) (interface{}, error) {
+				return client.ListArticles(ctx, &articlesv1.ListArticlesRequest{})
+			},
+			expectedCode:  codes.Internal,
+			expectedError: appconst.Internalerror,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			if tc.mockDBExpect != nil {
+				tc.mockDBExpect(mockDB)
+			}
+			conn, _, token := testServer(t, services.NewArticleService(mockDB))
+			authorization := tc.authorization
+			if authorization == "Bearer token" {
+				authorization = "Bearer " + token
+			}
+
+			result, err := tc.call(articlesv1.NewArticleServiceClient(conn), withAuthorization(authorization))
+
+			if tc.expectedCode != codes.OK {
+				assert.Equal(t, tc.expectedCode, status.Code(err))
+				assert.Equal(t, tc.expectedError, status.Convert(err).Message())
+				return
+			}
+			assert.NoError(t, err)
+			if expected, ok := tc.expected.(proto.Message); ok {
+				assert.True(t, proto.Equal(expected, result.(proto.Message)), "expected %v, got %v", expected, result)
+				return
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestWatchArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	articles := services.NewArticleService(mockDB)
+	conn, server, token := testServer(t, articles)
+	client := articlesv1.NewArticleServiceClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.WatchArticles(ctx, &articlesv1.WatchArticlesRequest{})
+	assert.NoError(t, err)
+	// the watch has started once the server has sent its headers
+	_, err = stream.Header()
+	assert.NoError(t, err)
+
+	// an anonymous watcher does not see the new draft, only the article
+	// published after it
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "ada"}, nil)
+	mockDB.EXPECT().CreateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) (int, error) {
+		article.ID = 5
+		article.Status = models.StatusDraft
+		return 5, nil
+	})
+	mockDB.EXPECT().OneArticle(5).Return(&models.Article{ID: 5, AuthorID: 7, Status: models.StatusDraft}, nil)
+	_, err = client.CreateArticle(withAuthorization("Bearer "+token), &articlesv1.CreateArticleRequest{Article: &articlesv1.ArticleInput{Title: "Hello", Content: "Hi"}})
+	assert.NoError(t, err)
+
+	mockDB.EXPECT().OneArticle(6).Return(&models.Article{ID: 6, AuthorID: 9, Status: models.StatusInReview}, nil)
+	mockDB.EXPECT().SetArticleStatus(gomock.Any(), models.StatusInReview).Return(nil)
+	_, err = articles.PublishArticle(6, models.Principal{UserID: 2, Username: "grace", Role: models.RoleEditor})
+	assert.NoError(t, err)
+
+	event, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, articlesv1.WatchArticlesResponse_EVENT_TYPE_UPDATED, event.GetType())
+	assert.Equal(t, int64(6), event.GetArticle().GetId())
+	assert.Equal(t, articlesv1.Status_STATUS_PUBLISHED, event.GetArticle().GetStatus())
+
+	// shutting down ends the watch
+	server.Shutdown(context.Background())
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, appconst.Serverstopping, status.Convert(err).Message())
+}
+
+func TestHealthAndReflection(t *testing.T) {
+	conn, server, _ := testServer(t, services.NewArticleService(nil))
+	ctx := context.Background()
+
+	health := healthpb.NewHealthClient(conn)
+	res, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: "articles.v1.ArticleService"})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	_, err = health.Check(ctx, &healthpb.HealthCheckRequest{Service: "articles.v2.ArticleService"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{}}))
+	info, err := stream.Recv()
+	assert.NoError(t, err)
+	var names []string
+	for _, service := range info.GetListServicesResponse().GetService() {
+		names = append(names, service.GetName())
+	}
+	assert.ElementsMatch(t, []string{"articles.v1.ArticleService", "grpc.health.v1.Health", "grpc.reflection.v1.ServerReflection", "grpc.reflection.v1alpha.ServerReflection"}, names)
+	assert.NoError(t, stream.CloseSend())
+
+	// a server shutting down is reported as not serving
+	server.health.Shutdown()
+	res, err = health.Check(ctx, &healthpb.HealthCheckRequest{Service: "articles.v1.ArticleService"})
+	assert.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
@@ -2,11 +2,28 @@ package main
 
 import (
 	"backend/internal/controller"
+	"backend/internal/graph"
 	"backend/internal/routes"
+	"backend/internal/rpc"
 	appconst "backend/pkg/appconstant"
+	"backend/pkg/auth"
 	"backend/pkg/db"
+	"backend/pkg/migration"
+	"backend/pkg/models"
 	"backend/pkg/repository/dbrepo"
+	"backend/pkg/sitemap"
+	"backend/pkg/storage"
 	services "backend/services/articles"
+	"backend/services/comments"
+	"backend/services/media"
+	"backend/services/taxonomy"
+	"backend/services/users"
+	"context"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -14,16 +31,52 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 )
 
+// shutdownTimeout bounds how long in-flight requests may take to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	// Set application config
 	var app routes.Application
 
 	// Read from the command line
 	flag.StringVar(&app.DSN, "dsn", "host=postgres port=5432 user=postgres password=postgres dbname=articles sslmode=disable timezone=UTC connect_timeout=5", "Postgres connection string")
+	publishInterval := flag.Duration("publish-interval", 30*time.Second, "How often scheduled articles are checked for publishing")
+	var tokenConfig auth.Config
+	var jwtSecret, jwtPrivateKey, jwtPublicKey string
+	flag.StringVar(&tokenConfig.Algorithm, "jwt-alg", auth.HS256, "Signing algorithm of access tokens, HS256 or RS256")
+	flag.StringVar(&jwtSecret, "jwt-secret", os.Getenv("JWT_SECRET"), "Secret of HS256 access tokens, defaults to $JWT_SECRET")
+	flag.StringVar(&jwtPrivateKey, "jwt-private-key", "", "PEM file with the RSA private key that signs RS256 access tokens")
+	flag.StringVar(&jwtPublicKey, "jwt-public-key", "", "PEM file with the RSA public key that verifies RS256 access tokens, if there is no private key")
+	flag.StringVar(&tokenConfig.Issuer, "jwt-issuer", "articles", "Issuer of access tokens")
+	flag.DurationVar(&tokenConfig.AccessTTL, "access-token-ttl", 15*time.Minute, "How long access tokens are valid")
+	flag.DurationVar(&tokenConfig.RefreshTTL, "refresh-token-ttl", 30*24*time.Hour, "How long refresh tokens are valid")
+	holdThreshold := flag.Float64("spam-threshold", comments.DefaultHoldThreshold, "Spam score from 0 to 1 at which edited comments are held for moderation again; 0 holds every edit")
+	blockedWords := flag.String("blocked-words", "", "Comma separated words that make comments look like spam")
+	mediaStore := flag.String("media-store", "local", "Where uploaded media is kept, local or s3")
+	mediaDir := flag.String("media-dir", "media", "Directory uploaded media is kept in with -media-store local")
+	mediaMaxSize := flag.Int64("media-max-size", models.DefaultMaxMediaSize, "Largest file that can be uploaded, in bytes")
+	imageWidths := flag.String("image-widths", "320,640,1024,1600", "Comma separated widths in pixels uploaded images are resized to")
+	imageInterval := flag.Duration("image-interval", time.Minute, "How often images left unprocessed are checked for, besides on every upload")
+	siteTitle := flag.String("site-title", "Articles", "Name of the site, the title of its feeds")
+	siteURL := flag.String("site-url", "http://localhost:8080", "Public URL of the site, which links in feeds and sitemaps start with")
+	robotsFile := flag.String("robots-file", "", "File served as /robots.txt instead of the generated one")
+	robotsDisallow := flag.String("robots-disallow", "/auth/,/api-keys,/moderation/,/users", "Comma separated paths crawlers are kept out of in the generated /robots.txt")
+	var graphLimits graph.Limits
+	flag.IntVar(&graphLimits.MaxDepth, "graphql-max-depth", graph.DefaultMaxDepth, "How deeply the fields of a GraphQL query may be nested")
+	flag.IntVar(&graphLimits.MaxComplexity, "graphql-max-complexity", graph.DefaultMaxComplexity, "How many fields a GraphQL query may resolve, counting the fields under a list once per item")
+	grpcPort := flag.Int("grpc-port", appconst.GRPCPort, "Port the gRPC article service is served on")
+	var s3Config storage.S3Config
+	flag.StringVar(&s3Config.Endpoint, "s3-endpoint", "", "URL of the S3 compatible service uploaded media is kept in with -media-store s3")
+	flag.StringVar(&s3Config.Region, "s3-region", "us-east-1", "Region of the S3 bucket")
+	flag.StringVar(&s3Config.Bucket, "s3-bucket", "", "S3 bucket uploaded media is kept in")
+	flag.StringVar(&s3Config.AccessKey, "s3-access-key", os.Getenv("S3_ACCESS_KEY"), "S3 access key, defaults to $S3_ACCESS_KEY")
+	flag.StringVar(&s3Config.SecretKey, "s3-secret-key", os.Getenv("S3_SECRET_KEY"), "S3 secret key, defaults to $S3_SECRET_KEY")
 	flag.Parse()
+
 	fmt.Println(appconst.DatabseWait)
 	time.Sleep(5 * time.Second)
 
@@ -35,28 +88,188 @@ func main() {
 	app.DB = &dbrepo.PostgresDBRepo{DB: conn}
 	defer app.DB.Connection().Close()
 
-	// Create the table if it does not exist in the container
-	app.DB.CreateTable()
+	// Run the migrate subcommand instead of the server when requested
+	if flag.Arg(0) == "migrate" {
+		if err := runMigrate(conn, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Bring the database schema up to date
+	migrator, err := migration.New(conn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+
+	// Load the keys access tokens are signed and verified with
+	tokenConfig.Secret = []byte(jwtSecret)
+	if jwtPrivateKey != "" {
+		key, err := auth.ReadPrivateKey(jwtPrivateKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tokenConfig.PrivateKey = key
+	}
+	if jwtPublicKey != "" {
+		key, err := auth.ReadPublicKey(jwtPublicKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		tokenConfig.PublicKey = key
+	}
+	tokens, err := auth.NewTokens(tokenConfig)
+	if err != nil {
+		log.Fatal(err)
+	}
+	app.Tokens = tokens
 
 	// Initialize the ArticleService with the DatabaseRepo
 	articleService := services.NewArticleService(app.DB)
 
+	userService := users.NewUserService(app.DB, tokens)
+	app.APIKeys = userService
+
+	// Keep uploaded media on the local disk or in an S3 bucket
+	blobs, err := newBlobStore(*mediaStore, *mediaDir, s3Config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	widths, err := parseWidths(*imageWidths)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mediaService := media.NewMediaService(app.DB, blobs, *mediaMaxSize, widths)
+
+	commentService := comments.NewCommentService(app.DB, comments.NewHeuristicScorer(splitList(*blockedWords)), *holdThreshold)
+	taxonomyService := taxonomy.NewTaxonomyService(app.DB)
+
+	// Serve the articles as GraphQL too, resolved by the same services
+	schema, err := graph.NewSchema(graph.Services{
+		Articles: articleService,
+		Users:    userService,
+		Comments: commentService,
+		Taxonomy: taxonomyService,
+	}, graphLimits)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Serve the robots.txt given, or one pointing crawlers to the sitemap
+	site := models.NewSite(*siteTitle, *siteURL)
+	robots := sitemap.Robots(splitList(*robotsDisallow), site.Link("/sitemap.xml"))
+	if *robotsFile != "" {
+		content, err := os.ReadFile(*robotsFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		robots = string(content)
+	}
+
 	// Create the MyApplication instance and pass the dependencies
 	myApp := controller.Controller{
-		DSN:            app.DSN,
-		DB:             app.DB,
-		Utility:        app.Utility, // You can replace this with your actual utility implementation
-		ArticleService: articleService,
+		DSN:             app.DSN,
+		DB:              app.DB,
+		Utility:         app.Utility, // You can replace this with your actual utility implementation
+		ArticleService:  articleService,
+		UserService:     userService,
+		CommentService:  commentService,
+		TaxonomyService: taxonomyService,
+		MediaService:    mediaService,
+		Site:            site,
+		Robots:          robots,
+		Graph:           schema,
 	}
 
 	// Set the handlers for your application
 	app.Handler = myApp
 
+	// Publish scheduled articles in the background
+	scheduler := services.NewScheduler(articleService, *publishInterval)
+	scheduler.Start()
+
+	// Resize uploaded images in the background
+	processor := media.NewProcessor(mediaService, *imageInterval)
+	processor.Start()
+
 	log.Println(appconst.Startapp, appconst.Port)
 
 	// Start a web server
-	err = http.ListenAndServe(fmt.Sprintf(":%d", appconst.Port), app.Routes())
+	srv := &http.Server{
+		Addr:    fmt.Sprintf(":%d", appconst.Port),
+		Handler: app.Routes(),
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	// Serve the articles to internal services over gRPC on a port of their own
+	log.Println(appconst.Startgrpc, *grpcPort)
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *grpcPort))
 	if err != nil {
 		log.Fatal(err)
 	}
+	grpcServer := rpc.NewServer(articleService, tokens, userService)
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// On SIGINT or SIGTERM stop taking requests, let the ones in flight and
+	// the current scheduler and image runs finish, then close the database
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	log.Println(appconst.Shutdown)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Println(err)
+	}
+	grpcServer.Shutdown(ctx)
+	scheduler.Stop()
+	processor.Stop()
+}
+
+// splitList splits a comma separated flag value, leaving out empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseWidths parses the comma separated widths images are resized to.
+func parseWidths(value string) ([]int, error) {
+	var widths []int
+	for _, item := range splitList(value) {
+		width, err := strconv.Atoi(item)
+		if err != nil || width <= 0 {
+			return nil, fmt.Errorf("invalid image width %q, must be a positive number of pixels", item)
+		}
+		widths = append(widths, width)
+	}
+	return widths, nil
+}
+
+// newBlobStore returns the store uploaded media is kept in.
+func newBlobStore(kind, dir string, s3Config storage.S3Config) (storage.BlobStore, error) {
+	switch kind {
+	case "local":
+		return storage.NewLocalStore(dir)
+	case "s3":
+		return storage.NewS3Store(s3Config)
+	default:
+		return nil, fmt.Errorf("unknown media store %q, must be local or s3", kind)
+	}
 }
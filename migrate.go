@@ -0,0 +1,68 @@
+package main
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/migration"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runMigrate implements the migrate subcommand:
+//
+//	main -dsn <dsn> migrate [-dry-run] [-steps n] up|down|status
+func runMigrate(conn *sql.DB, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Report the migrations that would run without applying them")
+	steps := flags.Int("steps", 1, "Number of migrations to revert with down")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	migrator, err := migration.New(conn)
+	if err != nil {
+		return err
+	}
+	migrator.DryRun = *dryRun
+
+	ctx := context.Background()
+
+	switch command := flags.Arg(0); command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err == nil && len(applied) == 0 {
+			log.Println(appconst.MigrationsUpToDate)
+		}
+		return err
+	case "down":
+		_, err := migrator.Down(ctx, *steps)
+		return err
+	case "status", "":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate command %q, expected up, down or status", command)
+	}
+}
+
+func printStatus(statuses []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.Applied {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	w.Flush()
+}
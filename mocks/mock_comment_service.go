@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./services/comments/comments_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/pkg/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCommentServices is a mock of CommentServices interface.
+type MockCommentServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentServicesMockRecorder
+}
+
+// MockCommentServicesMockRecorder is the mock recorder for MockCommentServices.
+type MockCommentServicesMockRecorder struct {
+	mock *MockCommentServices
+}
+
+// NewMockCommentServices creates a new mock instance.
+func NewMockCommentServices(ctrl *gomock.Controller) *MockCommentServices {
+	mock := &MockCommentServices{ctrl: ctrl}
+	mock.recorder = &MockCommentServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentServices) EXPECT() *MockCommentServicesMockRecorder {
+	return m.recorder
+}
+
+// DeleteComment mocks base method.
+func (m *MockCommentServices) DeleteComment(articleID, id int, actor models.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", articleID, id, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockCommentServicesMockRecorder) DeleteComment(articleID, id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockCommentServices)(nil).DeleteComment), articleID, id, actor)
+}
+
+// EditComment mocks base method.
+func (m *MockCommentServices) EditComment(articleID, id int, request models.CommentRequest, actor models.Principal) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditComment", articleID, id, request, actor)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditComment indicates an expected call of EditComment.
+func (mr *MockCommentServicesMockRecorder) EditComment(articleID, id, request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditComment", reflect.TypeOf((*MockCommentServices)(nil).EditComment), articleID, id, request, actor)
+}
+
+// GetArticlesComments mocks base method.
+func (m *MockCommentServices) GetArticlesComments(articleIDs []int, limit int) (map[int][]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticlesComments", articleIDs, limit)
+	ret0, _ := ret[0].(map[int][]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticlesComments indicates an expected call of GetArticlesComments.
+func (mr *MockCommentServicesMockRecorder) GetArticlesComments(articleIDs, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticlesComments", reflect.TypeOf((*MockCommentServices)(nil).GetArticlesComments), articleIDs, limit)
+}
+
+// GetComments mocks base method.
+func (m *MockCommentServices) GetComments(articleID int, view string, params models.ListParams, viewer models.Principal) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", articleID, view, params, viewer)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockCommentServicesMockRecorder) GetComments(articleID, view, params, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockCommentServices)(nil).GetComments), articleID, view, params, viewer)
+}
+
+// ModerateComment mocks base method.
+func (m *MockCommentServices) ModerateComment(id int, status string, actor models.Principal) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateComment", id, status, actor)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateComment indicates an expected call of ModerateComment.
+func (mr *MockCommentServicesMockRecorder) ModerateComment(id, status, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateComment", reflect.TypeOf((*MockCommentServices)(nil).ModerateComment), id, status, actor)
+}
+
+// ModerationQueue mocks base method.
+func (m *MockCommentServices) ModerationQueue(status string, params models.ListParams, actor models.Principal) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerationQueue", status, params, actor)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerationQueue indicates an expected call of ModerationQueue.
+func (mr *MockCommentServicesMockRecorder) ModerationQueue(status, params, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerationQueue", reflect.TypeOf((*MockCommentServices)(nil).ModerationQueue), status, params, actor)
+}
+
+// PostComment mocks base method.
+func (m *MockCommentServices) PostComment(articleID int, request models.CommentRequest, actor models.Principal) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostComment", articleID, request, actor)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostComment indicates an expected call of PostComment.
+func (mr *MockCommentServicesMockRecorder) PostComment(articleID, request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostComment", reflect.TypeOf((*MockCommentServices)(nil).PostComment), articleID, request, actor)
+}
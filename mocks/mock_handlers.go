@@ -36,19 +36,169 @@ func (m *MockDBInterface) EXPECT() *MockDBInterfaceMockRecorder {
 	return m.recorder
 }
 
+// APIKeys mocks base method.
+func (m *MockDBInterface) APIKeys(userID int) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys", userID)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockDBInterfaceMockRecorder) APIKeys(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockDBInterface)(nil).APIKeys), userID)
+}
+
 // AllArticles mocks base method.
-func (m *MockDBInterface) AllArticles() ([]models.Article, error) {
+func (m *MockDBInterface) AllArticles(params models.ListParams) (*models.ArticlePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AllArticles")
-	ret0, _ := ret[0].([]models.Article)
+	ret := m.ctrl.Call(m, "AllArticles", params)
+	ret0, _ := ret[0].(*models.ArticlePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AllArticles indicates an expected call of AllArticles.
-func (mr *MockDBInterfaceMockRecorder) AllArticles() *gomock.Call {
+func (mr *MockDBInterfaceMockRecorder) AllArticles(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllArticles", reflect.TypeOf((*MockDBInterface)(nil).AllArticles), params)
+}
+
+// AllUsers mocks base method.
+func (m *MockDBInterface) AllUsers(params models.ListParams) (*models.UserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllUsers", params)
+	ret0, _ := ret[0].(*models.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllUsers indicates an expected call of AllUsers.
+func (mr *MockDBInterfaceMockRecorder) AllUsers(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllUsers", reflect.TypeOf((*MockDBInterface)(nil).AllUsers), params)
+}
+
+// ArticleBySlug mocks base method.
+func (m *MockDBInterface) ArticleBySlug(slug string) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArticleBySlug", slug)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArticleBySlug indicates an expected call of ArticleBySlug.
+func (mr *MockDBInterfaceMockRecorder) ArticleBySlug(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleBySlug", reflect.TypeOf((*MockDBInterface)(nil).ArticleBySlug), slug)
+}
+
+// ArticleComments mocks base method.
+func (m *MockDBInterface) ArticleComments(articleID int, params models.ListParams) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArticleComments", articleID, params)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArticleComments indicates an expected call of ArticleComments.
+func (mr *MockDBInterfaceMockRecorder) ArticleComments(articleID, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleComments", reflect.TypeOf((*MockDBInterface)(nil).ArticleComments), articleID, params)
+}
+
+// ArticleRevision mocks base method.
+func (m *MockDBInterface) ArticleRevision(articleID, revision int) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArticleRevision", articleID, revision)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArticleRevision indicates an expected call of ArticleRevision.
+func (mr *MockDBInterfaceMockRecorder) ArticleRevision(articleID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleRevision", reflect.TypeOf((*MockDBInterface)(nil).ArticleRevision), articleID, revision)
+}
+
+// ArticleRevisions mocks base method.
+func (m *MockDBInterface) ArticleRevisions(articleID int, params models.ListParams) (*models.RevisionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArticleRevisions", articleID, params)
+	ret0, _ := ret[0].(*models.RevisionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArticleRevisions indicates an expected call of ArticleRevisions.
+func (mr *MockDBInterfaceMockRecorder) ArticleRevisions(articleID, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllArticles", reflect.TypeOf((*MockDBInterface)(nil).AllArticles))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleRevisions", reflect.TypeOf((*MockDBInterface)(nil).ArticleRevisions), articleID, params)
+}
+
+// Categories mocks base method.
+func (m *MockDBInterface) Categories() ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categories")
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Categories indicates an expected call of Categories.
+func (mr *MockDBInterfaceMockRecorder) Categories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categories", reflect.TypeOf((*MockDBInterface)(nil).Categories))
+}
+
+// ClaimPendingMedia mocks base method.
+func (m *MockDBInterface) ClaimPendingMedia(limit int) ([]models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingMedia", limit)
+	ret0, _ := ret[0].([]models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingMedia indicates an expected call of ClaimPendingMedia.
+func (mr *MockDBInterfaceMockRecorder) ClaimPendingMedia(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingMedia", reflect.TypeOf((*MockDBInterface)(nil).ClaimPendingMedia), limit)
+}
+
+// CommentQueue mocks base method.
+func (m *MockDBInterface) CommentQueue(status string, params models.ListParams) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentQueue", status, params)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentQueue indicates an expected call of CommentQueue.
+func (mr *MockDBInterfaceMockRecorder) CommentQueue(status, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentQueue", reflect.TypeOf((*MockDBInterface)(nil).CommentQueue), status, params)
+}
+
+// CommentsByArticles mocks base method.
+func (m *MockDBInterface) CommentsByArticles(articleIDs []int, limit int) ([]models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommentsByArticles", articleIDs, limit)
+	ret0, _ := ret[0].([]models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommentsByArticles indicates an expected call of CommentsByArticles.
+func (mr *MockDBInterfaceMockRecorder) CommentsByArticles(articleIDs, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentsByArticles", reflect.TypeOf((*MockDBInterface)(nil).CommentsByArticles), articleIDs, limit)
 }
 
 // Connection mocks base method.
@@ -65,6 +215,20 @@ func (mr *MockDBInterfaceMockRecorder) Connection() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connection", reflect.TypeOf((*MockDBInterface)(nil).Connection))
 }
 
+// CreateAPIKey mocks base method.
+func (m *MockDBInterface) CreateAPIKey(key *models.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockDBInterfaceMockRecorder) CreateAPIKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockDBInterface)(nil).CreateAPIKey), key)
+}
+
 // CreateArticle mocks base method.
 func (m *MockDBInterface) CreateArticle(article *models.Article) (int, error) {
 	m.ctrl.T.Helper()
@@ -80,16 +244,191 @@ func (mr *MockDBInterfaceMockRecorder) CreateArticle(article interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArticle", reflect.TypeOf((*MockDBInterface)(nil).CreateArticle), article)
 }
 
-// CreateTable mocks base method.
-func (m *MockDBInterface) CreateTable() {
+// CreateCategory mocks base method.
+func (m *MockDBInterface) CreateCategory(category *models.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockDBInterfaceMockRecorder) CreateCategory(category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockDBInterface)(nil).CreateCategory), category)
+}
+
+// CreateComment mocks base method.
+func (m *MockDBInterface) CreateComment(comment *models.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockDBInterfaceMockRecorder) CreateComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockDBInterface)(nil).CreateComment), comment)
+}
+
+// CreateMedia mocks base method.
+func (m *MockDBInterface) CreateMedia(media *models.Media) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedia", media)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMedia indicates an expected call of CreateMedia.
+func (mr *MockDBInterfaceMockRecorder) CreateMedia(media interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedia", reflect.TypeOf((*MockDBInterface)(nil).CreateMedia), media)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockDBInterface) CreateRefreshToken(token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockDBInterfaceMockRecorder) CreateRefreshToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockDBInterface)(nil).CreateRefreshToken), token)
+}
+
+// CreateUser mocks base method.
+func (m *MockDBInterface) CreateUser(user *models.User) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", user)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockDBInterfaceMockRecorder) CreateUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockDBInterface)(nil).CreateUser), user)
+}
+
+// DeleteArticle mocks base method.
+func (m *MockDBInterface) DeleteArticle(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArticle", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArticle indicates an expected call of DeleteArticle.
+func (mr *MockDBInterfaceMockRecorder) DeleteArticle(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticle", reflect.TypeOf((*MockDBInterface)(nil).DeleteArticle), id)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockDBInterface) DeleteCategory(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockDBInterfaceMockRecorder) DeleteCategory(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockDBInterface)(nil).DeleteCategory), id)
+}
+
+// DeleteComment mocks base method.
+func (m *MockDBInterface) DeleteComment(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockDBInterfaceMockRecorder) DeleteComment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockDBInterface)(nil).DeleteComment), id)
+}
+
+// LatestArticles mocks base method.
+func (m *MockDBInterface) LatestArticles(filter models.ArticleFilter, limit int) ([]models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LatestArticles", filter, limit)
+	ret0, _ := ret[0].([]models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LatestArticles indicates an expected call of LatestArticles.
+func (mr *MockDBInterfaceMockRecorder) LatestArticles(filter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LatestArticles", reflect.TypeOf((*MockDBInterface)(nil).LatestArticles), filter, limit)
+}
+
+// MediaByChecksum mocks base method.
+func (m *MockDBInterface) MediaByChecksum(checksum string) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MediaByChecksum", checksum)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MediaByChecksum indicates an expected call of MediaByChecksum.
+func (mr *MockDBInterfaceMockRecorder) MediaByChecksum(checksum interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MediaByChecksum", reflect.TypeOf((*MockDBInterface)(nil).MediaByChecksum), checksum)
+}
+
+// MediaVariant mocks base method.
+func (m *MockDBInterface) MediaVariant(mediaID, width int) (*models.MediaVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MediaVariant", mediaID, width)
+	ret0, _ := ret[0].(*models.MediaVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MediaVariant indicates an expected call of MediaVariant.
+func (mr *MockDBInterfaceMockRecorder) MediaVariant(mediaID, width interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MediaVariant", reflect.TypeOf((*MockDBInterface)(nil).MediaVariant), mediaID, width)
+}
+
+// MergeTags mocks base method.
+func (m *MockDBInterface) MergeTags(from, into int) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "CreateTable")
+	ret := m.ctrl.Call(m, "MergeTags", from, into)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CreateTable indicates an expected call of CreateTable.
-func (mr *MockDBInterfaceMockRecorder) CreateTable() *gomock.Call {
+// MergeTags indicates an expected call of MergeTags.
+func (mr *MockDBInterfaceMockRecorder) MergeTags(from, into interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTable", reflect.TypeOf((*MockDBInterface)(nil).CreateTable))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTags", reflect.TypeOf((*MockDBInterface)(nil).MergeTags), from, into)
+}
+
+// OneAPIKey mocks base method.
+func (m *MockDBInterface) OneAPIKey(id int) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OneAPIKey", id)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OneAPIKey indicates an expected call of OneAPIKey.
+func (mr *MockDBInterfaceMockRecorder) OneAPIKey(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneAPIKey", reflect.TypeOf((*MockDBInterface)(nil).OneAPIKey), id)
 }
 
 // OneArticle mocks base method.
@@ -107,6 +446,387 @@ func (mr *MockDBInterfaceMockRecorder) OneArticle(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneArticle", reflect.TypeOf((*MockDBInterface)(nil).OneArticle), id)
 }
 
+// OneCategory mocks base method.
+func (m *MockDBInterface) OneCategory(slug string) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OneCategory", slug)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OneCategory indicates an expected call of OneCategory.
+func (mr *MockDBInterfaceMockRecorder) OneCategory(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneCategory", reflect.TypeOf((*MockDBInterface)(nil).OneCategory), slug)
+}
+
+// OneComment mocks base method.
+func (m *MockDBInterface) OneComment(id int) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OneComment", id)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OneComment indicates an expected call of OneComment.
+func (mr *MockDBInterfaceMockRecorder) OneComment(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneComment", reflect.TypeOf((*MockDBInterface)(nil).OneComment), id)
+}
+
+// OneMedia mocks base method.
+func (m *MockDBInterface) OneMedia(id int) (*models.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OneMedia", id)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OneMedia indicates an expected call of OneMedia.
+func (mr *MockDBInterfaceMockRecorder) OneMedia(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneMedia", reflect.TypeOf((*MockDBInterface)(nil).OneMedia), id)
+}
+
+// OneTag mocks base method.
+func (m *MockDBInterface) OneTag(slug string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OneTag", slug)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OneTag indicates an expected call of OneTag.
+func (mr *MockDBInterfaceMockRecorder) OneTag(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneTag", reflect.TypeOf((*MockDBInterface)(nil).OneTag), slug)
+}
+
+// OneUser mocks base method.
+func (m *MockDBInterface) OneUser(id int) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OneUser", id)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OneUser indicates an expected call of OneUser.
+func (mr *MockDBInterfaceMockRecorder) OneUser(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OneUser", reflect.TypeOf((*MockDBInterface)(nil).OneUser), id)
+}
+
+// PublishDueArticles mocks base method.
+func (m *MockDBInterface) PublishDueArticles(limit int, publishedBy string) ([]models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDueArticles", limit, publishedBy)
+	ret0, _ := ret[0].([]models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDueArticles indicates an expected call of PublishDueArticles.
+func (mr *MockDBInterfaceMockRecorder) PublishDueArticles(limit, publishedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDueArticles", reflect.TypeOf((*MockDBInterface)(nil).PublishDueArticles), limit, publishedBy)
+}
+
+// RenameTag mocks base method.
+func (m *MockDBInterface) RenameTag(tag *models.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockDBInterfaceMockRecorder) RenameTag(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockDBInterface)(nil).RenameTag), tag)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockDBInterface) RevokeAPIKey(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockDBInterfaceMockRecorder) RevokeAPIKey(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockDBInterface)(nil).RevokeAPIKey), id)
+}
+
+// RevokeRefreshTokens mocks base method.
+func (m *MockDBInterface) RevokeRefreshTokens(hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokens", hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokens indicates an expected call of RevokeRefreshTokens.
+func (mr *MockDBInterfaceMockRecorder) RevokeRefreshTokens(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokens", reflect.TypeOf((*MockDBInterface)(nil).RevokeRefreshTokens), hash)
+}
+
+// SaveMediaVariants mocks base method.
+func (m *MockDBInterface) SaveMediaVariants(media *models.Media, variants []models.MediaVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMediaVariants", media, variants)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMediaVariants indicates an expected call of SaveMediaVariants.
+func (mr *MockDBInterfaceMockRecorder) SaveMediaVariants(media, variants interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMediaVariants", reflect.TypeOf((*MockDBInterface)(nil).SaveMediaVariants), media, variants)
+}
+
+// ScheduledArticles mocks base method.
+func (m *MockDBInterface) ScheduledArticles(params models.ListParams) (*models.ArticlePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduledArticles", params)
+	ret0, _ := ret[0].(*models.ArticlePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduledArticles indicates an expected call of ScheduledArticles.
+func (mr *MockDBInterfaceMockRecorder) ScheduledArticles(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduledArticles", reflect.TypeOf((*MockDBInterface)(nil).ScheduledArticles), params)
+}
+
+// SearchArticles mocks base method.
+func (m *MockDBInterface) SearchArticles(query string, params models.ListParams) (*models.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchArticles", query, params)
+	ret0, _ := ret[0].(*models.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchArticles indicates an expected call of SearchArticles.
+func (mr *MockDBInterfaceMockRecorder) SearchArticles(query, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchArticles", reflect.TypeOf((*MockDBInterface)(nil).SearchArticles), query, params)
+}
+
+// SetArticleStatus mocks base method.
+func (m *MockDBInterface) SetArticleStatus(article *models.Article, from string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArticleStatus", article, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArticleStatus indicates an expected call of SetArticleStatus.
+func (mr *MockDBInterfaceMockRecorder) SetArticleStatus(article, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArticleStatus", reflect.TypeOf((*MockDBInterface)(nil).SetArticleStatus), article, from)
+}
+
+// SetCommentStatus mocks base method.
+func (m *MockDBInterface) SetCommentStatus(id int, status, moderatedBy string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCommentStatus", id, status, moderatedBy)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCommentStatus indicates an expected call of SetCommentStatus.
+func (mr *MockDBInterfaceMockRecorder) SetCommentStatus(id, status, moderatedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCommentStatus", reflect.TypeOf((*MockDBInterface)(nil).SetCommentStatus), id, status, moderatedBy)
+}
+
+// SetMediaStatus mocks base method.
+func (m *MockDBInterface) SetMediaStatus(id int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMediaStatus", id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMediaStatus indicates an expected call of SetMediaStatus.
+func (mr *MockDBInterfaceMockRecorder) SetMediaStatus(id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMediaStatus", reflect.TypeOf((*MockDBInterface)(nil).SetMediaStatus), id, status)
+}
+
+// SetUserRole mocks base method.
+func (m *MockDBInterface) SetUserRole(id int, role string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", id, role)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockDBInterfaceMockRecorder) SetUserRole(id, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockDBInterface)(nil).SetUserRole), id, role)
+}
+
+// SitemapEntries mocks base method.
+func (m *MockDBInterface) SitemapEntries(page, size int) ([]models.SitemapEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SitemapEntries", page, size)
+	ret0, _ := ret[0].([]models.SitemapEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SitemapEntries indicates an expected call of SitemapEntries.
+func (mr *MockDBInterfaceMockRecorder) SitemapEntries(page, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SitemapEntries", reflect.TypeOf((*MockDBInterface)(nil).SitemapEntries), page, size)
+}
+
+// SitemapPages mocks base method.
+func (m *MockDBInterface) SitemapPages(size int) ([]models.SitemapPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SitemapPages", size)
+	ret0, _ := ret[0].([]models.SitemapPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SitemapPages indicates an expected call of SitemapPages.
+func (mr *MockDBInterfaceMockRecorder) SitemapPages(size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SitemapPages", reflect.TypeOf((*MockDBInterface)(nil).SitemapPages), size)
+}
+
+// Tags mocks base method.
+func (m *MockDBInterface) Tags(params models.ListParams) (*models.TagPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Tags", params)
+	ret0, _ := ret[0].(*models.TagPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Tags indicates an expected call of Tags.
+func (mr *MockDBInterfaceMockRecorder) Tags(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tags", reflect.TypeOf((*MockDBInterface)(nil).Tags), params)
+}
+
+// UpdateArticle mocks base method.
+func (m *MockDBInterface) UpdateArticle(article *models.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArticle", article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArticle indicates an expected call of UpdateArticle.
+func (mr *MockDBInterfaceMockRecorder) UpdateArticle(article interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockDBInterface)(nil).UpdateArticle), article)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockDBInterface) UpdateCategory(category *models.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockDBInterfaceMockRecorder) UpdateCategory(category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockDBInterface)(nil).UpdateCategory), category)
+}
+
+// UpdateComment mocks base method.
+func (m *MockDBInterface) UpdateComment(comment *models.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockDBInterfaceMockRecorder) UpdateComment(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockDBInterface)(nil).UpdateComment), comment)
+}
+
+// UseAPIKey mocks base method.
+func (m *MockDBInterface) UseAPIKey(hash string) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseAPIKey", hash)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseAPIKey indicates an expected call of UseAPIKey.
+func (mr *MockDBInterfaceMockRecorder) UseAPIKey(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseAPIKey", reflect.TypeOf((*MockDBInterface)(nil).UseAPIKey), hash)
+}
+
+// UseRefreshToken mocks base method.
+func (m *MockDBInterface) UseRefreshToken(hash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRefreshToken", hash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRefreshToken indicates an expected call of UseRefreshToken.
+func (mr *MockDBInterfaceMockRecorder) UseRefreshToken(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRefreshToken", reflect.TypeOf((*MockDBInterface)(nil).UseRefreshToken), hash)
+}
+
+// UserByUsername mocks base method.
+func (m *MockDBInterface) UserByUsername(username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserByUsername", username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserByUsername indicates an expected call of UserByUsername.
+func (mr *MockDBInterfaceMockRecorder) UserByUsername(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserByUsername", reflect.TypeOf((*MockDBInterface)(nil).UserByUsername), username)
+}
+
+// UsersByIDs mocks base method.
+func (m *MockDBInterface) UsersByIDs(ids []int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UsersByIDs", ids)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UsersByIDs indicates an expected call of UsersByIDs.
+func (mr *MockDBInterfaceMockRecorder) UsersByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsersByIDs", reflect.TypeOf((*MockDBInterface)(nil).UsersByIDs), ids)
+}
+
 // MockUtilityInterface is a mock of UtilityInterface interface.
 type MockUtilityInterface struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./services/media/media_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/pkg/models"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMediaServices is a mock of MediaServices interface.
+type MockMediaServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaServicesMockRecorder
+}
+
+// MockMediaServicesMockRecorder is the mock recorder for MockMediaServices.
+type MockMediaServicesMockRecorder struct {
+	mock *MockMediaServices
+}
+
+// NewMockMediaServices creates a new mock instance.
+func NewMockMediaServices(ctrl *gomock.Controller) *MockMediaServices {
+	mock := &MockMediaServices{ctrl: ctrl}
+	mock.recorder = &MockMediaServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaServices) EXPECT() *MockMediaServicesMockRecorder {
+	return m.recorder
+}
+
+// MaxSize mocks base method.
+func (m *MockMediaServices) MaxSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxSize indicates an expected call of MaxSize.
+func (mr *MockMediaServicesMockRecorder) MaxSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxSize", reflect.TypeOf((*MockMediaServices)(nil).MaxSize))
+}
+
+// OpenMedia mocks base method.
+func (m *MockMediaServices) OpenMedia(id int) (*models.Media, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenMedia", id)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenMedia indicates an expected call of OpenMedia.
+func (mr *MockMediaServicesMockRecorder) OpenMedia(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenMedia", reflect.TypeOf((*MockMediaServices)(nil).OpenMedia), id)
+}
+
+// OpenVariant mocks base method.
+func (m *MockMediaServices) OpenVariant(id, width int) (*models.MediaVariant, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenVariant", id, width)
+	ret0, _ := ret[0].(*models.MediaVariant)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// OpenVariant indicates an expected call of OpenVariant.
+func (mr *MockMediaServicesMockRecorder) OpenVariant(id, width interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenVariant", reflect.TypeOf((*MockMediaServices)(nil).OpenVariant), id, width)
+}
+
+// ProcessPendingMedia mocks base method.
+func (m *MockMediaServices) ProcessPendingMedia() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessPendingMedia")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessPendingMedia indicates an expected call of ProcessPendingMedia.
+func (mr *MockMediaServicesMockRecorder) ProcessPendingMedia() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessPendingMedia", reflect.TypeOf((*MockMediaServices)(nil).ProcessPendingMedia))
+}
+
+// UploadMedia mocks base method.
+func (m *MockMediaServices) UploadMedia(file io.Reader, filename string, actor models.Principal) (*models.Media, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadMedia", file, filename, actor)
+	ret0, _ := ret[0].(*models.Media)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockMediaServicesMockRecorder) UploadMedia(file, filename, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockMediaServices)(nil).UploadMedia), file, filename, actor)
+}
@@ -46,6 +46,186 @@ func (mr *MockRoutesMockRecorder) AllArticle(w, r interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllArticle", reflect.TypeOf((*MockRoutes)(nil).AllArticle), w, r)
 }
 
+// ApproveComment mocks base method.
+func (m *MockRoutes) ApproveComment(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ApproveComment", w, r)
+}
+
+// ApproveComment indicates an expected call of ApproveComment.
+func (mr *MockRoutesMockRecorder) ApproveComment(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveComment", reflect.TypeOf((*MockRoutes)(nil).ApproveComment), w, r)
+}
+
+// ArchiveArticle mocks base method.
+func (m *MockRoutes) ArchiveArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ArchiveArticle", w, r)
+}
+
+// ArchiveArticle indicates an expected call of ArchiveArticle.
+func (mr *MockRoutesMockRecorder) ArchiveArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveArticle", reflect.TypeOf((*MockRoutes)(nil).ArchiveArticle), w, r)
+}
+
+// ArticleComments mocks base method.
+func (m *MockRoutes) ArticleComments(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ArticleComments", w, r)
+}
+
+// ArticleComments indicates an expected call of ArticleComments.
+func (mr *MockRoutesMockRecorder) ArticleComments(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleComments", reflect.TypeOf((*MockRoutes)(nil).ArticleComments), w, r)
+}
+
+// ArticleRevisions mocks base method.
+func (m *MockRoutes) ArticleRevisions(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ArticleRevisions", w, r)
+}
+
+// ArticleRevisions indicates an expected call of ArticleRevisions.
+func (mr *MockRoutesMockRecorder) ArticleRevisions(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArticleRevisions", reflect.TypeOf((*MockRoutes)(nil).ArticleRevisions), w, r)
+}
+
+// AuthorFeed mocks base method.
+func (m *MockRoutes) AuthorFeed(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AuthorFeed", w, r)
+}
+
+// AuthorFeed indicates an expected call of AuthorFeed.
+func (mr *MockRoutesMockRecorder) AuthorFeed(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorFeed", reflect.TypeOf((*MockRoutes)(nil).AuthorFeed), w, r)
+}
+
+// CategoryArticles mocks base method.
+func (m *MockRoutes) CategoryArticles(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CategoryArticles", w, r)
+}
+
+// CategoryArticles indicates an expected call of CategoryArticles.
+func (mr *MockRoutesMockRecorder) CategoryArticles(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CategoryArticles", reflect.TypeOf((*MockRoutes)(nil).CategoryArticles), w, r)
+}
+
+// CommentQueue mocks base method.
+func (m *MockRoutes) CommentQueue(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CommentQueue", w, r)
+}
+
+// CommentQueue indicates an expected call of CommentQueue.
+func (mr *MockRoutesMockRecorder) CommentQueue(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommentQueue", reflect.TypeOf((*MockRoutes)(nil).CommentQueue), w, r)
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockRoutes) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateAPIKey", w, r)
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockRoutesMockRecorder) CreateAPIKey(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockRoutes)(nil).CreateAPIKey), w, r)
+}
+
+// CreateCategory mocks base method.
+func (m *MockRoutes) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateCategory", w, r)
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockRoutesMockRecorder) CreateCategory(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockRoutes)(nil).CreateCategory), w, r)
+}
+
+// DeleteArticle mocks base method.
+func (m *MockRoutes) DeleteArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteArticle", w, r)
+}
+
+// DeleteArticle indicates an expected call of DeleteArticle.
+func (mr *MockRoutesMockRecorder) DeleteArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticle", reflect.TypeOf((*MockRoutes)(nil).DeleteArticle), w, r)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockRoutes) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteCategory", w, r)
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockRoutesMockRecorder) DeleteCategory(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockRoutes)(nil).DeleteCategory), w, r)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRoutes) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteComment", w, r)
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRoutesMockRecorder) DeleteComment(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRoutes)(nil).DeleteComment), w, r)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockRoutes) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DiffRevisions", w, r)
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockRoutesMockRecorder) DiffRevisions(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockRoutes)(nil).DiffRevisions), w, r)
+}
+
+// EditComment mocks base method.
+func (m *MockRoutes) EditComment(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EditComment", w, r)
+}
+
+// EditComment indicates an expected call of EditComment.
+func (mr *MockRoutesMockRecorder) EditComment(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditComment", reflect.TypeOf((*MockRoutes)(nil).EditComment), w, r)
+}
+
+// Feed mocks base method.
+func (m *MockRoutes) Feed(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Feed", w, r)
+}
+
+// Feed indicates an expected call of Feed.
+func (mr *MockRoutesMockRecorder) Feed(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Feed", reflect.TypeOf((*MockRoutes)(nil).Feed), w, r)
+}
+
 // GetArticle mocks base method.
 func (m *MockRoutes) GetArticle(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -58,6 +238,66 @@ func (mr *MockRoutesMockRecorder) GetArticle(w, r interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticle", reflect.TypeOf((*MockRoutes)(nil).GetArticle), w, r)
 }
 
+// GetArticleBySlug mocks base method.
+func (m *MockRoutes) GetArticleBySlug(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetArticleBySlug", w, r)
+}
+
+// GetArticleBySlug indicates an expected call of GetArticleBySlug.
+func (mr *MockRoutesMockRecorder) GetArticleBySlug(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBySlug", reflect.TypeOf((*MockRoutes)(nil).GetArticleBySlug), w, r)
+}
+
+// GetMedia mocks base method.
+func (m *MockRoutes) GetMedia(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetMedia", w, r)
+}
+
+// GetMedia indicates an expected call of GetMedia.
+func (mr *MockRoutesMockRecorder) GetMedia(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedia", reflect.TypeOf((*MockRoutes)(nil).GetMedia), w, r)
+}
+
+// GetMediaVariant mocks base method.
+func (m *MockRoutes) GetMediaVariant(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetMediaVariant", w, r)
+}
+
+// GetMediaVariant indicates an expected call of GetMediaVariant.
+func (mr *MockRoutesMockRecorder) GetMediaVariant(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaVariant", reflect.TypeOf((*MockRoutes)(nil).GetMediaVariant), w, r)
+}
+
+// GetRevision mocks base method.
+func (m *MockRoutes) GetRevision(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetRevision", w, r)
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockRoutesMockRecorder) GetRevision(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockRoutes)(nil).GetRevision), w, r)
+}
+
+// GraphQL mocks base method.
+func (m *MockRoutes) GraphQL(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GraphQL", w, r)
+}
+
+// GraphQL indicates an expected call of GraphQL.
+func (mr *MockRoutesMockRecorder) GraphQL(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GraphQL", reflect.TypeOf((*MockRoutes)(nil).GraphQL), w, r)
+}
+
 // HealthCheck mocks base method.
 func (m *MockRoutes) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -81,3 +321,363 @@ func (mr *MockRoutesMockRecorder) InsertArticle(w, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertArticle", reflect.TypeOf((*MockRoutes)(nil).InsertArticle), w, r)
 }
+
+// ListAPIKeys mocks base method.
+func (m *MockRoutes) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListAPIKeys", w, r)
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockRoutesMockRecorder) ListAPIKeys(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockRoutes)(nil).ListAPIKeys), w, r)
+}
+
+// ListCategories mocks base method.
+func (m *MockRoutes) ListCategories(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListCategories", w, r)
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockRoutesMockRecorder) ListCategories(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockRoutes)(nil).ListCategories), w, r)
+}
+
+// ListTags mocks base method.
+func (m *MockRoutes) ListTags(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListTags", w, r)
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRoutesMockRecorder) ListTags(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRoutes)(nil).ListTags), w, r)
+}
+
+// ListUsers mocks base method.
+func (m *MockRoutes) ListUsers(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListUsers", w, r)
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockRoutesMockRecorder) ListUsers(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockRoutes)(nil).ListUsers), w, r)
+}
+
+// Login mocks base method.
+func (m *MockRoutes) Login(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Login", w, r)
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockRoutesMockRecorder) Login(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockRoutes)(nil).Login), w, r)
+}
+
+// Logout mocks base method.
+func (m *MockRoutes) Logout(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Logout", w, r)
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockRoutesMockRecorder) Logout(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockRoutes)(nil).Logout), w, r)
+}
+
+// MergeTag mocks base method.
+func (m *MockRoutes) MergeTag(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MergeTag", w, r)
+}
+
+// MergeTag indicates an expected call of MergeTag.
+func (mr *MockRoutesMockRecorder) MergeTag(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTag", reflect.TypeOf((*MockRoutes)(nil).MergeTag), w, r)
+}
+
+// PatchArticle mocks base method.
+func (m *MockRoutes) PatchArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PatchArticle", w, r)
+}
+
+// PatchArticle indicates an expected call of PatchArticle.
+func (mr *MockRoutesMockRecorder) PatchArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchArticle", reflect.TypeOf((*MockRoutes)(nil).PatchArticle), w, r)
+}
+
+// PostComment mocks base method.
+func (m *MockRoutes) PostComment(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PostComment", w, r)
+}
+
+// PostComment indicates an expected call of PostComment.
+func (mr *MockRoutesMockRecorder) PostComment(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostComment", reflect.TypeOf((*MockRoutes)(nil).PostComment), w, r)
+}
+
+// PublishArticle mocks base method.
+func (m *MockRoutes) PublishArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishArticle", w, r)
+}
+
+// PublishArticle indicates an expected call of PublishArticle.
+func (mr *MockRoutesMockRecorder) PublishArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishArticle", reflect.TypeOf((*MockRoutes)(nil).PublishArticle), w, r)
+}
+
+// Refresh mocks base method.
+func (m *MockRoutes) Refresh(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Refresh", w, r)
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockRoutesMockRecorder) Refresh(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockRoutes)(nil).Refresh), w, r)
+}
+
+// Register mocks base method.
+func (m *MockRoutes) Register(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", w, r)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockRoutesMockRecorder) Register(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockRoutes)(nil).Register), w, r)
+}
+
+// RejectComment mocks base method.
+func (m *MockRoutes) RejectComment(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RejectComment", w, r)
+}
+
+// RejectComment indicates an expected call of RejectComment.
+func (mr *MockRoutesMockRecorder) RejectComment(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectComment", reflect.TypeOf((*MockRoutes)(nil).RejectComment), w, r)
+}
+
+// RenameTag mocks base method.
+func (m *MockRoutes) RenameTag(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RenameTag", w, r)
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockRoutesMockRecorder) RenameTag(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockRoutes)(nil).RenameTag), w, r)
+}
+
+// RestoreRevision mocks base method.
+func (m *MockRoutes) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreRevision", w, r)
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockRoutesMockRecorder) RestoreRevision(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockRoutes)(nil).RestoreRevision), w, r)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockRoutes) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeAPIKey", w, r)
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockRoutesMockRecorder) RevokeAPIKey(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockRoutes)(nil).RevokeAPIKey), w, r)
+}
+
+// RobotsTxt mocks base method.
+func (m *MockRoutes) RobotsTxt(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RobotsTxt", w, r)
+}
+
+// RobotsTxt indicates an expected call of RobotsTxt.
+func (mr *MockRoutesMockRecorder) RobotsTxt(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RobotsTxt", reflect.TypeOf((*MockRoutes)(nil).RobotsTxt), w, r)
+}
+
+// ScheduledArticles mocks base method.
+func (m *MockRoutes) ScheduledArticles(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ScheduledArticles", w, r)
+}
+
+// ScheduledArticles indicates an expected call of ScheduledArticles.
+func (mr *MockRoutesMockRecorder) ScheduledArticles(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduledArticles", reflect.TypeOf((*MockRoutes)(nil).ScheduledArticles), w, r)
+}
+
+// SearchArticles mocks base method.
+func (m *MockRoutes) SearchArticles(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SearchArticles", w, r)
+}
+
+// SearchArticles indicates an expected call of SearchArticles.
+func (mr *MockRoutesMockRecorder) SearchArticles(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchArticles", reflect.TypeOf((*MockRoutes)(nil).SearchArticles), w, r)
+}
+
+// SetUserRole mocks base method.
+func (m *MockRoutes) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetUserRole", w, r)
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockRoutesMockRecorder) SetUserRole(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockRoutes)(nil).SetUserRole), w, r)
+}
+
+// Sitemap mocks base method.
+func (m *MockRoutes) Sitemap(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Sitemap", w, r)
+}
+
+// Sitemap indicates an expected call of Sitemap.
+func (mr *MockRoutesMockRecorder) Sitemap(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sitemap", reflect.TypeOf((*MockRoutes)(nil).Sitemap), w, r)
+}
+
+// SitemapPage mocks base method.
+func (m *MockRoutes) SitemapPage(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SitemapPage", w, r)
+}
+
+// SitemapPage indicates an expected call of SitemapPage.
+func (mr *MockRoutesMockRecorder) SitemapPage(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SitemapPage", reflect.TypeOf((*MockRoutes)(nil).SitemapPage), w, r)
+}
+
+// SpamComment mocks base method.
+func (m *MockRoutes) SpamComment(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SpamComment", w, r)
+}
+
+// SpamComment indicates an expected call of SpamComment.
+func (mr *MockRoutesMockRecorder) SpamComment(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpamComment", reflect.TypeOf((*MockRoutes)(nil).SpamComment), w, r)
+}
+
+// SubmitArticle mocks base method.
+func (m *MockRoutes) SubmitArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubmitArticle", w, r)
+}
+
+// SubmitArticle indicates an expected call of SubmitArticle.
+func (mr *MockRoutesMockRecorder) SubmitArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitArticle", reflect.TypeOf((*MockRoutes)(nil).SubmitArticle), w, r)
+}
+
+// TagArticles mocks base method.
+func (m *MockRoutes) TagArticles(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TagArticles", w, r)
+}
+
+// TagArticles indicates an expected call of TagArticles.
+func (mr *MockRoutesMockRecorder) TagArticles(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagArticles", reflect.TypeOf((*MockRoutes)(nil).TagArticles), w, r)
+}
+
+// TagFeed mocks base method.
+func (m *MockRoutes) TagFeed(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TagFeed", w, r)
+}
+
+// TagFeed indicates an expected call of TagFeed.
+func (mr *MockRoutesMockRecorder) TagFeed(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagFeed", reflect.TypeOf((*MockRoutes)(nil).TagFeed), w, r)
+}
+
+// UnpublishArticle mocks base method.
+func (m *MockRoutes) UnpublishArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UnpublishArticle", w, r)
+}
+
+// UnpublishArticle indicates an expected call of UnpublishArticle.
+func (mr *MockRoutesMockRecorder) UnpublishArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpublishArticle", reflect.TypeOf((*MockRoutes)(nil).UnpublishArticle), w, r)
+}
+
+// UpdateArticle mocks base method.
+func (m *MockRoutes) UpdateArticle(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateArticle", w, r)
+}
+
+// UpdateArticle indicates an expected call of UpdateArticle.
+func (mr *MockRoutesMockRecorder) UpdateArticle(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockRoutes)(nil).UpdateArticle), w, r)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockRoutes) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateCategory", w, r)
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockRoutesMockRecorder) UpdateCategory(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockRoutes)(nil).UpdateCategory), w, r)
+}
+
+// UploadMedia mocks base method.
+func (m *MockRoutes) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UploadMedia", w, r)
+}
+
+// UploadMedia indicates an expected call of UploadMedia.
+func (mr *MockRoutesMockRecorder) UploadMedia(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadMedia", reflect.TypeOf((*MockRoutes)(nil).UploadMedia), w, r)
+}
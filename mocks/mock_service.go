@@ -34,47 +34,331 @@ func (m *MockArticleServices) EXPECT() *MockArticleServicesMockRecorder {
 	return m.recorder
 }
 
+// ArchiveArticle mocks base method.
+func (m *MockArticleServices) ArchiveArticle(id int, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveArticle", id, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveArticle indicates an expected call of ArchiveArticle.
+func (mr *MockArticleServicesMockRecorder) ArchiveArticle(id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveArticle", reflect.TypeOf((*MockArticleServices)(nil).ArchiveArticle), id, actor)
+}
+
 // CreateArticle mocks base method.
-func (m *MockArticleServices) CreateArticle(article *models.Article) (int, error) {
+func (m *MockArticleServices) CreateArticle(article *models.Article, actor models.Principal) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateArticle", article)
+	ret := m.ctrl.Call(m, "CreateArticle", article, actor)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateArticle indicates an expected call of CreateArticle.
-func (mr *MockArticleServicesMockRecorder) CreateArticle(article interface{}) *gomock.Call {
+func (mr *MockArticleServicesMockRecorder) CreateArticle(article, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArticle", reflect.TypeOf((*MockArticleServices)(nil).CreateArticle), article, actor)
+}
+
+// DeleteArticle mocks base method.
+func (m *MockArticleServices) DeleteArticle(id int, actor models.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArticle", id, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArticle indicates an expected call of DeleteArticle.
+func (mr *MockArticleServicesMockRecorder) DeleteArticle(id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArticle", reflect.TypeOf((*MockArticleServices)(nil).DeleteArticle), id, actor)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleServices) DiffRevisions(id, from, to int, viewer models.Principal) (*models.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", id, from, to, viewer)
+	ret0, _ := ret[0].(*models.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServicesMockRecorder) DiffRevisions(id, from, to, viewer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArticle", reflect.TypeOf((*MockArticleServices)(nil).CreateArticle), article)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleServices)(nil).DiffRevisions), id, from, to, viewer)
 }
 
 // GetAllArticles mocks base method.
-func (m *MockArticleServices) GetAllArticles() ([]models.Article, error) {
+func (m *MockArticleServices) GetAllArticles(params models.ListParams) (*models.ArticlePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllArticles")
-	ret0, _ := ret[0].([]models.Article)
+	ret := m.ctrl.Call(m, "GetAllArticles", params)
+	ret0, _ := ret[0].(*models.ArticlePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllArticles indicates an expected call of GetAllArticles.
-func (mr *MockArticleServicesMockRecorder) GetAllArticles() *gomock.Call {
+func (mr *MockArticleServicesMockRecorder) GetAllArticles(params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArticles", reflect.TypeOf((*MockArticleServices)(nil).GetAllArticles))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllArticles", reflect.TypeOf((*MockArticleServices)(nil).GetAllArticles), params)
 }
 
 // GetArticleByID mocks base method.
-func (m *MockArticleServices) GetArticleByID(id int) (*models.Article, error) {
+func (m *MockArticleServices) GetArticleByID(id int, viewer models.Principal) (*models.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArticleByID", id)
+	ret := m.ctrl.Call(m, "GetArticleByID", id, viewer)
 	ret0, _ := ret[0].(*models.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetArticleByID indicates an expected call of GetArticleByID.
-func (mr *MockArticleServicesMockRecorder) GetArticleByID(id interface{}) *gomock.Call {
+func (mr *MockArticleServicesMockRecorder) GetArticleByID(id, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleByID", reflect.TypeOf((*MockArticleServices)(nil).GetArticleByID), id, viewer)
+}
+
+// GetArticleBySlug mocks base method.
+func (m *MockArticleServices) GetArticleBySlug(slug string, viewer models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArticleBySlug", slug, viewer)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArticleBySlug indicates an expected call of GetArticleBySlug.
+func (mr *MockArticleServicesMockRecorder) GetArticleBySlug(slug, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleBySlug", reflect.TypeOf((*MockArticleServices)(nil).GetArticleBySlug), slug, viewer)
+}
+
+// GetFeed mocks base method.
+func (m *MockArticleServices) GetFeed(author, tag string) (*models.Feed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", author, tag)
+	ret0, _ := ret[0].(*models.Feed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockArticleServicesMockRecorder) GetFeed(author, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockArticleServices)(nil).GetFeed), author, tag)
+}
+
+// GetRevision mocks base method.
+func (m *MockArticleServices) GetRevision(id, revision int, viewer models.Principal) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", id, revision, viewer)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockArticleServicesMockRecorder) GetRevision(id, revision, viewer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockArticleServices)(nil).GetRevision), id, revision, viewer)
+}
+
+// GetRevisions mocks base method.
+func (m *MockArticleServices) GetRevisions(id int, viewer models.Principal, params models.ListParams) (*models.RevisionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", id, viewer, params)
+	ret0, _ := ret[0].(*models.RevisionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockArticleServicesMockRecorder) GetRevisions(id, viewer, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockArticleServices)(nil).GetRevisions), id, viewer, params)
+}
+
+// GetScheduledArticles mocks base method.
+func (m *MockArticleServices) GetScheduledArticles(params models.ListParams) (*models.ArticlePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledArticles", params)
+	ret0, _ := ret[0].(*models.ArticlePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledArticles indicates an expected call of GetScheduledArticles.
+func (mr *MockArticleServicesMockRecorder) GetScheduledArticles(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledArticles", reflect.TypeOf((*MockArticleServices)(nil).GetScheduledArticles), params)
+}
+
+// GetSitemap mocks base method.
+func (m *MockArticleServices) GetSitemap(page int) ([]models.SitemapEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSitemap", page)
+	ret0, _ := ret[0].([]models.SitemapEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSitemap indicates an expected call of GetSitemap.
+func (mr *MockArticleServicesMockRecorder) GetSitemap(page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSitemap", reflect.TypeOf((*MockArticleServices)(nil).GetSitemap), page)
+}
+
+// GetSitemapPages mocks base method.
+func (m *MockArticleServices) GetSitemapPages() ([]models.SitemapPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSitemapPages")
+	ret0, _ := ret[0].([]models.SitemapPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSitemapPages indicates an expected call of GetSitemapPages.
+func (mr *MockArticleServicesMockRecorder) GetSitemapPages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSitemapPages", reflect.TypeOf((*MockArticleServices)(nil).GetSitemapPages))
+}
+
+// PatchArticle mocks base method.
+func (m *MockArticleServices) PatchArticle(id int, patch []byte, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchArticle", id, patch, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchArticle indicates an expected call of PatchArticle.
+func (mr *MockArticleServicesMockRecorder) PatchArticle(id, patch, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchArticle", reflect.TypeOf((*MockArticleServices)(nil).PatchArticle), id, patch, actor)
+}
+
+// PublishArticle mocks base method.
+func (m *MockArticleServices) PublishArticle(id int, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishArticle", id, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishArticle indicates an expected call of PublishArticle.
+func (mr *MockArticleServicesMockRecorder) PublishArticle(id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishArticle", reflect.TypeOf((*MockArticleServices)(nil).PublishArticle), id, actor)
+}
+
+// PublishDueArticles mocks base method.
+func (m *MockArticleServices) PublishDueArticles() ([]models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDueArticles")
+	ret0, _ := ret[0].([]models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDueArticles indicates an expected call of PublishDueArticles.
+func (mr *MockArticleServicesMockRecorder) PublishDueArticles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDueArticles", reflect.TypeOf((*MockArticleServices)(nil).PublishDueArticles))
+}
+
+// RestoreRevision mocks base method.
+func (m *MockArticleServices) RestoreRevision(id, revision int, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRevision", id, revision, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreRevision indicates an expected call of RestoreRevision.
+func (mr *MockArticleServicesMockRecorder) RestoreRevision(id, revision, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRevision", reflect.TypeOf((*MockArticleServices)(nil).RestoreRevision), id, revision, actor)
+}
+
+// SearchArticles mocks base method.
+func (m *MockArticleServices) SearchArticles(query string, params models.ListParams) (*models.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchArticles", query, params)
+	ret0, _ := ret[0].(*models.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchArticles indicates an expected call of SearchArticles.
+func (mr *MockArticleServicesMockRecorder) SearchArticles(query, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchArticles", reflect.TypeOf((*MockArticleServices)(nil).SearchArticles), query, params)
+}
+
+// SubmitArticle mocks base method.
+func (m *MockArticleServices) SubmitArticle(id int, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitArticle", id, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitArticle indicates an expected call of SubmitArticle.
+func (mr *MockArticleServicesMockRecorder) SubmitArticle(id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitArticle", reflect.TypeOf((*MockArticleServices)(nil).SubmitArticle), id, actor)
+}
+
+// UnpublishArticle mocks base method.
+func (m *MockArticleServices) UnpublishArticle(id int, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpublishArticle", id, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnpublishArticle indicates an expected call of UnpublishArticle.
+func (mr *MockArticleServicesMockRecorder) UnpublishArticle(id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpublishArticle", reflect.TypeOf((*MockArticleServices)(nil).UnpublishArticle), id, actor)
+}
+
+// UpdateArticle mocks base method.
+func (m *MockArticleServices) UpdateArticle(id int, article *models.Article, actor models.Principal) (*models.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArticle", id, article, actor)
+	ret0, _ := ret[0].(*models.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateArticle indicates an expected call of UpdateArticle.
+func (mr *MockArticleServicesMockRecorder) UpdateArticle(id, article, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArticle", reflect.TypeOf((*MockArticleServices)(nil).UpdateArticle), id, article, actor)
+}
+
+// WatchArticles mocks base method.
+func (m *MockArticleServices) WatchArticles() (<-chan models.ArticleEvent, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchArticles")
+	ret0, _ := ret[0].(<-chan models.ArticleEvent)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// WatchArticles indicates an expected call of WatchArticles.
+func (mr *MockArticleServicesMockRecorder) WatchArticles() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArticleByID", reflect.TypeOf((*MockArticleServices)(nil).GetArticleByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchArticles", reflect.TypeOf((*MockArticleServices)(nil).WatchArticles))
 }
@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./services/taxonomy/taxonomy_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/pkg/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTaxonomyServices is a mock of TaxonomyServices interface.
+type MockTaxonomyServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxonomyServicesMockRecorder
+}
+
+// MockTaxonomyServicesMockRecorder is the mock recorder for MockTaxonomyServices.
+type MockTaxonomyServicesMockRecorder struct {
+	mock *MockTaxonomyServices
+}
+
+// NewMockTaxonomyServices creates a new mock instance.
+func NewMockTaxonomyServices(ctrl *gomock.Controller) *MockTaxonomyServices {
+	mock := &MockTaxonomyServices{ctrl: ctrl}
+	mock.recorder = &MockTaxonomyServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxonomyServices) EXPECT() *MockTaxonomyServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateCategory mocks base method.
+func (m *MockTaxonomyServices) CreateCategory(request models.CategoryRequest, actor models.Principal) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", request, actor)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockTaxonomyServicesMockRecorder) CreateCategory(request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockTaxonomyServices)(nil).CreateCategory), request, actor)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockTaxonomyServices) DeleteCategory(slug string, actor models.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", slug, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockTaxonomyServicesMockRecorder) DeleteCategory(slug, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockTaxonomyServices)(nil).DeleteCategory), slug, actor)
+}
+
+// GetCategories mocks base method.
+func (m *MockTaxonomyServices) GetCategories() ([]models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategories")
+	ret0, _ := ret[0].([]models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategories indicates an expected call of GetCategories.
+func (mr *MockTaxonomyServicesMockRecorder) GetCategories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategories", reflect.TypeOf((*MockTaxonomyServices)(nil).GetCategories))
+}
+
+// GetCategoryArticles mocks base method.
+func (m *MockTaxonomyServices) GetCategoryArticles(slug string, params models.ListParams) (*models.ArticlePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryArticles", slug, params)
+	ret0, _ := ret[0].(*models.ArticlePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryArticles indicates an expected call of GetCategoryArticles.
+func (mr *MockTaxonomyServicesMockRecorder) GetCategoryArticles(slug, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryArticles", reflect.TypeOf((*MockTaxonomyServices)(nil).GetCategoryArticles), slug, params)
+}
+
+// GetTag mocks base method.
+func (m *MockTaxonomyServices) GetTag(slug string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTag", slug)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTag indicates an expected call of GetTag.
+func (mr *MockTaxonomyServicesMockRecorder) GetTag(slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTag", reflect.TypeOf((*MockTaxonomyServices)(nil).GetTag), slug)
+}
+
+// GetTagArticles mocks base method.
+func (m *MockTaxonomyServices) GetTagArticles(slug string, params models.ListParams) (*models.ArticlePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagArticles", slug, params)
+	ret0, _ := ret[0].(*models.ArticlePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTagArticles indicates an expected call of GetTagArticles.
+func (mr *MockTaxonomyServicesMockRecorder) GetTagArticles(slug, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagArticles", reflect.TypeOf((*MockTaxonomyServices)(nil).GetTagArticles), slug, params)
+}
+
+// GetTags mocks base method.
+func (m *MockTaxonomyServices) GetTags(params models.ListParams) (*models.TagPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", params)
+	ret0, _ := ret[0].(*models.TagPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockTaxonomyServicesMockRecorder) GetTags(params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockTaxonomyServices)(nil).GetTags), params)
+}
+
+// MergeTag mocks base method.
+func (m *MockTaxonomyServices) MergeTag(slug string, request models.TagMergeRequest, actor models.Principal) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeTag", slug, request, actor)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeTag indicates an expected call of MergeTag.
+func (mr *MockTaxonomyServicesMockRecorder) MergeTag(slug, request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeTag", reflect.TypeOf((*MockTaxonomyServices)(nil).MergeTag), slug, request, actor)
+}
+
+// RenameTag mocks base method.
+func (m *MockTaxonomyServices) RenameTag(slug string, request models.TagRequest, actor models.Principal) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", slug, request, actor)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockTaxonomyServicesMockRecorder) RenameTag(slug, request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockTaxonomyServices)(nil).RenameTag), slug, request, actor)
+}
+
+// UpdateCategory mocks base method.
+func (m *MockTaxonomyServices) UpdateCategory(slug string, request models.CategoryRequest, actor models.Principal) (*models.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", slug, request, actor)
+	ret0, _ := ret[0].(*models.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockTaxonomyServicesMockRecorder) UpdateCategory(slug, request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockTaxonomyServices)(nil).UpdateCategory), slug, request, actor)
+}
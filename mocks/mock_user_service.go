@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./services/users/users_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	models "backend/pkg/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserServices is a mock of UserServices interface.
+type MockUserServices struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServicesMockRecorder
+}
+
+// MockUserServicesMockRecorder is the mock recorder for MockUserServices.
+type MockUserServicesMockRecorder struct {
+	mock *MockUserServices
+}
+
+// NewMockUserServices creates a new mock instance.
+func NewMockUserServices(ctrl *gomock.Controller) *MockUserServices {
+	mock := &MockUserServices{ctrl: ctrl}
+	mock.recorder = &MockUserServicesMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServices) EXPECT() *MockUserServicesMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockUserServices) CreateAPIKey(request models.APIKeyRequest, actor models.Principal) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", request, actor)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockUserServicesMockRecorder) CreateAPIKey(request, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockUserServices)(nil).CreateAPIKey), request, actor)
+}
+
+// GetAuthor mocks base method.
+func (m *MockUserServices) GetAuthor(username string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthor", username)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockUserServicesMockRecorder) GetAuthor(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockUserServices)(nil).GetAuthor), username)
+}
+
+// GetAuthors mocks base method.
+func (m *MockUserServices) GetAuthors(ids []int) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthors", ids)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthors indicates an expected call of GetAuthors.
+func (mr *MockUserServicesMockRecorder) GetAuthors(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthors", reflect.TypeOf((*MockUserServices)(nil).GetAuthors), ids)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockUserServices) ListAPIKeys(actor models.Principal) ([]models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", actor)
+	ret0, _ := ret[0].([]models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockUserServicesMockRecorder) ListAPIKeys(actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockUserServices)(nil).ListAPIKeys), actor)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserServices) ListUsers(params models.ListParams, actor models.Principal) (*models.UserPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", params, actor)
+	ret0, _ := ret[0].(*models.UserPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserServicesMockRecorder) ListUsers(params, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserServices)(nil).ListUsers), params, actor)
+}
+
+// Login mocks base method.
+func (m *MockUserServices) Login(credentials models.Credentials) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Login", credentials)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Login indicates an expected call of Login.
+func (mr *MockUserServicesMockRecorder) Login(credentials interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserServices)(nil).Login), credentials)
+}
+
+// Logout mocks base method.
+func (m *MockUserServices) Logout(refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockUserServicesMockRecorder) Logout(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserServices)(nil).Logout), refreshToken)
+}
+
+// Refresh mocks base method.
+func (m *MockUserServices) Refresh(refreshToken string) (*models.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", refreshToken)
+	ret0, _ := ret[0].(*models.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockUserServicesMockRecorder) Refresh(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockUserServices)(nil).Refresh), refreshToken)
+}
+
+// Register mocks base method.
+func (m *MockUserServices) Register(registration models.Registration) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Register", registration)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockUserServicesMockRecorder) Register(registration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserServices)(nil).Register), registration)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockUserServices) RevokeAPIKey(id int, actor models.Principal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", id, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockUserServicesMockRecorder) RevokeAPIKey(id, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockUserServices)(nil).RevokeAPIKey), id, actor)
+}
+
+// SetRole mocks base method.
+func (m *MockUserServices) SetRole(id int, role string, actor models.Principal) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", id, role, actor)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockUserServicesMockRecorder) SetRole(id, role, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockUserServices)(nil).SetRole), id, role, actor)
+}
+
+// VerifyAPIKey mocks base method.
+func (m *MockUserServices) VerifyAPIKey(key string) (models.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAPIKey", key)
+	ret0, _ := ret[0].(models.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyAPIKey indicates an expected call of VerifyAPIKey.
+func (mr *MockUserServicesMockRecorder) VerifyAPIKey(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAPIKey", reflect.TypeOf((*MockUserServices)(nil).VerifyAPIKey), key)
+}
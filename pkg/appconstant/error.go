@@ -3,6 +3,7 @@ package appconst
 const (
 	Errorconst        = "Error in retrieving articles: "
 	NoArticleforid    = "No article found for the given ID"
+	NoArticleforslug  = "No article found for the given slug"
 	Parsingarticle    = "Error parsing article ID: "
 	Retrivearticle    = "Error in retrieving article: "
 	Noarticlefound    = "No article found"
@@ -10,4 +11,125 @@ const (
 	Articlenotcreated = "Article not created due to database error: "
 	Queryerror        = "Error in getting query: "
 	Nextrow           = "Error in getting next row: "
+	Articlenotupdated = "Article not updated: "
+	Articlenotdeleted = "Article not deleted: "
+	Patchparsing      = "Error applying merge patch"
+	Internalerror     = "Internal server error"
+	Timeouterror      = "The request timed out, please retry"
+	Unavailableerror  = "Service temporarily unavailable"
+	Conflicterror     = "The request conflicts with an existing resource"
+	Validationerror   = "The request is invalid"
+	Unauthorizederror = "Authentication required"
+	Invalidlimit      = "limit must be a number between 1 and %d"
+	Invalidoffset     = "offset must be a positive number"
+	Invalidcursor     = "cursor is invalid"
+	Cursorwithoffset  = "cursor and offset cannot be combined"
+	Emptysearch       = "q must contain a search query"
+	Searchcursor      = "search results are paged with offset, not cursor"
+	Searcherror       = "Error in searching articles: "
+	Rendererror       = "Error in rendering article content: "
+	Unknownparameter  = "unknown query parameter %q, valid parameters are: %s"
+	Invalidsort       = "sort must be one of: %s, prefixed with - for descending order"
+	Invalidtimestamp  = "%s must be an RFC 3339 timestamp"
+	Invalidstatus     = "status must be one of: %s"
+	Invalidformat     = "format must be one of: %s"
+	Cursorsort        = "cursor was issued for a different sort order"
+	Invalidtransition = "cannot %s an article that is %s"
+	Statuschanged     = "The article status was changed by another request, please retry"
+	Statusnotchanged  = "Article status not changed: "
+	Offsetonly        = "this listing is paged with offset, not cursor"
+	Scheduledlist     = "Error in retrieving scheduled articles: "
+	Publishinpast     = "publish_at must be in the future"
+	Norevision        = "No revision found for the given number"
+	Parsingrevision   = "Error parsing revision number: "
+	Revisionerror     = "Error in retrieving revisions: "
+	Restoreerror      = "Revision not restored: "
+	Invalidfrom       = "from must be a revision number"
+	Nouser            = "No user found"
+	Usertaken         = "username or email is already registered"
+	Invalidusername   = "username must be 3 to 32 letters, digits, dots, dashes or underscores"
+	Invalidemail      = "email must be a valid email address"
+	Shortpassword     = "password must be at least %d characters"
+	Longpassword      = "password must be at most %d characters"
+	Badcredentials    = "invalid username or password"
+	Registererror     = "User not registered: "
+	Loginerror        = "Login failed: "
+	Authorrequired    = "author_id must reference a registered user"
+	Unauthenticated   = "sign in to do this"
+	Invalidtoken      = "access token is invalid or has expired"
+	Invalidrefresh    = "refresh token is invalid, expired or revoked"
+	Refresherror      = "Tokens not refreshed: "
+	Logouterror       = "Logout failed: "
+	Forbidden         = "you are not allowed to do this"
+	Invalidrole       = "role must be one of: %s"
+	Ownrole           = "admins cannot change their own role"
+	Userlist          = "Error in retrieving users: "
+	Parsinguser       = "Error parsing user ID: "
+	Missingscope      = "the API key does not have the %s scope"
+	Invalidapikey     = "API key is invalid or has been revoked"
+	Apikeyname        = "name must not be empty"
+	Apikeyscopes      = "scopes must be one or more of: %s"
+	Adminscope        = "only admins can issue keys with the admin scope"
+	Noapikey          = "No API key found"
+	Parsingapikey     = "Error parsing API key ID: "
+	Apikeyerror       = "API key not issued: "
+	Apikeylist        = "Error in retrieving API keys: "
+	Apikeynotrevoked  = "API key not revoked: "
+	Rolenotchanged    = "Role not changed: "
+	Nocomment         = "No comment found"
+	Commentbody       = "comment must not be empty"
+	Commentlength     = "comment must be at most %d characters"
+	Noparent          = "the comment replied to is not on this article"
+	Invalidview       = "view must be one of: %s"
+	Parsingcomment    = "Error parsing comment ID: "
+	Commentlist       = "Error in retrieving comments: "
+	Commenterror      = "Comment not posted: "
+	Commentnotsaved   = "Comment not updated: "
+	Commentnotdeleted = "Comment not deleted: "
+	Commentstatus     = "status must be one of: %s"
+	Commentqueue      = "Error in retrieving the moderation queue: "
+	Notmoderated      = "Comment not moderated: "
+	Notag             = "No tag found"
+	Nocategory        = "No category found"
+	Unknowncategory   = "categories must be slugs of existing categories"
+	Invalidname       = "name must have a letter or digit and be at most %d characters"
+	Toomanytags       = "an article can have at most %d tags"
+	Tagexists         = "a tag with the slug %s already exists, merge the tags instead"
+	Mergetarget       = "into must be the slug of another existing tag"
+	Categoryexists    = "a category with the slug %s already exists"
+	Noparentcategory  = "parent_id must reference an existing category"
+	Categorycycle     = "a category cannot be moved below itself"
+	Categorychildren  = "move or delete the categories below this one first"
+	Taglist           = "Error in retrieving tags: "
+	Tagnotsaved       = "Tag not updated: "
+	Tagsnotmerged     = "Tags not merged: "
+	Categorylist      = "Error in retrieving categories: "
+	Categoryerror     = "Category not created: "
+	Categorynotsaved  = "Category not updated: "
+	Categorynotdelete = "Category not deleted: "
+	Nomedia           = "No media found"
+	Mediaempty        = "the file must not be empty"
+	Mediatoolarge     = "the file must be at most %d bytes"
+	Mediatype         = "the file must be one of: %s"
+	Mediamalformed    = "the image could not be read"
+	Mediafile         = "the upload must have a file part named file"
+	Parsingmedia      = "Error parsing media ID: "
+	Mediaerror        = "Media not uploaded: "
+	Mediaunavailable  = "Error in retrieving media: "
+	Novariant         = "No variant of the image found with this width"
+	Parsingwidth      = "Error parsing variant width: "
+	Nocover           = "cover_id must reference an uploaded image"
+	Nofeed            = "No feed in this format, use rss, atom or json"
+	Feederror         = "Error in generating feed: "
+	Nositemap         = "No sitemap found with this number"
+	Sitemaperror      = "Error in generating sitemap: "
+	Invalidid         = "%s must be an ID"
+	Articleselector   = "give either id or slug"
+	Graphqlquery      = "the request must have a query"
+	Querydepth        = "the query is nested more than %d levels deep"
+	Querycomplexity   = "the query has a complexity of %d, at most %d is allowed"
+	Graphqlerror      = "Error in resolving GraphQL query: "
+	Watchbehind       = "the watch fell behind the changes to articles, watch again"
+	Serverstopping    = "the server is shutting down"
+	Grpcerror         = "Error in serving gRPC call: "
 )
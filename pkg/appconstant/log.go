@@ -1,6 +1,12 @@
 package appconst
 
 const (
-	Startapp    = "Starting application on port"
-	DatabseWait = "Wait for the database container to start up"
+	Startapp           = "Starting application on port"
+	Startgrpc          = "Starting gRPC server on port"
+	DatabseWait        = "Wait for the database container to start up"
+	Scheduledpublished = "Published scheduled article"
+	Schedulererror     = "Error publishing scheduled articles: "
+	Shutdown           = "Shutting down"
+	Mediaprocessed     = "Processed image"
+	Processingerror    = "Error processing images: "
 )
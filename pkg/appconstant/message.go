@@ -3,5 +3,8 @@ package appconst
 const (
 	Success            = "Success"
 	Serverup           = "Go articles up and running"
-	CreateArticleTable = "Table 'articles' created successfully!"
+	MigrationApplied   = "Applied migration"
+	MigrationReverted  = "Reverted migration"
+	MigrationsUpToDate = "Database schema is up to date"
+	MigrationDryRun    = "[dry run] "
 )
@@ -1,3 +1,6 @@
 package appconst
 
 const Port = 8080
+
+// GRPCPort is the port the gRPC services are served on by default
+const GRPCPort = 9090
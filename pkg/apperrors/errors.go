@@ -0,0 +1,93 @@
+// Package apperrors defines the domain errors shared by the repository,
+// service and controller layers.
+package apperrors
+
+import "errors"
+
+// Kinds of domain errors. Compare against them with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrTimeout      = errors.New("timeout")
+	ErrUnavailable  = errors.New("unavailable")
+	ErrTooLarge     = errors.New("too large")
+	ErrUnsupported  = errors.New("unsupported")
+)
+
+// Error is a domain error. Message is safe to show to clients while Err keeps
+// the underlying cause for logging only.
+type Error struct {
+	Kind    error
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Is reports whether target is the kind of this error.
+func (e *Error) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns a domain error of the given kind.
+func New(kind error, message string, err error) error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+func NotFound(message string, err error) error {
+	return New(ErrNotFound, message, err)
+}
+
+func Conflict(message string, err error) error {
+	return New(ErrConflict, message, err)
+}
+
+func Validation(message string, err error) error {
+	return New(ErrValidation, message, err)
+}
+
+func Unauthorized(message string, err error) error {
+	return New(ErrUnauthorized, message, err)
+}
+
+func Forbidden(message string, err error) error {
+	return New(ErrForbidden, message, err)
+}
+
+func Timeout(message string, err error) error {
+	return New(ErrTimeout, message, err)
+}
+
+func Unavailable(message string, err error) error {
+	return New(ErrUnavailable, message, err)
+}
+
+func TooLarge(message string, err error) error {
+	return New(ErrTooLarge, message, err)
+}
+
+func Unsupported(message string, err error) error {
+	return New(ErrUnsupported, message, err)
+}
+
+// Message returns the client-safe message of a domain error and false for
+// any other error.
+func Message(err error) (string, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Message, true
+	}
+	return "", false
+}
@@ -0,0 +1,49 @@
+package apperrors
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{name: "Not found", err: NotFound("missing", sql.ErrNoRows), kind: ErrNotFound},
+		{name: "Conflict", err: Conflict("duplicate", nil), kind: ErrConflict},
+		{name: "Validation", err: Validation("invalid", nil), kind: ErrValidation},
+		{name: "Unauthorized", err: Unauthorized("denied", nil), kind: ErrUnauthorized},
+		{name: "Forbidden", err: Forbidden("not allowed", nil), kind: ErrForbidden},
+		{name: "Timeout", err: Timeout("slow", nil), kind: ErrTimeout},
+		{name: "Unavailable", err: Unavailable("down", nil), kind: ErrUnavailable},
+		{name: "Too large", err: TooLarge("big", nil), kind: ErrTooLarge},
+		{name: "Unsupported", err: Unsupported("unknown type", nil), kind: ErrUnsupported},
+		{name: "Wrapped", err: fmt.Errorf("context: %w", NotFound("missing", nil)), kind: ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ErrorIs(t, test.err, test.kind)
+		})
+	}
+}
+
+func TestErrorCause(t *testing.T) {
+	err := NotFound("No article found", sql.ErrNoRows)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, "No article found: sql: no rows in result set", err.Error())
+
+	message, ok := Message(err)
+	assert.True(t, ok)
+	assert.Equal(t, "No article found", message)
+
+	_, ok = Message(errors.New("driver error"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// APIKeyTag starts every API key, so leaked keys are easy to scan for.
+const APIKeyTag = "ak"
+
+// apiKeySecretLen is the number of random bytes in the secret part of an
+// API key
+const apiKeySecretLen = 32
+
+// NewAPIKey returns a random API key, its visible prefix and the hash it
+// is stored under. Keys look like ak_<8 hex digits>_<secret>; the prefix
+// is everything before the secret.
+func NewAPIKey() (key, prefix, hash string, err error) {
+	id, err := randomBytes(4)
+	if err != nil {
+		return "", "", "", err
+	}
+	secret, err := randomString(apiKeySecretLen)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	prefix = APIKeyTag + "_" + hex.EncodeToString(id)
+	key = prefix + "_" + secret
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash an API key is stored under.
+func HashAPIKey(key string) string {
+	return HashRefreshToken(key)
+}
+
+// ValidAPIKey reports whether key is shaped like a key from NewAPIKey,
+// so obvious garbage is turned away without a database lookup.
+func ValidAPIKey(key string) bool {
+	parts := strings.SplitN(key, "_", 3)
+	return len(parts) == 3 && parts[0] == APIKeyTag && len(parts[1]) == 8 && parts[2] != ""
+}
@@ -0,0 +1,23 @@
+// Package auth issues and verifies the signed access tokens users
+// authenticate with, and carries the authenticated principal through a
+// request context.
+package auth
+
+import (
+	"backend/pkg/models"
+	"context"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal.
+func NewContext(ctx context.Context, principal models.Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal in ctx. Requests without one are
+// anonymous and get the zero Principal.
+func FromContext(ctx context.Context) models.Principal {
+	principal, _ := ctx.Value(contextKey{}).(models.Principal)
+	return principal
+}
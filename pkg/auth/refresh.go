@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// refreshTokenLen is the number of random bytes in a refresh token
+const refreshTokenLen = 32
+
+// NewRefreshToken returns a random opaque refresh token and the hash it is
+// stored under. Only the hash is kept, so a leaked table can't be used to
+// sign in.
+func NewRefreshToken() (token, hash string, err error) {
+	token, err = randomString(refreshTokenLen)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hash a refresh token is stored under.
+// Refresh tokens are long and random, so a fast hash is enough.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewFamily returns a random ID for a chain of rotated refresh tokens.
+func NewFamily() (string, error) {
+	return randomString(16)
+}
+
+func randomString(n int) (string, error) {
+	b, err := randomBytes(n)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms of access tokens
+const (
+	HS256 = "HS256"
+	RS256 = "RS256"
+)
+
+// Config holds the keys of access tokens and the lifetimes of access and
+// refresh tokens.
+type Config struct {
+	// Algorithm is HS256 or RS256
+	Algorithm string
+	// Secret signs and verifies HS256 tokens
+	Secret []byte
+	// PrivateKey signs RS256 tokens. Replicas that only verify tokens can
+	// leave it unset.
+	PrivateKey *rsa.PrivateKey
+	// PublicKey verifies RS256 tokens; taken from PrivateKey when unset
+	PublicKey *rsa.PublicKey
+	// Issuer is written to every token and required when verifying one
+	Issuer string
+	// AccessTTL is how long an access token is valid
+	AccessTTL time.Duration
+	// RefreshTTL is how long a refresh token is valid
+	RefreshTTL time.Duration
+}
+
+// Tokens issues and verifies signed JWT access tokens.
+type Tokens struct {
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	issuer    string
+	ttl       time.Duration
+	refresh   time.Duration
+	now       func() time.Time
+}
+
+// claims are the contents of an access token. The subject is the user ID.
+type claims struct {
+	Username string `json:"name"`
+	Role     string `json:"role,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewTokens checks that config has the keys its algorithm needs.
+func NewTokens(config Config) (*Tokens, error) {
+	if config.AccessTTL <= 0 || config.RefreshTTL <= 0 {
+		return nil, errors.New("token lifetimes must be positive")
+	}
+	t := &Tokens{issuer: config.Issuer, ttl: config.AccessTTL, refresh: config.RefreshTTL, now: time.Now}
+
+	switch config.Algorithm {
+	case HS256:
+		if len(config.Secret) == 0 {
+			return nil, errors.New("HS256 needs a secret")
+		}
+		t.method = jwt.SigningMethodHS256
+		t.signKey = config.Secret
+		t.verifyKey = config.Secret
+	case RS256:
+		publicKey := config.PublicKey
+		if publicKey == nil && config.PrivateKey != nil {
+			publicKey = &config.PrivateKey.PublicKey
+		}
+		if publicKey == nil {
+			return nil, errors.New("RS256 needs a public or private key")
+		}
+		t.method = jwt.SigningMethodRS256
+		if config.PrivateKey != nil {
+			t.signKey = config.PrivateKey
+		}
+		t.verifyKey = publicKey
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q, use %s or %s", config.Algorithm, HS256, RS256)
+	}
+	return t, nil
+}
+
+// Issue returns a signed access token for the user and the time it expires.
+func (t *Tokens) Issue(user *models.User) (string, time.Time, error) {
+	if t.signKey == nil {
+		return "", time.Time{}, errors.New("no private key to sign tokens with")
+	}
+
+	now := t.now()
+	expires := now.Add(t.ttl)
+	token := jwt.NewWithClaims(t.method, claims{
+		Username: user.Username,
+		Role:     user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    t.issuer,
+			Subject:   strconv.Itoa(user.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expires),
+		},
+	})
+	signed, err := token.SignedString(t.signKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expires, nil
+}
+
+// Verify checks the signature, issuer and expiry of an access token and
+// returns the principal it was issued to. Tokens signed with any other
+// algorithm than the configured one are rejected.
+func (t *Tokens) Verify(token string) (models.Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(*jwt.Token) (interface{}, error) {
+		return t.verifyKey, nil
+	},
+		jwt.WithValidMethods([]string{t.method.Alg()}),
+		jwt.WithIssuer(t.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(t.now),
+	)
+	if err != nil {
+		return models.Principal{}, apperrors.Unauthorized(appconst.Invalidtoken, err)
+	}
+
+	id, err := strconv.Atoi(c.Subject)
+	if err != nil || id <= 0 {
+		return models.Principal{}, apperrors.Unauthorized(appconst.Invalidtoken, err)
+	}
+	// Tokens issued before users had roles belong to authors
+	role := c.Role
+	if role == "" {
+		role = models.RoleAuthor
+	}
+	return models.Principal{UserID: id, Username: c.Username, Role: role}, nil
+}
+
+// RefreshExpiry returns the time a refresh token issued now expires.
+func (t *Tokens) RefreshExpiry() time.Time {
+	return t.now().Add(t.refresh)
+}
+
+// ReadPrivateKey reads a PEM encoded RSA private key.
+func ReadPrivateKey(path string) (*rsa.PrivateKey, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPrivateKeyFromPEM(pem)
+}
+
+// ReadPublicKey reads a PEM encoded RSA public key.
+func ReadPublicKey(path string) (*rsa.PublicKey, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.ParseRSAPublicKeyFromPEM(pem)
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+var ada = &models.User{ID: 7, Username: "ada", Role: models.RoleEditor}
+
+func TestTokens_RoundTrip(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		config Config
+	}{
+		{name: "HS256", config: Config{Algorithm: HS256, Secret: []byte("secret")}},
+		{name: "RS256", config: Config{Algorithm: RS256, PrivateKey: key}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.config.Issuer = "test"
+			tc.config.AccessTTL = time.Minute
+			tc.config.RefreshTTL = time.Hour
+			tokens, err := NewTokens(tc.config)
+			assert.NoError(t, err)
+
+			token, expires, err := tokens.Issue(ada)
+			assert.NoError(t, err)
+			assert.WithinDuration(t, time.Now().Add(time.Minute), expires, 2*time.Second)
+
+			principal, err := tokens.Verify(token)
+			assert.NoError(t, err)
+			assert.Equal(t, models.Principal{UserID: 7, Username: "ada", Role: models.RoleEditor}, principal)
+		})
+	}
+}
+
+func TestTokens_WithoutRole(t *testing.T) {
+	tokens, err := NewTokens(Config{Algorithm: HS256, Secret: []byte("secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	assert.NoError(t, err)
+
+	token, _, err := tokens.Issue(&models.User{ID: 7, Username: "ada"})
+	assert.NoError(t, err)
+
+	principal, err := tokens.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, models.RoleAuthor, principal.Role)
+}
+
+func TestTokens_Rejected(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	hs, _ := NewTokens(Config{Algorithm: HS256, Secret: []byte("secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	rs, _ := NewTokens(Config{Algorithm: RS256, PrivateKey: key, Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	verifyOnly, _ := NewTokens(Config{Algorithm: RS256, PublicKey: &key.PublicKey, Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	otherIssuer, _ := NewTokens(Config{Algorithm: HS256, Secret: []byte("secret"), Issuer: "other", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	otherSecret, _ := NewTokens(Config{Algorithm: HS256, Secret: []byte("other"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+
+	expired, _ := NewTokens(Config{Algorithm: HS256, Secret: []byte("secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	expired.now = func() time.Time { return time.Now().Add(-time.Hour) }
+
+	sign := func(tokens *Tokens) string {
+		token, _, err := tokens.Issue(ada)
+		assert.NoError(t, err)
+		return token
+	}
+	// HS256 tokens must not be accepted where RS256 is expected, whatever
+	// they were signed with
+	hmacSigned, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer: "test", Subject: "7", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}).SignedString([]byte("secret"))
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+		Issuer: "test", Subject: "7", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	noExpiry, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer: "test", Subject: "7",
+	}).SignedString([]byte("secret"))
+	noSubject, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Issuer: "test", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}).SignedString([]byte("secret"))
+
+	testCases := []struct {
+		name     string
+		verifier *Tokens
+		token    string
+	}{
+		{name: "Malformed", verifier: hs, token: "not.a.token"},
+		{name: "Expired", verifier: hs, token: sign(expired)},
+		{name: "Other issuer", verifier: hs, token: sign(otherIssuer)},
+		{name: "Other secret", verifier: hs, token: sign(otherSecret)},
+		{name: "HS256 token for an RS256 verifier", verifier: rs, token: hmacSigned},
+		{name: "RS256 token for an HS256 verifier", verifier: hs, token: sign(rs)},
+		{name: "Unsigned", verifier: hs, token: unsigned},
+		{name: "No expiry", verifier: hs, token: noExpiry},
+		{name: "No subject", verifier: hs, token: noSubject},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.verifier.Verify(tc.token)
+			assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+		})
+	}
+
+	// Replicas with only the public key verify tokens but can't issue them
+	_, err = verifyOnly.Verify(sign(rs))
+	assert.NoError(t, err)
+	_, _, err = verifyOnly.Issue(ada)
+	assert.Error(t, err)
+}
+
+func TestNewTokens_Config(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		config Config
+	}{
+		{name: "HS256 without a secret", config: Config{Algorithm: HS256, AccessTTL: time.Minute, RefreshTTL: time.Hour}},
+		{name: "RS256 without keys", config: Config{Algorithm: RS256, Secret: []byte("secret"), AccessTTL: time.Minute, RefreshTTL: time.Hour}},
+		{name: "Unknown algorithm", config: Config{Algorithm: "none", Secret: []byte("secret"), AccessTTL: time.Minute, RefreshTTL: time.Hour}},
+		{name: "No access token lifetime", config: Config{Algorithm: RS256, PrivateKey: key, RefreshTTL: time.Hour}},
+		{name: "No refresh token lifetime", config: Config{Algorithm: HS256, Secret: []byte("secret"), AccessTTL: time.Minute}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewTokens(tc.config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.True(t, FromContext(context.Background()).Anonymous())
+
+	ctx := NewContext(context.Background(), models.Principal{UserID: 7, Username: "ada"})
+	assert.Equal(t, models.Principal{UserID: 7, Username: "ada"}, FromContext(ctx))
+}
+
+func TestRefreshToken(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	assert.NoError(t, err)
+	assert.Equal(t, HashRefreshToken(token), hash)
+	assert.NotEqual(t, token, hash)
+
+	other, _, _ := NewRefreshToken()
+	assert.NotEqual(t, token, other)
+}
+
+func TestAPIKey(t *testing.T) {
+	key, prefix, hash, err := NewAPIKey()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(key, prefix+"_"))
+	assert.Len(t, prefix, len("ak_")+8)
+	assert.Equal(t, HashAPIKey(key), hash)
+	assert.True(t, ValidAPIKey(key))
+
+	for _, malformed := range []string{"", prefix, prefix + "_", "xx" + key[2:], "ak_123_secret"} {
+		assert.False(t, ValidAPIKey(malformed), malformed)
+	}
+}
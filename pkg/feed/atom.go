@@ -0,0 +1,91 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int64  `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Author     *atomAuthor    `xml:"author"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Atom writes the feed as Atom.
+func Atom(f *Feed) ([]byte, error) {
+	feed := atomFeed{
+		XMLNS:   atomNamespace,
+		ID:      f.FeedURL,
+		Title:   f.Title,
+		Updated: atomTime(f.Updated),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate"},
+			{Href: f.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:      item.ID,
+			Title:   item.Title,
+			Updated: atomTime(item.Updated),
+			Links:   []atomLink{{Href: item.Link, Rel: "alternate"}},
+			Content: atomContent{Type: "html", Value: item.ContentHTML},
+		}
+		if !item.Published.IsZero() {
+			entry.Published = atomTime(item.Published)
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		if item.Image != nil && item.Image.Size > 0 {
+			entry.Links = append(entry.Links, atomLink{Href: item.Image.URL, Rel: "enclosure", Type: item.Image.ContentType, Length: item.Image.Size})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return marshalXML(feed)
+}
+
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,58 @@
+// Package feed writes syndication feeds of articles in the three formats
+// feed readers understand: RSS 2.0, Atom and JSON Feed 1.1.
+package feed
+
+import "time"
+
+// Content types of the feed formats
+const (
+	RSSType  = "application/rss+xml; charset=utf-8"
+	AtomType = "application/atom+xml; charset=utf-8"
+	JSONType = "application/feed+json; charset=utf-8"
+)
+
+// Feed is a feed in no format in particular. Every URL in it is absolute.
+type Feed struct {
+	Title       string
+	Description string
+	// Link is the page the feed is the feed of
+	Link string
+	// FeedURL is where the feed itself is served from
+	FeedURL string
+	// Updated is when an item last changed
+	Updated time.Time
+	Items   []Item
+}
+
+// Item is an entry of a feed.
+type Item struct {
+	// ID never changes, even when the link does
+	ID     string
+	Title  string
+	Link   string
+	Author string
+	// ContentHTML is the content as HTML, which is sent escaped
+	ContentHTML string
+	Published   time.Time
+	Updated     time.Time
+	Tags        []string
+	Image       *Image
+}
+
+// Image is the picture of an item.
+type Image struct {
+	URL         string
+	ContentType string
+	Size        int64
+}
+
+// Formats maps the extensions feeds are served with to the function that
+// writes them and their content type.
+var Formats = map[string]struct {
+	Write       func(*Feed) ([]byte, error)
+	ContentType string
+}{
+	"rss":  {RSS, RSSType},
+	"atom": {Atom, AtomType},
+	"json": {JSON, JSONType},
+}
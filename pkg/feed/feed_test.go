@@ -0,0 +1,98 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var published = time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("CET", 3600))
+
+var testFeed = &Feed{
+	Title:       "Articles",
+	Description: "The latest articles",
+	Link:        "https://example.com",
+	FeedURL:     "https://example.com/feed.rss",
+	Updated:     published.Add(time.Hour),
+	Items: []Item{
+		{
+			ID:          "https://example.com/articles/1",
+			Title:       "Fish & Chips",
+			Link:        "https://example.com/articles/by-slug/fish-chips",
+			Author:      "ada",
+			ContentHTML: "<p>Hot <b>&amp;</b> salty</p>",
+			Published:   published,
+			Updated:     published.Add(time.Hour),
+			Tags:        []string{"Food"},
+			Image:       &Image{URL: "https://example.com/media/3/variants/640", ContentType: "image/jpeg", Size: 2048},
+		},
+	},
+}
+
+func TestRSS(t *testing.T) {
+	out, err := RSS(testFeed)
+	assert.NoError(t, err)
+	body := string(out)
+
+	assert.Contains(t, body, `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/">`)
+	assert.Contains(t, body, `<atom:link href="https://example.com/feed.rss" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, body, `<lastBuildDate>Fri, 01 Mar 2024 09:30:00 +0000</lastBuildDate>`)
+	assert.Contains(t, body, `<title>Fish &amp; Chips</title>`)
+	assert.Contains(t, body, `<guid isPermaLink="false">https://example.com/articles/1</guid>`)
+	assert.Contains(t, body, `<dc:creator>ada</dc:creator>`)
+	assert.Contains(t, body, `<pubDate>Fri, 01 Mar 2024 08:30:00 +0000</pubDate>`)
+	assert.Contains(t, body, `<category>Food</category>`)
+	// The HTML is escaped, not mixed into the XML
+	assert.Contains(t, body, `<description>&lt;p&gt;Hot &lt;b&gt;&amp;amp;&lt;/b&gt; salty&lt;/p&gt;</description>`)
+	assert.Contains(t, body, `<enclosure url="https://example.com/media/3/variants/640" length="2048" type="image/jpeg"></enclosure>`)
+	assert.NoError(t, xml.Unmarshal(out, new(interface{})))
+}
+
+func TestAtom(t *testing.T) {
+	out, err := Atom(testFeed)
+	assert.NoError(t, err)
+	body := string(out)
+
+	assert.Contains(t, body, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, body, `<id>https://example.com/feed.rss</id>`)
+	assert.Contains(t, body, `<updated>2024-03-01T09:30:00Z</updated>`)
+	assert.Contains(t, body, `<link href="https://example.com" rel="alternate"></link>`)
+	assert.Contains(t, body, `<published>2024-03-01T08:30:00Z</published>`)
+	assert.Contains(t, body, `<author>`)
+	assert.Contains(t, body, `<name>ada</name>`)
+	assert.Contains(t, body, `<category term="Food"></category>`)
+	assert.Contains(t, body, `<content type="html">&lt;p&gt;Hot &lt;b&gt;&amp;amp;&lt;/b&gt; salty&lt;/p&gt;</content>`)
+	assert.Contains(t, body, `<link href="https://example.com/media/3/variants/640" rel="enclosure" type="image/jpeg" length="2048"></link>`)
+}
+
+func TestJSON(t *testing.T) {
+	out, err := JSON(testFeed)
+	assert.NoError(t, err)
+
+	var feed map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out, &feed))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", feed["version"])
+	assert.Equal(t, "https://example.com", feed["home_page_url"])
+
+	items := feed["items"].([]interface{})
+	assert.Len(t, items, 1)
+	assert.Equal(t, map[string]interface{}{
+		"id":             "https://example.com/articles/1",
+		"url":            "https://example.com/articles/by-slug/fish-chips",
+		"title":          "Fish & Chips",
+		"content_html":   "<p>Hot <b>&amp;</b> salty</p>",
+		"image":          "https://example.com/media/3/variants/640",
+		"date_published": "2024-03-01T08:30:00Z",
+		"date_modified":  "2024-03-01T09:30:00Z",
+		"authors":        []interface{}{map[string]interface{}{"name": "ada"}},
+		"tags":           []interface{}{"Food"},
+	}, items[0])
+
+	// An empty feed still has a list of items
+	out, err = JSON(&Feed{Title: "Empty"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"items": []`)
+}
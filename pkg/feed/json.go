@@ -0,0 +1,81 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+type jsonFeed struct {
+	Version     string     `json:"version"`
+	Title       string     `json:"title"`
+	HomePageURL string     `json:"home_page_url"`
+	FeedURL     string     `json:"feed_url"`
+	Description string     `json:"description,omitempty"`
+	Items       []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID            string       `json:"id"`
+	URL           string       `json:"url"`
+	Title         string       `json:"title"`
+	ContentHTML   string       `json:"content_html"`
+	Image         string       `json:"image,omitempty"`
+	DatePublished *time.Time   `json:"date_published,omitempty"`
+	DateModified  *time.Time   `json:"date_modified,omitempty"`
+	Authors       []jsonAuthor `json:"authors,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+// JSON writes the feed as JSON Feed 1.1.
+// See https://www.jsonfeed.org/version/1.1/
+func JSON(f *Feed) ([]byte, error) {
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.Title,
+		HomePageURL: f.Link,
+		FeedURL:     f.FeedURL,
+		Description: f.Description,
+		Items:       []jsonItem{},
+	}
+	for _, item := range f.Items {
+		entry := jsonItem{
+			ID:            item.ID,
+			URL:           item.Link,
+			Title:         item.Title,
+			ContentHTML:   item.ContentHTML,
+			DatePublished: jsonTime(item.Published),
+			DateModified:  jsonTime(item.Updated),
+			Tags:          item.Tags,
+		}
+		if item.Image != nil {
+			entry.Image = item.Image.URL
+		}
+		if item.Author != "" {
+			entry.Authors = []jsonAuthor{{Name: item.Author}}
+		}
+		feed.Items = append(feed.Items, entry)
+	}
+
+	// The content is HTML, which reads better left unescaped
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func jsonTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,92 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	DC      string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Self          atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Categories  []string      `xml:"category"`
+	Description string        `xml:"description"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// RSS writes the feed as RSS 2.0. The author of an item goes in a Dublin
+// Core creator, since RSS only has room for an email address.
+func RSS(f *Feed) ([]byte, error) {
+	channel := rssChannel{
+		Title:       f.Title,
+		Link:        f.Link,
+		Description: f.Description,
+		Self:        atomLink{Href: f.FeedURL, Rel: "self", Type: "application/rss+xml"},
+	}
+	if !f.Updated.IsZero() {
+		channel.LastBuildDate = f.Updated.UTC().Format(time.RFC1123Z)
+	}
+	for _, item := range f.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{Value: item.ID},
+			Creator:     item.Author,
+			Categories:  item.Tags,
+			Description: item.ContentHTML,
+		}
+		if !item.Published.IsZero() {
+			entry.PubDate = item.Published.UTC().Format(time.RFC1123Z)
+		}
+		if item.Image != nil && item.Image.Size > 0 {
+			entry.Enclosure = &rssEnclosure{URL: item.Image.URL, Length: item.Image.Size, Type: item.Image.ContentType}
+		}
+		channel.Items = append(channel.Items, entry)
+	}
+
+	return marshalXML(rss{
+		Version: "2.0",
+		Atom:    atomNamespace,
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: channel,
+	})
+}
+
+// marshalXML returns v as an indented XML document.
+func marshalXML(v interface{}) ([]byte, error) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
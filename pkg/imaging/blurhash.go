@@ -0,0 +1,124 @@
+package imaging
+
+import (
+	"image"
+	"math"
+	"strings"
+)
+
+// Components of the placeholder hash, across and down. Four by three suits
+// the landscape images covers mostly are.
+const (
+	blurhashX = 4
+	blurhashY = 3
+)
+
+// blurhashWidth is the width images are scaled down to before their hash is
+// taken; the hash keeps far less detail than that
+const blurhashWidth = 32
+
+const base83 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// Blurhash returns the BlurHash of img, a short string clients decode into
+// a blurred version of the image to show while it loads.
+// See https://blurha.sh
+func Blurhash(img image.Image) string {
+	small := img
+	if img.Bounds().Dx() > blurhashWidth {
+		small = Resize(img, blurhashWidth)
+	}
+	bounds := small.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+
+	// The linear color of every pixel
+	pixels := make([][3]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, b, _ := small.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			pixels[y*w+x] = [3]float64{srgbToLinear(r >> 8), srgbToLinear(g >> 8), srgbToLinear(b >> 8)}
+		}
+	}
+
+	factors := make([][3]float64, 0, blurhashX*blurhashY)
+	for j := 0; j < blurhashY; j++ {
+		for i := 0; i < blurhashX; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1
+			}
+			var factor [3]float64
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					basis := normalisation *
+						math.Cos(math.Pi*float64(i)*float64(x)/float64(w)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(h))
+					for c := range factor {
+						factor[c] += basis * pixels[y*w+x][c]
+					}
+				}
+			}
+			for c := range factor {
+				factor[c] /= float64(w * h)
+			}
+			factors = append(factors, factor)
+		}
+	}
+
+	var hash strings.Builder
+	hash.WriteString(encode83((blurhashX-1)+(blurhashY-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maxValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, factor := range ac {
+			for _, value := range factor {
+				actualMax = math.Max(actualMax, math.Abs(value))
+			}
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maxValue = float64(quantisedMax+1) / 166
+		hash.WriteString(encode83(quantisedMax, 1))
+	} else {
+		hash.WriteString(encode83(0, 1))
+	}
+
+	hash.WriteString(encode83(linearToSRGB(dc[0])<<16+linearToSRGB(dc[1])<<8+linearToSRGB(dc[2]), 4))
+	for _, factor := range ac {
+		quantised := 0
+		for _, value := range factor {
+			quantised = quantised*19 + int(math.Max(0, math.Min(18, math.Floor(signPow(value/maxValue, 0.5)*9+9.5))))
+		}
+		hash.WriteString(encode83(quantised, 2))
+	}
+	return hash.String()
+}
+
+func encode83(value, length int) string {
+	digits := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		digits[i] = base83[value%83]
+		value /= 83
+	}
+	return string(digits)
+}
+
+func srgbToLinear(value uint32) float64 {
+	v := float64(value) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(value float64) int {
+	v := math.Max(0, math.Min(1, value))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(value, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(value), exp), value)
+}
@@ -0,0 +1,67 @@
+// Package imaging makes the resized variants of uploaded images and their
+// placeholders. Images are decoded and encoded again, so nothing but their
+// pixels carries over: EXIF data, GPS positions included, is left behind.
+// The uploaded images themselves are stored without their metadata too.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// jpegQuality is the quality variants are encoded with as JPEG
+const jpegQuality = 82
+
+// maxPixels is the most pixels an image may have to be decoded, so a small
+// file cannot claim gigabytes of memory
+const maxPixels = 50_000_000
+
+// ErrTooManyPixels is returned for images larger than maxPixels.
+var ErrTooManyPixels = errors.New("image has too many pixels")
+
+// Decode decodes a JPEG, PNG, GIF or WebP image, turned upright as its EXIF
+// orientation says. Animated GIFs are decoded to their first frame.
+func Decode(data []byte) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return orient(img, exifOrientation(data)), nil
+}
+
+// Resize returns img scaled to width, keeping its aspect ratio.
+func Resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	height := (bounds.Dy()*width + bounds.Dx()/2) / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// Encode writes img as a JPEG, or as a PNG when it has transparent pixels,
+// and returns the content type it was written in.
+func Encode(w io.Writer, img image.Image) (string, error) {
+	if opaque, ok := img.(interface{ Opaque() bool }); ok && !opaque.Opaque() {
+		return "image/png", png.Encode(w, img)
+	}
+	return "image/jpeg", jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+}
@@ -0,0 +1,253 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withOrientation inserts an EXIF segment with an orientation after the
+// start of a JPEG.
+func withOrientation(data []byte, orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08\x00\x01")
+	entry := make([]byte, 12)
+	binary.BigEndian.PutUint16(entry, exifOrientationTag)
+	binary.BigEndian.PutUint16(entry[2:], 3)
+	binary.BigEndian.PutUint32(entry[4:], 1)
+	binary.BigEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	return append(result, data[2:]...)
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil))
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	data := encodeJPEG(t, 4, 2)
+
+	img, err := Decode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, image.Pt(4, 2), img.Bounds().Size())
+
+	// Turned a quarter, the sides are swapped
+	for orientation, size := range map[uint16]image.Point{1: {4, 2}, 3: {4, 2}, 6: {2, 4}, 8: {2, 4}, 9: {4, 2}} {
+		img, err := Decode(withOrientation(data, orientation))
+		assert.NoError(t, err)
+		assert.Equal(t, size, img.Bounds().Size(), orientation)
+	}
+
+	_, err = Decode([]byte("not an image"))
+	assert.Error(t, err)
+}
+
+func TestDecodeTooManyPixels(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+
+	// Claim to be 10000x10000 in the header chunk, with its checksum fixed
+	ihdr := data[8+8 : 8+8+13]
+	binary.BigEndian.PutUint32(ihdr, 10000)
+	binary.BigEndian.PutUint32(ihdr[4:], 10000)
+	binary.BigEndian.PutUint32(data[8+8+13:], crc32.ChecksumIEEE(data[8+4:8+8+13]))
+
+	_, err := Decode(data)
+	assert.ErrorIs(t, err, ErrTooManyPixels)
+}
+
+func TestOrient(t *testing.T) {
+	// A 2x1 image with a red pixel on the left
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 1))
+	red := color.NRGBA{R: 255, A: 255}
+	img.Set(0, 0, red)
+
+	testCases := map[int]image.Point{
+		2: {1, 0},
+		3: {1, 0},
+		4: {0, 0},
+		5: {0, 0},
+		6: {0, 0},
+		7: {0, 1},
+		8: {0, 1},
+	}
+	for orientation, redAt := range testCases {
+		oriented := orient(img, orientation)
+		assert.Equal(t, red, oriented.At(redAt.X, redAt.Y), orientation)
+	}
+}
+
+func TestResize(t *testing.T) {
+	img := Resize(image.NewGray(image.Rect(0, 0, 400, 300)), 160)
+	assert.Equal(t, image.Pt(160, 120), img.Bounds().Size())
+
+	img = Resize(image.NewGray(image.Rect(0, 0, 1000, 1)), 10)
+	assert.Equal(t, image.Pt(10, 1), img.Bounds().Size())
+}
+
+func TestEncode(t *testing.T) {
+	var buf bytes.Buffer
+	contentType, err := Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2)))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/jpeg", contentType)
+	_, format, _ := image.DecodeConfig(&buf)
+	assert.Equal(t, "jpeg", format)
+
+	// Transparency is kept
+	buf.Reset()
+	contentType, err = Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 2, 2)))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+	_, format, _ = image.DecodeConfig(&buf)
+	assert.Equal(t, "png", format)
+}
+
+func TestEncodeStripsMetadata(t *testing.T) {
+	data := withOrientation(encodeJPEG(t, 4, 2), 6)
+	img, err := Decode(data)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, err = Encode(&buf, img)
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), "Exif")
+	assert.Equal(t, 1, exifOrientation(buf.Bytes()))
+}
+
+func TestBlurhash(t *testing.T) {
+	testCases := []struct {
+		name    string
+		color   color.NRGBA
+		average string
+	}{
+		{name: "White", color: color.NRGBA{R: 255, G: 255, B: 255, A: 255}, average: "TSUA"},
+		{name: "Black", color: color.NRGBA{A: 255}, average: "0000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			img := image.NewNRGBA(image.Rect(0, 0, 64, 48))
+			for y := 0; y < 48; y++ {
+				for x := 0; x < 64; x++ {
+					img.Set(x, y, tc.color)
+				}
+			}
+
+			// 4x3 components: the size flag, the maximum, the average color
+			// and two characters for each of the other 11 components
+			hash := Blurhash(img)
+			assert.Len(t, hash, 1+1+4+2*11)
+			assert.Equal(t, "L", hash[:1])
+			assert.Equal(t, tc.average, hash[2:6])
+		})
+	}
+}
+
+// withSegment inserts a segment after the start of a JPEG.
+func withSegment(data []byte, marker byte, payload string) []byte {
+	segment := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+// pngChunk returns a PNG chunk of a type.
+func pngChunk(kind, payload string) []byte {
+	chunk := make([]byte, 4, 12+len(payload))
+	binary.BigEndian.PutUint32(chunk, uint32(len(payload)))
+	chunk = append(chunk, kind...)
+	chunk = append(chunk, payload...)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+	return append(chunk, crc...)
+}
+
+func TestStripMetadata(t *testing.T) {
+	t.Run("JPEG", func(t *testing.T) {
+		data := encodeJPEG(t, 4, 2)
+		stripped, err := StripMetadata(data, "image/jpeg")
+		assert.NoError(t, err)
+		assert.Equal(t, data, stripped)
+
+		tagged := withSegment(withOrientation(data, 6), 0xE1, "http://ns.adobe.com/xap/1.0/\x00<exif:GPSLatitude>51,30N</exif:GPSLatitude>")
+		tagged = withSegment(tagged, 0xFE, "taken at home")
+		stripped, err = StripMetadata(tagged, "image/jpeg")
+		assert.NoError(t, err)
+		assert.NotContains(t, string(stripped), "GPSLatitude")
+		assert.NotContains(t, string(stripped), "taken at home")
+
+		// The orientation is kept, so the image still shows upright
+		assert.Equal(t, 6, exifOrientation(stripped))
+		img, err := Decode(stripped)
+		assert.NoError(t, err)
+		assert.Equal(t, image.Pt(2, 4), img.Bounds().Size())
+	})
+
+	t.Run("PNG", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 2))))
+		data := buf.Bytes()
+		// The header chunk is 25 bytes long and comes after the signature
+		tagged := append(append(append([]byte{}, data[:33]...), pngChunk("tEXt", "GPS\x0051,30N")...), data[33:]...)
+
+		stripped, err := StripMetadata(tagged, "image/png")
+		assert.NoError(t, err)
+		assert.Equal(t, data, stripped)
+	})
+
+	t.Run("GIF", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, gif.Encode(&buf, image.NewGray(image.Rect(0, 0, 4, 2)), nil))
+		data := buf.Bytes()
+		comment := []byte("!\xfe\x0dtaken at home\x00")
+		tagged := append(append(append([]byte{}, data[:len(data)-1]...), comment...), ';')
+
+		stripped, err := StripMetadata(tagged, "image/gif")
+		assert.NoError(t, err)
+		assert.Equal(t, data, stripped)
+	})
+
+	t.Run("WebP", func(t *testing.T) {
+		vp8x := []byte("VP8X\x0a\x00\x00\x00\x08\x00\x00\x00\x03\x00\x00\x01\x00\x00")
+		exif := []byte("EXIF\x05\x00\x00\x00GPS!!\x00")
+		tagged := append(append([]byte("RIFF\x00\x00\x00\x00WEBP"), vp8x...), exif...)
+
+		stripped, err := StripMetadata(tagged, "image/webp")
+		assert.NoError(t, err)
+		assert.NotContains(t, string(stripped), "GPS")
+		assert.Equal(t, byte(0), stripped[20], "flags")
+		assert.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:]))
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := StripMetadata([]byte("\xff\xd8\xff\xe1\xff\xffGPS"), "image/jpeg")
+		assert.ErrorIs(t, err, ErrMalformed)
+	})
+
+	t.Run("Not An Image", func(t *testing.T) {
+		stripped, err := StripMetadata([]byte("%PDF-1.7"), "application/pdf")
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("%PDF-1.7"), stripped)
+	})
+}
@@ -0,0 +1,254 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// ErrMalformed is returned for images whose structure cannot be read, so
+// their metadata cannot be told apart from their pixels.
+var ErrMalformed = errors.New("image is malformed")
+
+// StripMetadata returns an image of a content type without the metadata
+// it was uploaded with, EXIF data and GPS positions included, leaving its
+// pixels untouched. A JPEG keeps its orientation, so it still shows
+// upright; color profiles are kept too. Other content types are returned
+// as they are.
+func StripMetadata(data []byte, contentType string) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/gif":
+		return stripGIF(data)
+	case "image/webp":
+		return stripWebP(data)
+	}
+	return data, nil
+}
+
+// stripJPEG drops the application segments but JFIF, ICC profiles and
+// Adobe color transforms, and comments, from the segments before the image
+// data. An EXIF segment with nothing but the orientation takes their place.
+func stripJPEG(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrMalformed
+	}
+	orientation := exifOrientation(data)
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	oriented := orientation == 1
+
+	i := 2
+	for {
+		if i+4 > len(data) || data[i] != 0xFF {
+			return nil, ErrMalformed
+		}
+		marker := data[i+1]
+		// Markers may be preceded by fill bytes
+		if marker == 0xFF {
+			i++
+			continue
+		}
+		// The orientation goes after the JFIF segment, which comes first
+		if !oriented && marker != 0xE0 {
+			out = append(out, orientationSegment(orientation)...)
+			oriented = true
+		}
+		// Metadata comes before the image data starts
+		if marker == 0xDA {
+			return append(out, data[i:]...), nil
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return nil, ErrMalformed
+		}
+		if !jpegMetadata(marker) {
+			out = append(out, data[i:i+2+length]...)
+		}
+		i += 2 + length
+	}
+}
+
+// jpegMetadata reports whether a JPEG segment only describes the image.
+func jpegMetadata(marker byte) bool {
+	switch marker {
+	case 0xE0, 0xE2, 0xEE:
+		// JFIF, ICC profile and Adobe color transform
+		return false
+	case 0xFE:
+		return true
+	}
+	return marker >= 0xE1 && marker <= 0xEF
+}
+
+// orientationSegment returns an EXIF segment holding an orientation alone.
+func orientationSegment(orientation int) []byte {
+	var tiff bytes.Buffer
+	tiff.WriteString("MM\x00\x2A")
+	binary.Write(&tiff, binary.BigEndian, uint32(8))
+	// One directory entry of type SHORT and no next directory
+	binary.Write(&tiff, binary.BigEndian, []uint16{1, exifOrientationTag, 3})
+	binary.Write(&tiff, binary.BigEndian, uint32(1))
+	binary.Write(&tiff, binary.BigEndian, []uint16{uint16(orientation), 0})
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	segment = append(segment, "Exif\x00\x00"...)
+	segment = append(segment, tiff.Bytes()...)
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(segment)-2))
+	return segment
+}
+
+// pngMetadata lists the PNG chunks that only describe the image
+var pngMetadata = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// stripPNG drops the EXIF, text and time chunks of a PNG.
+func stripPNG(data []byte) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if len(data) < len(signature) || string(data[:len(signature)]) != signature {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, signature...)
+
+	for i := len(signature); i < len(data); {
+		if i+12 > len(data) {
+			return nil, ErrMalformed
+		}
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length
+		if end > len(data) {
+			return nil, ErrMalformed
+		}
+		if !pngMetadata[string(data[i+4:i+8])] {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	return out, nil
+}
+
+// stripWebP drops the EXIF and XMP chunks of a WebP and clears the flags
+// announcing them.
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, ErrMalformed
+		}
+		fourCC := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + size + size%2
+		if end > len(data) {
+			return nil, ErrMalformed
+		}
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(out)
+			out = append(out, data[i:end]...)
+			if size > 0 {
+				// The flags of the XMP and EXIF chunks
+				out[start+8] &^= 0x04 | 0x08
+			}
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
+
+// stripGIF drops the comments of a GIF and the application extensions but
+// the ones that make it loop, which is where XMP data is kept.
+func stripGIF(data []byte) ([]byte, error) {
+	if len(data) < 13 || (string(data[:6]) != "GIF87a" && string(data[:6]) != "GIF89a") {
+		return nil, ErrMalformed
+	}
+	start := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		start += 3 << (flags&0x07 + 1)
+	}
+	if start > len(data) {
+		return nil, ErrMalformed
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:start]...)
+
+	for i := start; i < len(data); {
+		switch data[i] {
+		case 0x3B:
+			// Nothing after the trailer belongs to the image
+			return append(out, data[i]), nil
+		case 0x21:
+			if i+2 > len(data) {
+				return nil, ErrMalformed
+			}
+			end, err := gifSubBlocks(data, i+2)
+			if err != nil {
+				return nil, err
+			}
+			if !gifMetadata(data[i+1], data[i+2:end]) {
+				out = append(out, data[i:end]...)
+			}
+			i = end
+		case 0x2C:
+			blocks := i + 10
+			if blocks > len(data) {
+				return nil, ErrMalformed
+			}
+			if flags := data[i+9]; flags&0x80 != 0 {
+				blocks += 3 << (flags&0x07 + 1)
+			}
+			// The LZW code size comes before the image data
+			end, err := gifSubBlocks(data, blocks+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, data[i:end]...)
+			i = end
+		default:
+			return nil, ErrMalformed
+		}
+	}
+	return nil, ErrMalformed
+}
+
+// gifSubBlocks returns where the sub-blocks starting at i end, after the
+// empty block ending them.
+func gifSubBlocks(data []byte, i int) (int, error) {
+	for i < len(data) {
+		size := int(data[i])
+		i++
+		if size == 0 {
+			return i, nil
+		}
+		i += size
+	}
+	return 0, ErrMalformed
+}
+
+// gifMetadata reports whether a GIF extension with a label and sub-blocks
+// only describes the image.
+func gifMetadata(label byte, blocks []byte) bool {
+	switch label {
+	case 0xFE:
+		return true
+	case 0xFF:
+		if len(blocks) < 12 {
+			return true
+		}
+		identifier := string(blocks[1:12])
+		return identifier != "NETSCAPE2.0" && identifier != "ANIMEXTS1.0"
+	}
+	return false
+}
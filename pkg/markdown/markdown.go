@@ -0,0 +1,163 @@
+// Package markdown renders the Markdown content of articles to HTML. Content
+// is CommonMark with the GitHub extensions: tables, strikethrough, task lists
+// and autolinks. Headings get an id and an anchor linking to it, fenced code
+// is highlighted with CSS classes, and the HTML is passed through an
+// allowlist sanitizer so nothing an author writes can run in a reader's
+// browser.
+package markdown
+
+import (
+	"backend/pkg/utility"
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Raw HTML in the content is let through the renderer and left to the
+// sanitizer, which keeps what it allows instead of dropping all of it.
+var converter = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+		parser.WithASTTransformers(util.Prioritized(headingAnchors{}, 100)),
+	),
+	goldmark.WithRendererOptions(
+		html.WithUnsafe(),
+		renderer.WithNodeRenderers(util.Prioritized(codeRenderer{}, 100)),
+	),
+)
+
+var formatter = chromahtml.New(chromahtml.WithClasses(true))
+
+// policy is the sanitizer allowlist: the elements and attributes of user
+// generated content, plus what the renderer itself produces.
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^[\w -]+$`)).OnElements("a", "pre", "code", "span")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	return p
+}
+
+// Render returns the sanitized HTML of the Markdown source.
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	ctx := parser.NewContext(parser.WithIDs(headingIDs{}))
+	if err := converter.Convert([]byte(source), &buf, parser.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	return policy.Sanitize(buf.String()), nil
+}
+
+// headingIDs makes the ids of headings from their text the way article
+// slugs are made, numbering the ones already used in the document.
+type headingIDs map[string]bool
+
+func (ids headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := utility.Slugify(utility.Transliterate(string(value)))
+	if base == "" {
+		base = "heading"
+	}
+	id := base
+	for n := 1; ids[id]; n++ {
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+	ids[id] = true
+	return []byte(id)
+}
+
+func (ids headingIDs) Put(value []byte) {
+	ids[string(value)] = true
+}
+
+// headingAnchors ends every heading with a link to itself, so readers can
+// share a link to a section.
+type headingAnchors struct{}
+
+func (headingAnchors) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		id, ok := heading.AttributeString("id")
+		if !ok {
+			return ast.WalkSkipChildren, nil
+		}
+		anchor := ast.NewLink()
+		anchor.Destination = append([]byte("#"), id.([]byte)...)
+		anchor.SetAttributeString("class", []byte("anchor"))
+		anchor.AppendChild(anchor, ast.NewString([]byte("#")))
+		heading.AppendChild(heading, ast.NewString([]byte(" ")))
+		heading.AppendChild(heading, anchor)
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// codeRenderer highlights fenced code in a language chroma knows, marking
+// the tokens with classes for a stylesheet to color. Code in other
+// languages is rendered as plain text.
+type codeRenderer struct{}
+
+func (codeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, renderFencedCode)
+}
+
+func renderFencedCode(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.FencedCodeBlock)
+
+	var code bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code.Write(line.Value(source))
+	}
+
+	language := string(n.Language(source))
+	var lexer chroma.Lexer
+	if language != "" {
+		lexer = lexers.Get(language)
+	}
+	if lexer == nil {
+		_, _ = w.WriteString("<pre><code")
+		if language != "" {
+			_, _ = w.WriteString(` class="language-`)
+			_, _ = w.Write(util.EscapeHTML([]byte(language)))
+			_ = w.WriteByte('"')
+		}
+		_ = w.WriteByte('>')
+		_, _ = w.Write(util.EscapeHTML(code.Bytes()))
+		_, _ = w.WriteString("</code></pre>\n")
+		return ast.WalkSkipChildren, nil
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code.String())
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	if err := formatter.Format(w, styles.Fallback, iterator); err != nil {
+		return ast.WalkStop, err
+	}
+	_ = w.WriteByte('\n')
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,77 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		source   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "CommonMark",
+			source:   "Some *emphasis* and `code`",
+			contains: []string{"<p>Some <em>emphasis</em> and <code>code</code></p>"},
+		},
+		{
+			name:     "GitHub Extensions",
+			source:   "| a |\n|---|\n| 1 |\n\n~~gone~~ https://example.com\n\n- [x] done",
+			contains: []string{"<td>1</td>", "<del>gone</del>", `<a href="https://example.com" rel="nofollow">`, `<input checked="" disabled="" type="checkbox">`},
+		},
+		{
+			name:   "Heading Anchors",
+			source: "# Getting started\n\n## Привет\n\n## Getting started",
+			contains: []string{
+				`<h1 id="getting-started">Getting started <a href="#getting-started" class="anchor" rel="nofollow">#</a></h1>`,
+				`<h2 id="privet">`,
+				`<h2 id="getting-started-1">`,
+			},
+		},
+		{
+			name:     "Highlighted Code",
+			source:   "```go\nfunc main() {}\n```",
+			contains: []string{`<pre class="chroma"><code>`, `<span class="kd">func</span>`},
+		},
+		{
+			name:     "Code In An Unknown Language",
+			source:   "```nosuchlang\n<b>\n```",
+			contains: []string{`<pre><code class="language-nosuchlang">&lt;b&gt;`},
+		},
+		{
+			name:     "Scripts Are Removed",
+			source:   "Hi <script>alert(1)</script><b onclick=\"alert(1)\">there</b> [link](javascript:alert(1)) <img src=x onerror=alert(1)>",
+			contains: []string{"<b>there</b>"},
+			excludes: []string{"<script", "alert", "onclick", "onerror", "javascript"},
+		},
+		{
+			name:     "Styles Are Removed",
+			source:   `<p style="position:fixed" class="x">text</p><iframe src="https://example.com"></iframe>`,
+			contains: []string{"<p>text</p>"},
+			excludes: []string{"style", "iframe"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			html, err := Render(tc.source)
+			assert.NoError(t, err)
+			for _, s := range tc.contains {
+				assert.Contains(t, html, s)
+			}
+			for _, s := range tc.excludes {
+				assert.NotContains(t, html, s)
+			}
+		})
+	}
+}
+
+func TestRender_Empty(t *testing.T) {
+	html, err := Render("")
+	assert.NoError(t, err)
+	assert.Equal(t, "", html)
+}
@@ -0,0 +1,96 @@
+// Package migration applies the versioned SQL schema migrations that are
+// compiled into the binary.
+package migration
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+// Migration is one schema version with its up and down SQL.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// fileName matches files such as 0002_add_timestamps.up.sql
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Embedded returns the migrations compiled into the binary.
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(embedded, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return Load(sub)
+}
+
+// Load reads the migrations in the root of fsys ordered by version. Every
+// version must provide both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		parts := fileName.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return nil, fmt.Errorf("migration: unexpected file name %q", entry.Name())
+		}
+
+		version, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("migration: invalid version in %q: %w", entry.Name(), err)
+		}
+
+		body, err := fs.ReadFile(fsys, path.Clean(entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		}
+		if m.Name != parts[2] {
+			return nil, fmt.Errorf("migration: version %d has conflicting names %q and %q", version, m.Name, parts[2])
+		}
+
+		if parts[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration: version %d (%s) needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmbedded(t *testing.T) {
+	migrations, err := Embedded()
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_articles", migrations[0].Name)
+	assert.Contains(t, migrations[0].Up, "CREATE TABLE IF NOT EXISTS articles")
+
+	for i := 1; i < len(migrations); i++ {
+		assert.Less(t, migrations[i-1].Version, migrations[i].Version, "migrations must be ordered by version")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         fstest.MapFS
+		expectedNames []string
+		expectErr     bool
+	}{
+		{
+			name: "Ordered by version",
+			files: fstest.MapFS{
+				"0010_second.up.sql":   {Data: []byte("SELECT 2")},
+				"0010_second.down.sql": {Data: []byte("SELECT -2")},
+				"0002_first.up.sql":    {Data: []byte("SELECT 1")},
+				"0002_first.down.sql":  {Data: []byte("SELECT -1")},
+			},
+			expectedNames: []string{"first", "second"},
+		},
+		{
+			name: "Missing down file",
+			files: fstest.MapFS{
+				"0001_only_up.up.sql": {Data: []byte("SELECT 1")},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Conflicting names for a version",
+			files: fstest.MapFS{
+				"0001_one.up.sql":     {Data: []byte("SELECT 1")},
+				"0001_other.down.sql": {Data: []byte("SELECT -1")},
+			},
+			expectErr: true,
+		},
+		{
+			name: "Unexpected file name",
+			files: fstest.MapFS{
+				"create_articles.sql": {Data: []byte("SELECT 1")},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			migrations, err := Load(test.files)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			var names []string
+			for _, migration := range migrations {
+				names = append(names, migration.Name)
+			}
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package migration
+
+import (
+	appconst "backend/pkg/appconstant"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// lockID is the key of the Postgres advisory lock that serialises migration
+// runs, so replicas starting at the same time do not race each other.
+const lockID int64 = 7_466_132_010
+
+// Migrator applies migrations to a database and records them in the
+// schema_migrations table.
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+	// DryRun reports what would be applied without changing the database.
+	DryRun bool
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// New returns a Migrator for the embedded migrations.
+func New(db *sql.DB) (*Migrator, error) {
+	migrations, err := Embedded()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order and returns the ones
+// that were applied, or that would be in dry-run mode.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.Migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			if !m.DryRun {
+				err := inTx(ctx, conn, migration.Up,
+					`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+				if err != nil {
+					return fmt.Errorf("migration %d (%s) up: %w", migration.Version, migration.Name, err)
+				}
+			}
+
+			log.Println(logMessage(m.DryRun, appconst.MigrationApplied), migration.Version, migration.Name)
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down reverts the most recently applied migrations, at most steps of them,
+// and returns the ones that were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.Migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.Migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			if !m.DryRun {
+				err := inTx(ctx, conn, migration.Down,
+					`DELETE FROM schema_migrations WHERE version = $1`, migration.Version)
+				if err != nil {
+					return fmt.Errorf("migration %d (%s) down: %w", migration.Version, migration.Name, err)
+				}
+			}
+
+			log.Println(logMessage(m.DryRun, appconst.MigrationReverted), migration.Version, migration.Name)
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.Migrations {
+			appliedAt, ok := done[migration.Version]
+			statuses = append(statuses, Status{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   ok,
+				AppliedAt: appliedAt,
+			})
+		}
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			log.Println(appconst.Queryerror, err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version BIGINT PRIMARY KEY,
+            name TEXT NOT NULL,
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+        )`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// appliedVersions returns the applied migration versions with their apply time.
+func appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]time.Time, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = appliedAt
+	}
+
+	return done, rows.Err()
+}
+
+// inTx runs the migration SQL and the bookkeeping statement in one transaction.
+func inTx(ctx context.Context, conn *sql.Conn, migrationSQL, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, migrationSQL); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func logMessage(dryRun bool, message string) string {
+	if dryRun {
+		return appconst.MigrationDryRun + message
+	}
+	return message
+}
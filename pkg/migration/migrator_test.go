@@ -0,0 +1,119 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_articles", Up: "CREATE TABLE articles", Down: "DROP TABLE articles"},
+	{Version: 2, Name: "add_timestamps", Up: "ALTER TABLE articles ADD", Down: "ALTER TABLE articles DROP"},
+}
+
+// expectLock sets up the statements run around every migration command.
+func expectLock(mock sqlmock.Sqlmock, applied *sqlmock.Rows) {
+	mock.ExpectExec("SELECT pg_advisory_lock").WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(applied)
+}
+
+func TestMigratorUp(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	applied := sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, time.Now())
+	expectLock(mock, applied)
+	mock.ExpectBegin()
+	mock.ExpectExec("ALTER TABLE articles ADD").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO schema_migrations").WithArgs(2, "add_timestamps").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := &Migrator{DB: db, Migrations: testMigrations}
+	done, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, done, 1)
+	assert.Equal(t, 2, done[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorUpFailureRollsBack(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	expectLock(mock, sqlmock.NewRows([]string{"version", "applied_at"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE articles").WillReturnError(errors.New("syntax error"))
+	mock.ExpectRollback()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := &Migrator{DB: db, Migrations: testMigrations}
+	done, err := migrator.Up(context.Background())
+
+	assert.ErrorContains(t, err, "migration 1 (create_articles) up: syntax error")
+	assert.Empty(t, done)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorUpDryRun(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	expectLock(mock, sqlmock.NewRows([]string{"version", "applied_at"}))
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := &Migrator{DB: db, Migrations: testMigrations, DryRun: true}
+	done, err := migrator.Up(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, done, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorDown(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	applied := sqlmock.NewRows([]string{"version", "applied_at"}).
+		AddRow(1, time.Now()).
+		AddRow(2, time.Now())
+	expectLock(mock, applied)
+	mock.ExpectBegin()
+	mock.ExpectExec("ALTER TABLE articles DROP").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := &Migrator{DB: db, Migrations: testMigrations}
+	done, err := migrator.Down(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Len(t, done, 1)
+	assert.Equal(t, 2, done[0].Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigratorStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	appliedAt := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	expectLock(mock, sqlmock.NewRows([]string{"version", "applied_at"}).AddRow(1, appliedAt))
+	mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(lockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := &Migrator{DB: db, Migrations: testMigrations}
+	statuses, err := migrator.Status(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Status{
+		{Version: 1, Name: "create_articles", Applied: true, AppliedAt: appliedAt},
+		{Version: 2, Name: "add_timestamps"},
+	}, statuses)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
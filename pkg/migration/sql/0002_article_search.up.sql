@@ -0,0 +1,8 @@
+ALTER TABLE articles
+    ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(content, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(author, '')), 'C')
+    ) STORED;
+
+CREATE INDEX articles_search_vector_idx ON articles USING GIN (search_vector);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS article_tags;
+
+DROP INDEX IF EXISTS articles_updated_at_id_idx;
+DROP INDEX IF EXISTS articles_created_at_id_idx;
+DROP INDEX IF EXISTS articles_author_id_idx;
+DROP INDEX IF EXISTS articles_title_id_idx;
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at;
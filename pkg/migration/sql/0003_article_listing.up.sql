@@ -0,0 +1,18 @@
+ALTER TABLE articles
+    ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'published';
+
+-- Every sortable column is indexed together with id for keyset pagination
+CREATE INDEX articles_title_id_idx ON articles (title, id);
+CREATE INDEX articles_author_id_idx ON articles (author, id);
+CREATE INDEX articles_created_at_id_idx ON articles (created_at, id);
+CREATE INDEX articles_updated_at_id_idx ON articles (updated_at, id);
+
+CREATE TABLE article_tags (
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (article_id, tag)
+);
+
+CREATE INDEX article_tags_tag_idx ON article_tags (tag);
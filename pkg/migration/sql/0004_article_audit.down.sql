@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS articles_set_updated_at ON articles;
+DROP FUNCTION IF EXISTS set_updated_at();
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS updated_by,
+    DROP COLUMN IF EXISTS created_by,
+    DROP COLUMN IF EXISTS published_at;
@@ -0,0 +1,21 @@
+ALTER TABLE articles
+    ADD COLUMN published_at TIMESTAMPTZ,
+    ADD COLUMN created_by TEXT NOT NULL DEFAULT '',
+    ADD COLUMN updated_by TEXT NOT NULL DEFAULT '';
+
+-- Articles have been public since they were created
+UPDATE articles SET published_at = created_at WHERE status = 'published';
+
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- updated_at only moves when a row actually changes
+CREATE TRIGGER articles_set_updated_at
+    BEFORE UPDATE ON articles
+    FOR EACH ROW
+    WHEN (OLD.* IS DISTINCT FROM NEW.*)
+    EXECUTE FUNCTION set_updated_at();
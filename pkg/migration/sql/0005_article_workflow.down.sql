@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS articles_status_idx;
+
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_status_check;
+
+ALTER TABLE articles ALTER COLUMN status SET DEFAULT 'published';
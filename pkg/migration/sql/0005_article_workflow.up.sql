@@ -0,0 +1,8 @@
+-- New articles start as drafts and have to be reviewed before publishing
+ALTER TABLE articles ALTER COLUMN status SET DEFAULT 'draft';
+
+ALTER TABLE articles
+    ADD CONSTRAINT articles_status_check
+    CHECK (status IN ('draft', 'in_review', 'published', 'archived'));
+
+CREATE INDEX articles_status_idx ON articles (status);
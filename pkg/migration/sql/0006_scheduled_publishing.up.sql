@@ -0,0 +1,5 @@
+ALTER TABLE articles ADD COLUMN publish_at TIMESTAMPTZ;
+
+-- The scheduler looks for reviewed articles that are due
+CREATE INDEX articles_publish_at_idx ON articles (publish_at)
+    WHERE status = 'in_review' AND publish_at IS NOT NULL;
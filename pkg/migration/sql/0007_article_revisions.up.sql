@@ -0,0 +1,21 @@
+-- revision counts the changes made to an article; every change is kept in
+-- article_revisions
+ALTER TABLE articles ADD COLUMN revision INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE article_revisions (
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    revision INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    author TEXT NOT NULL,
+    status TEXT NOT NULL,
+    publish_at TIMESTAMPTZ,
+    created_by TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (article_id, revision)
+);
+
+-- The history of existing articles starts with their current content
+INSERT INTO article_revisions (article_id, revision, title, content, author, status, publish_at, created_by, created_at)
+SELECT id, revision, title, content, author, status, publish_at, updated_by, updated_at
+FROM articles;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS articles_author_fk_idx;
+
+ALTER TABLE articles DROP COLUMN IF EXISTS author_id;
+
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,43 @@
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    username TEXT NOT NULL,
+    email TEXT,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Usernames and emails are unique regardless of case
+CREATE UNIQUE INDEX users_username_idx ON users (lower(username));
+CREATE UNIQUE INDEX users_email_idx ON users (lower(email));
+
+CREATE TRIGGER users_set_updated_at
+    BEFORE UPDATE ON users
+    FOR EACH ROW
+    WHEN (OLD.* IS DISTINCT FROM NEW.*)
+    EXECUTE FUNCTION set_updated_at();
+
+-- The authors of existing articles become users. They have no email and no
+-- usable password hash, so they cannot sign in until one is set.
+INSERT INTO users (username, password_hash)
+SELECT DISTINCT ON (lower(author)) author, ''
+FROM articles
+ORDER BY lower(author), author;
+
+-- articles.author keeps the author's username for search, sorting and
+-- filtering; author_id is the reference that counts
+ALTER TABLE articles ADD COLUMN author_id INTEGER REFERENCES users (id);
+
+-- Linking the authors is not a change to the articles themselves
+ALTER TABLE articles DISABLE TRIGGER articles_set_updated_at;
+
+UPDATE articles
+SET author_id = users.id
+FROM users
+WHERE lower(users.username) = lower(articles.author);
+
+ALTER TABLE articles ENABLE TRIGGER articles_set_updated_at;
+
+ALTER TABLE articles ALTER COLUMN author_id SET NOT NULL;
+
+CREATE INDEX articles_author_fk_idx ON articles (author_id);
@@ -0,0 +1,15 @@
+-- Refresh tokens are stored as hashes. Every refresh replaces the token
+-- with a new one of the same family; presenting a used token again revokes
+-- the whole family, since either the client or a thief holds a stolen copy.
+CREATE TABLE refresh_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    family TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX refresh_tokens_family_idx ON refresh_tokens (family);
@@ -0,0 +1,5 @@
+-- Everyone starts as an author. Promote the first admin by hand:
+--   UPDATE users SET role = 'admin' WHERE username = '...';
+ALTER TABLE users
+    ADD COLUMN role TEXT NOT NULL DEFAULT 'author'
+    CHECK (role IN ('author', 'editor', 'admin'));
@@ -0,0 +1,16 @@
+-- API keys act for the user who issued them, limited to their scopes.
+-- Only the hash of a key is stored; the prefix is kept in the clear so
+-- users can tell their keys apart. Scopes are separated by spaces.
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX api_keys_user_idx ON api_keys (user_id);
@@ -0,0 +1,25 @@
+-- Comments are threaded: replies point at the comment they answer through
+-- parent_id, and depth counts how far down the thread they are. Deleting
+-- an article deletes its comments. A deleted comment that has replies is
+-- kept with an empty body and deleted_at set, so the thread stays intact.
+CREATE TABLE comments (
+    id SERIAL PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES comments (id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    depth INTEGER NOT NULL DEFAULT 0,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- Threads are paged by their first comment
+CREATE INDEX comments_article_idx ON comments (article_id, created_at, id) WHERE parent_id IS NULL;
+CREATE INDEX comments_parent_idx ON comments (parent_id);
+
+CREATE TRIGGER comments_set_updated_at
+    BEFORE UPDATE ON comments
+    FOR EACH ROW
+    WHEN (OLD.* IS DISTINCT FROM NEW.*)
+    EXECUTE FUNCTION set_updated_at();
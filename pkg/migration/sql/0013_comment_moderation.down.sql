@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS comments_status_idx;
+
+ALTER TABLE comments
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS spam_score,
+    DROP COLUMN IF EXISTS spam_reasons,
+    DROP COLUMN IF EXISTS moderated_by,
+    DROP COLUMN IF EXISTS moderated_at;
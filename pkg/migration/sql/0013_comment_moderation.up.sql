@@ -0,0 +1,14 @@
+-- Comments are held for moderation when they look like spam. Comments
+-- posted before moderation existed stay approved.
+ALTER TABLE comments
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'approved'
+        CHECK (status IN ('pending', 'approved', 'rejected', 'spam')),
+    ADD COLUMN spam_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN spam_reasons TEXT NOT NULL DEFAULT '',
+    ADD COLUMN moderated_by TEXT,
+    ADD COLUMN moderated_at TIMESTAMPTZ;
+
+ALTER TABLE comments ALTER COLUMN status SET DEFAULT 'pending';
+
+-- The moderation queue lists comments by status, oldest first
+CREATE INDEX comments_status_idx ON comments (status, created_at, id);
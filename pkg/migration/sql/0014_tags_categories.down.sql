@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS article_categories;
+DROP TABLE IF EXISTS categories;
+
+ALTER TABLE article_tags RENAME TO article_tag_ids;
+ALTER INDEX article_tags_pkey RENAME TO article_tag_ids_pkey;
+
+CREATE TABLE article_tags (
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (article_id, tag)
+);
+
+CREATE INDEX article_tags_tag_idx ON article_tags (tag);
+
+INSERT INTO article_tags (article_id, tag)
+SELECT article_tag_ids.article_id, tags.name
+FROM article_tag_ids
+JOIN tags ON tags.id = article_tag_ids.tag_id;
+
+DROP TABLE article_tag_ids;
+DROP TABLE tags;
@@ -0,0 +1,60 @@
+-- Tags become rows of their own with a unique slug, replacing the free text
+-- tags of article_tags. Existing tags are carried over, tags that only
+-- differ in case or punctuation being merged into one.
+ALTER TABLE article_tags RENAME TO article_tag_names;
+ALTER INDEX article_tags_pkey RENAME TO article_tag_names_pkey;
+ALTER INDEX article_tags_tag_idx RENAME TO article_tag_names_tag_idx;
+
+CREATE TABLE tags (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE article_tags (
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (article_id, tag_id)
+);
+
+CREATE INDEX article_tags_tag_id_idx ON article_tags (tag_id);
+
+WITH named AS (
+    SELECT article_id, tag, trim(BOTH '-' FROM regexp_replace(lower(tag), '[^[:alnum:]]+', '-', 'g')) AS slug
+    FROM article_tag_names
+), created AS (
+    INSERT INTO tags (name, slug)
+    SELECT DISTINCT ON (slug) tag, slug
+    FROM named
+    WHERE slug <> ''
+    ORDER BY slug, tag
+    RETURNING id, slug
+)
+INSERT INTO article_tags (article_id, tag_id)
+SELECT DISTINCT named.article_id, created.id
+FROM named
+JOIN created ON created.slug = named.slug;
+
+DROP TABLE article_tag_names;
+
+-- Categories form a tree through parent_id. A category can only be deleted
+-- once it has no children.
+CREATE TABLE categories (
+    id SERIAL PRIMARY KEY,
+    parent_id INTEGER REFERENCES categories (id),
+    name TEXT NOT NULL,
+    slug TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (parent_id <> id)
+);
+
+CREATE INDEX categories_parent_id_idx ON categories (parent_id);
+
+CREATE TABLE article_categories (
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (article_id, category_id)
+);
+
+CREATE INDEX article_categories_category_id_idx ON article_categories (category_id);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS articles_record_slug ON articles;
+DROP FUNCTION IF EXISTS record_article_slug();
+DROP TABLE IF EXISTS article_slugs;
+ALTER TABLE articles DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,46 @@
+-- Articles are addressed by a unique slug made from their title. Existing
+-- articles get one from their title, common accents spelled without, and
+-- the ID is added to the slugs that are taken by an older article.
+ALTER TABLE articles ADD COLUMN slug TEXT;
+
+WITH made AS (
+    SELECT id, coalesce(nullif(trim(BOTH '-' FROM left(regexp_replace(
+        translate(lower(title), 'àáâãäåāăąçćčďđèéêëēėęěğìíîïīįıłñńňòóôõöøōőŕřśşšťùúûüūůűųýÿźżž', 'aaaaaaaaacccddeeeeeeeegiiiiiiilnnnoooooooorrssstuuuuuuuuyyzzz'),
+        '[^[:alnum:]]+', '-', 'g'), 80)), ''), 'article') AS slug
+    FROM articles
+), numbered AS (
+    SELECT id, slug, row_number() OVER (PARTITION BY slug ORDER BY id) AS n
+    FROM made
+)
+UPDATE articles
+SET slug = CASE WHEN numbered.n = 1 THEN numbered.slug ELSE numbered.slug || '-' || articles.id END
+FROM numbered
+WHERE numbered.id = articles.id;
+
+ALTER TABLE articles ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE articles ADD CONSTRAINT articles_slug_key UNIQUE (slug);
+
+-- The slugs an article had before its title changed, so that links to them
+-- keep working
+CREATE TABLE article_slugs (
+    slug TEXT PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX article_slugs_article_id_idx ON article_slugs (article_id);
+
+CREATE FUNCTION record_article_slug() RETURNS trigger AS $$
+BEGIN
+    -- An article can take back one of its old slugs
+    DELETE FROM article_slugs WHERE slug = NEW.slug;
+    INSERT INTO article_slugs (slug, article_id) VALUES (OLD.slug, OLD.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER articles_record_slug
+    AFTER UPDATE OF slug ON articles
+    FOR EACH ROW
+    WHEN (OLD.slug IS DISTINCT FROM NEW.slug)
+    EXECUTE FUNCTION record_article_slug();
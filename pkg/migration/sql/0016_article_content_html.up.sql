@@ -0,0 +1,4 @@
+-- The HTML rendered from the Markdown content of an article is kept next to
+-- it. Articles saved before are rendered when they are read until they are
+-- saved again.
+ALTER TABLE articles ADD COLUMN content_html TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+-- Uploaded media. The bytes live in the blob store under storage_key; the
+-- SHA-256 checksum of the bytes is unique, so the same file uploaded twice
+-- is stored once.
+CREATE TABLE media (
+    id SERIAL PRIMARY KEY,
+    checksum TEXT NOT NULL UNIQUE,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    filename TEXT NOT NULL DEFAULT '',
+    storage_key TEXT NOT NULL,
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
@@ -0,0 +1,9 @@
+ALTER TABLE articles DROP COLUMN IF EXISTS cover_id;
+DROP TABLE IF EXISTS media_variants;
+DROP INDEX IF EXISTS media_unprocessed_idx;
+ALTER TABLE media
+    DROP COLUMN IF EXISTS claimed_at,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS placeholder,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width;
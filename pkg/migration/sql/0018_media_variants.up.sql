@@ -0,0 +1,28 @@
+-- Uploaded images are processed in the background: their size and a
+-- BlurHash placeholder are kept with them, and their resized variants in
+-- media_variants. Images uploaded before are processed once this is
+-- applied. A claim older than ten minutes is taken to be abandoned.
+ALTER TABLE media
+    ADD COLUMN width INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN height INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN placeholder TEXT NOT NULL DEFAULT '',
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'ready'
+        CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    ADD COLUMN claimed_at TIMESTAMPTZ;
+
+UPDATE media SET status = 'pending' WHERE content_type LIKE 'image/%';
+
+CREATE INDEX media_unprocessed_idx ON media (id) WHERE status IN ('pending', 'processing');
+
+CREATE TABLE media_variants (
+    media_id INTEGER NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    storage_key TEXT NOT NULL,
+    PRIMARY KEY (media_id, width)
+);
+
+-- The cover image of an article
+ALTER TABLE articles ADD COLUMN cover_id INTEGER REFERENCES media(id) ON DELETE SET NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS articles_published_idx;
@@ -0,0 +1,2 @@
+-- Feeds read the latest published articles
+CREATE INDEX articles_published_idx ON articles (published_at DESC, id DESC) WHERE status = 'published';
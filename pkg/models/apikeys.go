@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// API key scopes. A key can only do what both its scopes and the role of
+// the user who issued it allow.
+const (
+	// ScopeArticlesRead reads the unpublished articles the user may see
+	ScopeArticlesRead = "articles:read"
+	// ScopeArticlesWrite creates, changes and publishes articles
+	ScopeArticlesWrite = "articles:write"
+	// ScopeAdmin manages users and API keys
+	ScopeAdmin = "admin"
+)
+
+// APIScopes lists every scope an API key can have.
+var APIScopes = []string{ScopeArticlesRead, ScopeArticlesWrite, ScopeAdmin}
+
+// APIKey lets a machine client act for the user who issued it, limited to
+// its scopes. The server keeps the hash of the key; the key itself is only
+// returned when it is issued.
+//
+// swagger:model APIKey
+type APIKey struct {
+	// ID of the key
+	ID int `json:"id"`
+	// ID of the user the key acts for
+	UserID int `json:"user_id"`
+	// What the key is for, e.g. the pipeline using it
+	Name string `json:"name"`
+	// Start of the key, to recognize it by
+	Prefix string `json:"prefix"`
+	// The full key, sent as "Authorization: ApiKey <key>"; only returned
+	// when the key is issued
+	Key string `json:"key,omitempty"`
+	// KeyHash is never serialized
+	KeyHash string `json:"-"`
+	// What the key may do: articles:read, articles:write and admin
+	Scopes []string `json:"scopes"`
+	// Time the key was issued, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Time the key was last used, in RFC 3339 format
+	// format: date-time
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	// Time the key was revoked, in RFC 3339 format
+	// format: date-time
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyRequest is the body of a request issuing an API key.
+//
+// swagger:model APIKeyRequest
+type APIKeyRequest struct {
+	// What the key is for, e.g. the pipeline using it
+	// required: true
+	Name string `json:"name"`
+	// At least one of articles:read, articles:write and admin
+	// required: true
+	Scopes []string `json:"scopes"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // Article
 //
 // swagger:response Article
@@ -10,10 +12,103 @@ type Article struct {
 	// Title of the article
 	// in: string
 	Title string `json:"title,omitempty"`
-	// Content of the article
+	// URL form of the title, unique among articles; set by the server and
+	// changed with the title, old slugs redirecting to the new one
+	// read only: true
+	Slug string `json:"slug,omitempty"`
+	// Content of the article in Markdown: CommonMark with the GitHub
+	// extensions. Read endpoints return the sanitized HTML rendered from
+	// it instead with format=html
 	// in: string
 	Content string `json:"content,omitempty"`
-	// Author of the article
-	// in: string
+	// HTML rendered from the Markdown content and sanitized; returned as
+	// content when the client asks for format=html
+	ContentHTML string `json:"-"`
+	// Username of the author; set by the server from author_id
+	// read only: true
 	Author string `json:"author,omitempty"`
+	// ID of the user who wrote the article; the signed in user for new
+	// articles
+	AuthorID int `json:"author_id,omitempty"`
+	// Workflow status: draft, in_review, published or archived; changed
+	// through the article actions only
+	// read only: true
+	Status string `json:"status,omitempty"`
+	// Time the article was created, in RFC 3339 format; set by the server
+	// format: date-time
+	// read only: true
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Time the article was last changed, in RFC 3339 format; set by the server
+	// format: date-time
+	// read only: true
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// Time the article was published, in RFC 3339 format; unset while unpublished
+	// format: date-time
+	// read only: true
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	// Time to publish the article automatically once it has been submitted
+	// for review, in RFC 3339 format; only editors may set or change it, to
+	// a time in the future
+	// format: date-time
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// Who created the article; set by the server
+	// read only: true
+	CreatedBy string `json:"created_by,omitempty"`
+	// Who last changed the article; set by the server
+	// read only: true
+	UpdatedBy string `json:"updated_by,omitempty"`
+	// Number of the article's latest revision; every change adds one
+	// read only: true
+	Revision int `json:"revision,omitempty"`
+	// Names of the tags of the article; tags that do not exist yet are
+	// created
+	Tags []string `json:"tags,omitempty"`
+	// Slugs of the categories the article is filed in
+	Categories []string `json:"categories,omitempty"`
+	// ID of the uploaded image shown as the cover of the article
+	CoverID *int `json:"cover_id,omitempty"`
+	// The cover image in the sizes it is served in; set by the server from
+	// cover_id
+	// read only: true
+	Cover *Cover `json:"cover,omitempty"`
+}
+
+// Article statuses. New articles are drafts; only published articles are
+// visible to everyone.
+const (
+	StatusDraft     = "draft"
+	StatusInReview  = "in_review"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Formats the content of an article is returned in. Content is written in
+// Markdown and also kept rendered to HTML.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// ContentFormats lists every format the content of an article can be
+// returned in.
+var ContentFormats = []string{FormatMarkdown, FormatHTML}
+
+// MaxSlugLength is the longest article slug, in characters
+const MaxSlugLength = 80
+
+// ArticleStatuses lists every status an article can be in.
+var ArticleStatuses = []string{StatusDraft, StatusInReview, StatusPublished, StatusArchived}
+
+// Kinds of article events
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// ArticleEvent is a change made to an article: the article after the
+// change, or as it was before it was deleted.
+type ArticleEvent struct {
+	Type    string
+	Article Article
 }
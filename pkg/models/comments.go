@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// Comment views
+const (
+	// CommentTree nests replies under the comment they answer
+	CommentTree = "tree"
+	// CommentFlat lists a thread depth first, each comment with its depth
+	CommentFlat = "flat"
+)
+
+// CommentViews lists the ways comments can be listed.
+var CommentViews = []string{CommentTree, CommentFlat}
+
+// Comment statuses. Comments are pending when they are posted, and again
+// when an edit looks like spam, until a moderator decides.
+const (
+	CommentPending  = "pending"
+	CommentApproved = "approved"
+	CommentRejected = "rejected"
+	CommentSpam     = "spam"
+)
+
+// CommentStatuses lists every status a comment can have.
+var CommentStatuses = []string{CommentPending, CommentApproved, CommentRejected, CommentSpam}
+
+// MaxCommentLength is the longest comment body, in characters.
+const MaxCommentLength = 10000
+
+// Comment is a response to an article, or to another comment on it.
+//
+// swagger:model Comment
+type Comment struct {
+	// ID of the comment
+	ID int `json:"id"`
+	// ID of the article commented on
+	ArticleID int `json:"article_id"`
+	// ID of the comment replied to; empty for the first comment of a thread
+	ParentID *int `json:"parent_id,omitempty"`
+	// ID of the user who wrote the comment; empty once it is deleted
+	AuthorID int `json:"author_id,omitempty"`
+	// Username of the user who wrote the comment; empty once it is deleted
+	Author string `json:"author,omitempty"`
+	// Text of the comment; empty once it is deleted
+	Body string `json:"body"`
+	// How many replies down the thread the comment is, 0 for the first
+	Depth int `json:"depth"`
+	// Deleted comments keep their place in the thread when they have replies
+	Deleted bool `json:"deleted,omitempty"`
+	// Moderation status: pending, approved, rejected or spam. Only approved
+	// comments are listed on the article.
+	Status string `json:"status"`
+	// How likely the comment is spam, from 0 to 1; only shown to moderators
+	SpamScore float64 `json:"spam_score,omitempty"`
+	// Why the comment looks like spam; only shown to moderators
+	SpamReasons []string `json:"spam_reasons,omitempty"`
+	// Who last approved, rejected or marked the comment as spam
+	ModeratedBy string `json:"moderated_by,omitempty"`
+	// Time the comment was last moderated, in RFC 3339 format
+	// format: date-time
+	ModeratedAt *time.Time `json:"moderated_at,omitempty"`
+	// Time the comment was posted, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Time the comment was last changed, in RFC 3339 format
+	// format: date-time
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	// Replies to the comment, oldest first; only in the tree view
+	Replies []Comment `json:"replies,omitempty"`
+}
+
+// CommentRequest is the body of a request posting or editing a comment.
+//
+// swagger:model CommentRequest
+type CommentRequest struct {
+	// Text of the comment
+	// required: true
+	Body string `json:"body"`
+	// ID of the comment to reply to; ignored when editing
+	ParentID *int `json:"parent_id,omitempty"`
+}
+
+// CommentPage is one page of the threads on an article, oldest first, each
+// thread depth first. Pages count threads, not comments.
+type CommentPage struct {
+	Comments []Comment
+	PageInfo
+}
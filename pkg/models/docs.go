@@ -6,9 +6,10 @@ package models
 type ArticleListResponse struct {
 	// in: body
 	Body struct {
-		Status  int       `json:"status"`
-		Message string    `json:"message"`
-		Data    []Article `json:"data"`
+		Status     int         `json:"status"`
+		Message    string      `json:"message"`
+		Data       []Article   `json:"data"`
+		Pagination *Pagination `json:"pagination"`
 	}
 }
 
@@ -53,7 +54,7 @@ type ResponseCreateArticle struct {
 
 // IDParameter represents the 'id' parameter in the Swagger schema.
 //
-// swagger:parameters idParameter
+// swagger:parameters idParameter articleRevisions
 type IDParameter struct {
 	// in: path
 	// required: true
@@ -61,3 +62,292 @@ type IDParameter struct {
 	// example: 1
 	ID int `json:"id"`
 }
+
+// ListParameters are the paging query parameters of list endpoints.
+//
+// swagger:parameters allArticle searchArticles scheduledArticles articleRevisions
+type ListParameters struct {
+	// Number of items per page, at most 100
+	// in: query
+	// example: 20
+	Limit int `json:"limit"`
+	// Number of items to skip; selects offset paging
+	// in: query
+	Offset int `json:"offset"`
+	// Opaque keyset cursor taken from a next or prev link
+	// in: query
+	Cursor string `json:"cursor"`
+}
+
+// ArticleListParameters are the sorting and filtering query parameters of
+// the article listing.
+//
+// swagger:parameters allArticle
+type ArticleListParameters struct {
+	// Field to order by: title, created_at, updated_at, author or id;
+	// prefix with - for descending order
+	// in: query
+	// example: -created_at
+	Sort string `json:"sort"`
+	// Only articles by this author
+	// in: query
+	Author string `json:"author"`
+	// Only articles created after this RFC 3339 timestamp
+	// in: query
+	// format: date-time
+	CreatedAfter string `json:"created_after"`
+	// Only articles created before this RFC 3339 timestamp
+	// in: query
+	// format: date-time
+	CreatedBefore string `json:"created_before"`
+	// Only articles with this tag, given by name or slug
+	// in: query
+	Tag string `json:"tag"`
+	// Only articles in the category with this slug or the categories below it
+	// in: query
+	Category string `json:"category"`
+	// Only articles in this status: draft, in_review, published or archived
+	// in: query
+	Status string `json:"status"`
+}
+
+// FormatParameter picks the format article content is returned in.
+//
+// swagger:parameters allArticle idParameter searchArticles scheduledArticles
+type FormatParameter struct {
+	// Format of the content: markdown, the default, or html rendered from it
+	// in: query
+	// example: html
+	Format string `json:"format"`
+}
+
+// SearchParameters are the query parameters of the search endpoint.
+//
+// swagger:parameters searchArticles
+type SearchParameters struct {
+	// Search query, e.g. "exact phrase" docker -windows kube*
+	// in: query
+	// required: true
+	Q string `json:"q"`
+}
+
+// SearchResponse
+//
+// swagger:response SearchResponse
+type SearchResponse struct {
+	// in: body
+	Body struct {
+		Status     int            `json:"status"`
+		Message    string         `json:"message"`
+		Data       []SearchResult `json:"data"`
+		Pagination *Pagination    `json:"pagination"`
+	}
+}
+
+// RevisionParameters address one revision of an article.
+//
+// swagger:parameters getRevision diffRevisions
+type RevisionParameters struct {
+	// ID of the article
+	// in: path
+	// required: true
+	ID int `json:"id"`
+	// Number of the revision
+	// in: path
+	// required: true
+	Rev int `json:"rev"`
+}
+
+// DiffParameters are the query parameters of the revision diff.
+//
+// swagger:parameters diffRevisions
+type DiffParameters struct {
+	// Revision to compare with; defaults to the previous revision, 0 is the
+	// empty article
+	// in: query
+	From int `json:"from"`
+}
+
+// RevisionListResponse
+//
+// swagger:response RevisionListResponse
+type RevisionListResponse struct {
+	// in: body
+	Body struct {
+		Status     int         `json:"status"`
+		Message    string      `json:"message"`
+		Data       []Revision  `json:"data"`
+		Pagination *Pagination `json:"pagination"`
+	}
+}
+
+// RevisionResponse
+//
+// swagger:response RevisionResponse
+type RevisionResponse struct {
+	// in: body
+	Body struct {
+		Status  int      `json:"status"`
+		Message string   `json:"message"`
+		Data    Revision `json:"data"`
+	}
+}
+
+// RevisionDiffResponse
+//
+// swagger:response RevisionDiffResponse
+type RevisionDiffResponse struct {
+	// in: body
+	Body struct {
+		Status  int          `json:"status"`
+		Message string       `json:"message"`
+		Data    RevisionDiff `json:"data"`
+	}
+}
+
+// UserResponse
+//
+// swagger:response UserResponse
+type UserResponse struct {
+	// in: body
+	Body struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		Data    User   `json:"data"`
+	}
+}
+
+// UserListResponse
+//
+// swagger:response UserListResponse
+type UserListResponse struct {
+	// in: body
+	Body struct {
+		Status     int         `json:"status"`
+		Message    string      `json:"message"`
+		Data       []User      `json:"data"`
+		Pagination *Pagination `json:"pagination"`
+	}
+}
+
+// APIKeyResponse
+//
+// swagger:response APIKeyResponse
+type APIKeyResponse struct {
+	// in: body
+	Body struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		Data    APIKey `json:"data"`
+	}
+}
+
+// APIKeyListResponse
+//
+// swagger:response APIKeyListResponse
+type APIKeyListResponse struct {
+	// in: body
+	Body struct {
+		Status  int      `json:"status"`
+		Message string   `json:"message"`
+		Data    []APIKey `json:"data"`
+	}
+}
+
+// CommentResponse
+//
+// swagger:response CommentResponse
+type CommentResponse struct {
+	// in: body
+	Body struct {
+		Status  int     `json:"status"`
+		Message string  `json:"message"`
+		Data    Comment `json:"data"`
+	}
+}
+
+// CommentListResponse
+//
+// swagger:response CommentListResponse
+type CommentListResponse struct {
+	// in: body
+	Body struct {
+		Status     int         `json:"status"`
+		Message    string      `json:"message"`
+		Data       []Comment   `json:"data"`
+		Pagination *Pagination `json:"pagination"`
+	}
+}
+
+// SessionResponse
+//
+// swagger:response SessionResponse
+type SessionResponse struct {
+	// in: body
+	Body struct {
+		Status  int     `json:"status"`
+		Message string  `json:"message"`
+		Data    Session `json:"data"`
+	}
+}
+
+// TagResponse
+//
+// swagger:response TagResponse
+type TagResponse struct {
+	// in: body
+	Body struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		Data    Tag    `json:"data"`
+	}
+}
+
+// MediaResponse
+//
+// swagger:response MediaResponse
+type MediaResponse struct {
+	// in: body
+	Body struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		Data    Media  `json:"data"`
+	}
+}
+
+// TagListResponse
+//
+// swagger:response TagListResponse
+type TagListResponse struct {
+	// in: body
+	Body struct {
+		Status     int         `json:"status"`
+		Message    string      `json:"message"`
+		Data       []Tag       `json:"data"`
+		Pagination *Pagination `json:"pagination"`
+	}
+}
+
+// CategoryResponse
+//
+// swagger:response CategoryResponse
+type CategoryResponse struct {
+	// in: body
+	Body struct {
+		Status  int      `json:"status"`
+		Message string   `json:"message"`
+		Data    Category `json:"data"`
+	}
+}
+
+// CategoryListResponse
+//
+// swagger:response CategoryListResponse
+type CategoryListResponse struct {
+	// in: body
+	Body struct {
+		Status  int        `json:"status"`
+		Message string     `json:"message"`
+		Data    []Category `json:"data"`
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FeedSize is the number of articles in a feed
+const FeedSize = 20
+
+// Feed is the latest published articles of the site, of one author or with
+// one tag, the most recently published first.
+type Feed struct {
+	// Author is the username of the author of every article, when the feed
+	// is limited to one
+	Author string
+	// Tag is the tag of every article, when the feed is limited to one
+	Tag      *Tag
+	Articles []Article
+}
+
+// Site is the public site articles are read on, which feeds and sitemaps
+// link into.
+type Site struct {
+	Title string
+	// URL is where the site is served from, without a trailing slash
+	URL string
+}
+
+// NewSite returns a site served from siteURL.
+func NewSite(title, siteURL string) Site {
+	return Site{Title: title, URL: strings.TrimRight(siteURL, "/")}
+}
+
+// Link returns the absolute URL of a path on the site.
+func (s Site) Link(path string) string {
+	return s.URL + path
+}
+
+// ArticleLink returns the URL an article is read at, which changes with its
+// slug.
+func (s Site) ArticleLink(article *Article) string {
+	return s.Link("/articles/by-slug/" + url.PathEscape(article.Slug))
+}
+
+// ArticleID returns the URL of an article by its ID, which never changes.
+func (s Site) ArticleID(article *Article) string {
+	return s.Link(fmt.Sprintf("/articles/%d", article.ID))
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Fields article listings can be sorted by
+const (
+	SortTitle     = "title"
+	SortCreatedAt = "created_at"
+	SortUpdatedAt = "updated_at"
+	SortAuthor    = "author"
+	SortID        = "id"
+)
+
+// ArticleSortFields lists the accepted sort fields in the order they are documented.
+var ArticleSortFields = []string{SortTitle, SortCreatedAt, SortUpdatedAt, SortAuthor, SortID}
+
+// Sort orders a listing by one field, ties being broken by ID.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort reads a sort parameter such as "title" or "-created_at". It
+// returns false when the field is not one of ArticleSortFields.
+func ParseSort(value string) (Sort, bool) {
+	sort := Sort{Field: strings.TrimPrefix(value, "-"), Desc: strings.HasPrefix(value, "-")}
+	for _, field := range ArticleSortFields {
+		if sort.Field == field {
+			return sort, true
+		}
+	}
+	return Sort{}, false
+}
+
+// String returns the sort in its query parameter form.
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// ArticleFilter narrows an article listing. Zero values do not filter.
+type ArticleFilter struct {
+	Author        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// Tag is the slug of a tag
+	Tag string
+	// Category is the slug of a category; articles of the categories below
+	// it are included
+	Category string
+	Status   string
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultMaxMediaSize is the largest file that can be uploaded unless the
+// server is configured otherwise, in bytes
+const DefaultMaxMediaSize = 10 << 20
+
+// MediaTypes lists the content types of files that can be uploaded. The
+// type is sniffed from the bytes of a file, not taken from the client.
+var MediaTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"}
+
+// ImageTypes lists the uploaded content types that are images, which get
+// resized variants and a placeholder.
+var ImageTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// IsImage reports whether files of a content type are images.
+func IsImage(contentType string) bool {
+	for _, imageType := range ImageTypes {
+		if contentType == imageType {
+			return true
+		}
+	}
+	return false
+}
+
+// Processing statuses of media. Images start pending until their variants
+// are made; other files are ready right away.
+const (
+	MediaPending    = "pending"
+	MediaProcessing = "processing"
+	MediaReady      = "ready"
+	MediaFailed     = "failed"
+)
+
+// MediaURL returns the URL a media file is served from.
+func MediaURL(id int) string {
+	return fmt.Sprintf("/media/%d", id)
+}
+
+// VariantURL returns the URL the variant of an image with a width is
+// served from.
+func VariantURL(id, width int) string {
+	return fmt.Sprintf("/media/%d/variants/%d", id, width)
+}
+
+// Media is an uploaded file.
+//
+// swagger:model Media
+type Media struct {
+	// ID of the file
+	ID int `json:"id"`
+	// Where the file is served from
+	URL string `json:"url"`
+	// Content type sniffed from the file
+	ContentType string `json:"content_type"`
+	// Size of the file in bytes
+	Size int64 `json:"size"`
+	// Hex encoded SHA-256 checksum of the file
+	Checksum string `json:"checksum"`
+	// Name of the file as it was uploaded
+	Filename string `json:"filename"`
+	// Width of an image in pixels, once it has been processed
+	Width int `json:"width,omitempty"`
+	// Height of an image in pixels, once it has been processed
+	Height int `json:"height,omitempty"`
+	// BlurHash of an image, to show while it loads
+	Placeholder string `json:"placeholder,omitempty"`
+	// Processing of the file: pending, processing, ready or failed
+	Status string `json:"status"`
+	// ID of the user who first uploaded the file
+	UploadedBy *int `json:"uploaded_by,omitempty"`
+	// Time the file was first uploaded, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// StorageKey is never serialized
+	StorageKey string `json:"-"`
+}
+
+// MediaVariant is an image resized to a width, without the metadata of the
+// uploaded image.
+//
+// swagger:model MediaVariant
+type MediaVariant struct {
+	// Where the variant is served from
+	URL string `json:"url"`
+	// Width of the variant in pixels
+	Width int `json:"width"`
+	// Height of the variant in pixels
+	Height int `json:"height"`
+	// Content type of the variant, JPEG or PNG for images with transparency
+	ContentType string `json:"content_type"`
+	// Size of the variant in bytes
+	Size int64 `json:"size"`
+	// MediaID is never serialized
+	MediaID int `json:"-"`
+	// StorageKey is never serialized
+	StorageKey string `json:"-"`
+}
+
+// Cover is the cover image of an article with the variants it can be shown
+// in.
+//
+// swagger:model Cover
+type Cover struct {
+	// URL of the widest variant, or of the uploaded image until its
+	// variants are made
+	URL string `json:"url"`
+	// Width of the image in pixels
+	Width int `json:"width,omitempty"`
+	// Height of the image in pixels
+	Height int `json:"height,omitempty"`
+	// BlurHash of the image, to show while it loads
+	Placeholder string `json:"placeholder,omitempty"`
+	// Variants of the image, narrowest first
+	Variants []MediaVariant `json:"variants"`
+	// The variants as the srcset attribute of an img element
+	Srcset string `json:"srcset,omitempty"`
+}
@@ -0,0 +1,122 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	// DefaultPageSize is used when a listing does not ask for a page size.
+	DefaultPageSize = 20
+	// MaxPageSize caps the page size a client may ask for.
+	MaxPageSize = 100
+)
+
+// Cursor directions
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a keyset position: the sort value and ID of the row a page starts
+// after (next) or ends before (prev). Sort records the order the cursor was
+// created for, since it is meaningless under any other.
+type Cursor struct {
+	Sort      string `json:"s,omitempty"`
+	Value     string `json:"v"`
+	ID        int    `json:"id"`
+	Direction string `json:"d"`
+}
+
+// Encode returns the opaque form of the cursor handed to clients.
+func (c Cursor) Encode() string {
+	out, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(out)
+}
+
+// DecodeCursor parses a cursor produced by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Direction != CursorNext && c.Direction != CursorPrev {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// ListParams selects one page of a listing. A page is addressed either by
+// Offset or, when Cursor is set, by keyset.
+type ListParams struct {
+	Limit  int
+	Offset int
+	Cursor *Cursor
+	Sort   Sort
+	Filter ArticleFilter
+	// Viewer is the user reading the listing, who also sees their own
+	// unpublished articles. Anonymous viewers see published articles only.
+	Viewer Principal
+	// AllStatuses also lists the unpublished articles of other authors. The
+	// service sets it for viewers the policy lets see them.
+	AllStatuses bool
+}
+
+// Normalize applies the default sort and the default and maximum page sizes.
+func (p *ListParams) Normalize() {
+	if p.Sort.Field == "" {
+		p.Sort.Field = SortTitle
+	}
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageSize
+	}
+	if p.Limit > MaxPageSize {
+		p.Limit = MaxPageSize
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+}
+
+// PageInfo locates a page within a listing so its neighbours can be fetched.
+type PageInfo struct {
+	Total int
+	// HasNext and HasPrev report whether there are rows after or before the page.
+	HasNext bool
+	HasPrev bool
+	// NextCursor and PrevCursor address the neighbouring pages by keyset.
+	NextCursor string
+	PrevCursor string
+}
+
+// ArticlePage is one page of articles.
+type ArticlePage struct {
+	Articles []Article
+	PageInfo
+}
+
+// Pagination describes the page returned in a Response.
+//
+// swagger:model Pagination
+type Pagination struct {
+	// Total number of items across all pages
+	Total int `json:"total"`
+	// Maximum number of items in this page
+	Limit int `json:"limit"`
+	// Offset of the first item when paging by offset
+	Offset int `json:"offset,omitempty"`
+	// Link to the next page, if any
+	Next string `json:"next,omitempty"`
+	// Link to the previous page, if any
+	Prev string `json:"prev,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// Principal is the authenticated user a request is made by.
+type Principal struct {
+	// ID of the user
+	UserID int
+	// Username of the user
+	Username string
+	// Role of the user when they signed in
+	Role string
+	// Scopes limit what a request authenticated with an API key may do.
+	// They are nil for users who signed in themselves, who may do all their
+	// role allows.
+	Scopes []string
+}
+
+// Anonymous reports whether no user is signed in.
+func (p Principal) Anonymous() bool {
+	return p.UserID == 0
+}
+
+// HasScope reports whether the principal is not limited to scopes that
+// leave out scope.
+func (p Principal) HasScope(scope string) bool {
+	if p.Scopes == nil {
+		return true
+	}
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -10,4 +10,6 @@ type Response struct {
 	Message string `json:"message"`
 	// Any type of Response data or null
 	Data interface{} `json:"data"`
+	// Paging details for list responses
+	Pagination *Pagination `json:"pagination,omitempty"`
 }
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Revision is an article as it was saved by one of its changes. Revisions
+// are numbered from 1 for each article.
+//
+// swagger:model Revision
+type Revision struct {
+	// ID of the article
+	ArticleID int `json:"article_id"`
+	// Number of the revision
+	Revision int `json:"revision"`
+	// Title of the article
+	Title string `json:"title"`
+	// Content of the article
+	Content string `json:"content"`
+	// Author of the article
+	Author string `json:"author"`
+	// Workflow status of the article
+	Status string `json:"status"`
+	// Scheduled publishing time of the article, in RFC 3339 format
+	// format: date-time
+	PublishAt *time.Time `json:"publish_at,omitempty"`
+	// Who made the change
+	CreatedBy string `json:"created_by"`
+	// Time of the change, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// RevisionPage is one page of an article's revisions, newest first.
+type RevisionPage struct {
+	Revisions []Revision
+	PageInfo
+}
+
+// Diff operations
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLine is one line of a line-based diff: kept, inserted by the newer
+// revision or deleted from the older one.
+//
+// swagger:model DiffLine
+type DiffLine struct {
+	// equal, insert or delete
+	Op string `json:"op"`
+	// The line, without its line break
+	Text string `json:"text"`
+}
+
+// RevisionDiff compares the title and content of two revisions of an article.
+//
+// swagger:model RevisionDiff
+type RevisionDiff struct {
+	// ID of the article
+	ArticleID int `json:"article_id"`
+	// The older revision
+	From int `json:"from"`
+	// The newer revision
+	To int `json:"to"`
+	// Line diff of the titles
+	Title []DiffLine `json:"title"`
+	// Line diff of the contents
+	Content []DiffLine `json:"content"`
+}
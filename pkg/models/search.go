@@ -0,0 +1,18 @@
+package models
+
+// SearchResult is an article matching a full-text search.
+//
+// swagger:model SearchResult
+type SearchResult struct {
+	Article
+	// Relevance of the article to the query, higher is better
+	Rank float64 `json:"rank"`
+	// Excerpt of the content as escaped HTML, with matches wrapped in <mark> tags
+	Snippet string `json:"snippet"`
+}
+
+// SearchPage is one page of search results ordered by rank.
+type SearchPage struct {
+	Results []SearchResult
+	PageInfo
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// SitemapPage is one of the sitemaps the published articles are listed in.
+type SitemapPage struct {
+	// Number of the page, from 1
+	Number int
+	// Modified is when the last article on the page changed
+	Modified time.Time
+}
+
+// SitemapEntry is a published article as it is listed in a sitemap.
+type SitemapEntry struct {
+	ArticleID int
+	Slug      string
+	// Modified is when the article was last published or changed
+	Modified time.Time
+	// CoverURL is where the cover of the article is served from, its widest
+	// variant if it has any, or empty without a cover
+	CoverURL string
+}
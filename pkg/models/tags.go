@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// Limits on the tags of an article
+const (
+	// MaxArticleTags is how many tags an article can have
+	MaxArticleTags = 20
+	// MaxTagLength is the longest tag or category name, in characters
+	MaxTagLength = 50
+)
+
+// Tag labels articles across categories. Tags are created the first time
+// an article uses them; tags whose names only differ in case or
+// punctuation share one slug and are the same tag.
+//
+// swagger:model Tag
+type Tag struct {
+	// ID of the tag
+	ID int `json:"id"`
+	// Name of the tag as it is shown
+	Name string `json:"name"`
+	// URL form of the name, unique among tags
+	Slug string `json:"slug"`
+	// Number of published articles with the tag
+	Articles int `json:"articles"`
+	// Time the tag was first used, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// TagRequest is the body of a request renaming a tag.
+//
+// swagger:model TagRequest
+type TagRequest struct {
+	// New name of the tag; its slug follows the name
+	// required: true
+	Name string `json:"name"`
+}
+
+// TagMergeRequest is the body of a request merging a tag into another.
+//
+// swagger:model TagMergeRequest
+type TagMergeRequest struct {
+	// Slug of the tag to keep
+	// required: true
+	Into string `json:"into"`
+}
+
+// TagPage is one page of tags, sorted by name.
+type TagPage struct {
+	Tags []Tag
+	PageInfo
+}
+
+// Category files articles in a tree of sections. An article can be in
+// several categories, and listing a category includes the articles of the
+// categories below it.
+//
+// swagger:model Category
+type Category struct {
+	// ID of the category
+	ID int `json:"id"`
+	// ID of the category above; empty for top level categories
+	ParentID *int `json:"parent_id,omitempty"`
+	// Name of the category as it is shown
+	Name string `json:"name"`
+	// URL form of the name, unique among categories
+	Slug string `json:"slug"`
+	// Number of published articles filed directly in the category
+	Articles int `json:"articles"`
+	// Time the category was created, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Categories below this one, sorted by name
+	Children []Category `json:"children,omitempty"`
+}
+
+// CategoryRequest is the body of a request creating or changing a
+// category.
+//
+// swagger:model CategoryRequest
+type CategoryRequest struct {
+	// Name of the category; its slug follows the name
+	// required: true
+	Name string `json:"name"`
+	// ID of the category to file it under; empty for a top level category
+	ParentID *int `json:"parent_id,omitempty"`
+}
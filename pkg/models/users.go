@@ -0,0 +1,116 @@
+package models
+
+import "time"
+
+// User is a registered account. Articles reference their author by user ID.
+//
+// swagger:model User
+type User struct {
+	// ID of the user
+	ID int `json:"id"`
+	// Unique name the user signs in with, shown as the author of their articles
+	Username string `json:"username"`
+	// Email address of the user
+	Email string `json:"email,omitempty"`
+	// What the user may do: author, editor or admin
+	Role string `json:"role,omitempty"`
+	// PasswordHash is never serialized
+	PasswordHash string `json:"-"`
+	// Time the user registered, in RFC 3339 format
+	// format: date-time
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// Time the user was last changed, in RFC 3339 format
+	// format: date-time
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// User roles. Authors write and manage their own articles, editors review,
+// edit and publish any article, and admins also manage users.
+const (
+	RoleAuthor = "author"
+	RoleEditor = "editor"
+	RoleAdmin  = "admin"
+)
+
+// UserRoles lists every role a user can have.
+var UserRoles = []string{RoleAuthor, RoleEditor, RoleAdmin}
+
+// RoleChange is the body of a request changing the role of a user.
+//
+// swagger:model RoleChange
+type RoleChange struct {
+	// author, editor or admin
+	// required: true
+	Role string `json:"role"`
+}
+
+// Registration is the body of a sign-up request.
+//
+// swagger:model Registration
+type Registration struct {
+	// 3 to 32 letters, digits, dots, dashes or underscores
+	// required: true
+	Username string `json:"username"`
+	// required: true
+	Email string `json:"email"`
+	// At least 8 characters
+	// required: true
+	Password string `json:"password"`
+}
+
+// Credentials is the body of a sign-in request.
+//
+// swagger:model Credentials
+type Credentials struct {
+	// required: true
+	Username string `json:"username"`
+	// required: true
+	Password string `json:"password"`
+}
+
+// RefreshToken is a stored refresh token. The token itself is only known
+// to the client; the server keeps its hash.
+type RefreshToken struct {
+	ID        int
+	UserID    int
+	TokenHash string
+	// Family is shared by the tokens a refresh token was rotated into
+	Family    string
+	ExpiresAt time.Time
+}
+
+// Session is returned when signing in or refreshing: a short-lived access
+// token to send as "Authorization: Bearer <token>", and a refresh token to
+// get the next one with.
+//
+// swagger:model Session
+type Session struct {
+	// Signed JWT access token
+	AccessToken string `json:"access_token"`
+	// Always Bearer
+	TokenType string `json:"token_type"`
+	// Time the access token expires, in RFC 3339 format
+	// format: date-time
+	ExpiresAt time.Time `json:"expires_at"`
+	// Single-use token to get a new session with
+	RefreshToken string `json:"refresh_token"`
+	// Time the refresh token expires, in RFC 3339 format
+	// format: date-time
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+	// The signed in user; only returned when signing in
+	User *User `json:"user,omitempty"`
+}
+
+// RefreshRequest is the body of a refresh or logout request.
+//
+// swagger:model RefreshRequest
+type RefreshRequest struct {
+	// required: true
+	RefreshToken string `json:"refresh_token"`
+}
+
+// UserPage is one page of users.
+type UserPage struct {
+	Users []User
+	PageInfo
+}
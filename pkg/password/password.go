@@ -0,0 +1,98 @@
+// Package password hashes and verifies user passwords. New hashes use
+// argon2id and are stored in the PHC string format, so the parameters can be
+// raised later without breaking existing hashes. bcrypt hashes, such as
+// those of accounts imported from other systems, are verified as well.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrMismatch is returned when a password does not match its hash.
+	ErrMismatch = errors.New("password does not match")
+	// ErrUnknownHash is returned for hashes in a format this package cannot verify.
+	ErrUnknownHash = errors.New("unknown password hash format")
+)
+
+// argon2id parameters, following the second recommended option of RFC 9106
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // KiB
+	argonThreads = 4
+	argonKeyLen  = 32
+	saltLen      = 16
+)
+
+var b64 = base64.RawStdEncoding
+
+// Hash returns the argon2id hash of password with a random salt.
+func Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argonMemory, argonTime, argonThreads, b64.EncodeToString(salt), b64.EncodeToString(key)), nil
+}
+
+// Verify checks password against a hash produced by Hash or by bcrypt. It
+// returns ErrMismatch when the password is wrong.
+func Verify(password, hash string) error {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return verifyArgon2id(password, hash)
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrMismatch
+		}
+		return err
+	default:
+		return ErrUnknownHash
+	}
+}
+
+// verifyArgon2id recomputes an argon2id hash with the parameters and salt
+// stored in it and compares the keys in constant time.
+func verifyArgon2id(password, hash string) error {
+	// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return ErrUnknownHash
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return ErrUnknownHash
+	}
+	salt, err := b64.DecodeString(parts[4])
+	if err != nil {
+		return ErrUnknownHash
+	}
+	key, err := b64.DecodeString(parts[5])
+	if err != nil {
+		return ErrUnknownHash
+	}
+
+	other := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashAndVerify(t *testing.T) {
+	hash, err := Hash("correct horse")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$"))
+
+	// Salts are random, so the same password never hashes the same way twice
+	other, err := Hash("correct horse")
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, other)
+
+	assert.NoError(t, Verify("correct horse", hash))
+	assert.ErrorIs(t, Verify("battery staple", hash), ErrMismatch)
+}
+
+func TestVerify_Bcrypt(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	assert.NoError(t, Verify("correct horse", string(hash)))
+	assert.ErrorIs(t, Verify("battery staple", string(hash)), ErrMismatch)
+}
+
+func TestVerify_UnknownHash(t *testing.T) {
+	for _, hash := range []string{"", "plain", "$argon2id$v=19$m=65536,t=3,p=4$salt", "$argon2id$v=16$m=65536,t=3,p=4$c2FsdA$a2V5", "$argon2id$v=19$m=65536,t=3,p=4$!!$a2V5"} {
+		assert.ErrorIs(t, Verify("password", hash), ErrUnknownHash, hash)
+	}
+}
@@ -0,0 +1,128 @@
+// Package policy decides what a principal may do. The services consult it
+// before every change, so the rules hold whichever transport a request
+// comes in by.
+package policy
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"fmt"
+)
+
+// Action is something a principal may be allowed to do.
+type Action string
+
+// Actions on articles, comments and users
+const (
+	// ViewArticle is reading an article. Published articles can be read by
+	// anyone, even anonymously.
+	ViewArticle Action = "view_article"
+	// ViewAllArticles is listing unpublished articles of every author.
+	ViewAllArticles Action = "view_all_articles"
+	CreateArticle   Action = "create_article"
+	EditArticle     Action = "edit_article"
+	DeleteArticle   Action = "delete_article"
+	SubmitArticle   Action = "submit_article"
+	PublishArticle  Action = "publish_article"
+	ArchiveArticle  Action = "archive_article"
+	// AssignAuthor is making someone else the author of an article.
+	AssignAuthor Action = "assign_author"
+	ManageUsers  Action = "manage_users"
+	// ManageAPIKeys is issuing, listing and revoking one's own API keys.
+	ManageAPIKeys Action = "manage_api_keys"
+	// PostComment is commenting on an article one can see, and editing and
+	// deleting one's own comments.
+	PostComment Action = "post_comment"
+	// ModerateComments is deleting the comments of others.
+	ModerateComments Action = "moderate_comments"
+	// ManageTaxonomy is renaming and merging tags and editing categories.
+	ManageTaxonomy Action = "manage_taxonomy"
+	// UploadMedia is uploading images and other files for articles.
+	UploadMedia Action = "upload_media"
+)
+
+// rule grants an action to every signed in user with one of roles, and to
+// the author of the article when own is set. Requests made with an API key
+// also need the key to have scope, or ownScope when the article is the
+// key owner's own.
+type rule struct {
+	roles    []string
+	own      bool
+	scope    string
+	ownScope string
+}
+
+var (
+	anyone  = []string{models.RoleAuthor, models.RoleEditor, models.RoleAdmin}
+	editors = []string{models.RoleEditor, models.RoleAdmin}
+	admins  = []string{models.RoleAdmin}
+)
+
+// rules is the permission matrix. Authors may work on their own articles,
+// editors on every article and decide what gets published and which
+// comments stay, and admins can do everything editors can and manage users,
+// tags and categories.
+var rules = map[Action]rule{
+	// Keys that may write articles may read their own back too
+	ViewArticle:      {roles: editors, own: true, scope: models.ScopeArticlesRead, ownScope: models.ScopeArticlesWrite},
+	ViewAllArticles:  {roles: editors, scope: models.ScopeArticlesRead},
+	CreateArticle:    {roles: anyone, scope: models.ScopeArticlesWrite},
+	EditArticle:      {roles: editors, own: true, scope: models.ScopeArticlesWrite},
+	DeleteArticle:    {roles: editors, own: true, scope: models.ScopeArticlesWrite},
+	SubmitArticle:    {roles: editors, own: true, scope: models.ScopeArticlesWrite},
+	PublishArticle:   {roles: editors, scope: models.ScopeArticlesWrite},
+	ArchiveArticle:   {roles: editors, scope: models.ScopeArticlesWrite},
+	AssignAuthor:     {roles: editors, scope: models.ScopeArticlesWrite},
+	ManageUsers:      {roles: admins, scope: models.ScopeAdmin},
+	ManageAPIKeys:    {roles: anyone, scope: models.ScopeAdmin},
+	PostComment:      {roles: anyone, scope: models.ScopeArticlesWrite},
+	ModerateComments: {roles: editors, scope: models.ScopeArticlesWrite},
+	ManageTaxonomy:   {roles: admins, scope: models.ScopeAdmin},
+	UploadMedia:      {roles: anyone, scope: models.ScopeArticlesWrite},
+}
+
+// Can reports whether actor may take action on article, which is nil for
+// actions that are not about one article.
+func Can(actor models.Principal, action Action, article *models.Article) bool {
+	return Authorize(actor, action, article) == nil
+}
+
+// Authorize returns nil when actor may take action on article. Anonymous
+// actors are unauthorized, signed in ones without permission forbidden.
+func Authorize(actor models.Principal, action Action, article *models.Article) error {
+	if action == ViewArticle && article != nil && article.Status == models.StatusPublished {
+		return nil
+	}
+	if actor.Anonymous() {
+		return apperrors.Unauthorized(appconst.Unauthenticated, nil)
+	}
+
+	r, ok := rules[action]
+	if !ok || !r.allows(actor, article) {
+		return apperrors.Forbidden(appconst.Forbidden, nil)
+	}
+	if !actor.HasScope(r.scope) && !(r.ownScope != "" && owns(actor, article) && actor.HasScope(r.ownScope)) {
+		return apperrors.Forbidden(fmt.Sprintf(appconst.Missingscope, r.scope), nil)
+	}
+	return nil
+}
+
+// allows reports whether the role of actor, or writing article, grants
+// the rule.
+func (r rule) allows(actor models.Principal, article *models.Article) bool {
+	if r.own && owns(actor, article) {
+		return true
+	}
+	for _, role := range r.roles {
+		if role == actor.Role {
+			return true
+		}
+	}
+	return false
+}
+
+// owns reports whether actor wrote article.
+func owns(actor models.Principal, article *models.Article) bool {
+	return article != nil && article.AuthorID == actor.UserID
+}
@@ -0,0 +1,133 @@
+package policy
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	anonymous = models.Principal{}
+	author    = models.Principal{UserID: 1, Username: "ada", Role: models.RoleAuthor}
+	other     = models.Principal{UserID: 2, Username: "grace", Role: models.RoleAuthor}
+	editor    = models.Principal{UserID: 3, Username: "edith", Role: models.RoleEditor}
+	admin     = models.Principal{UserID: 4, Username: "root", Role: models.RoleAdmin}
+)
+
+func TestCan(t *testing.T) {
+	draft := &models.Article{ID: 1, AuthorID: 1, Status: models.StatusDraft}
+	published := &models.Article{ID: 2, AuthorID: 1, Status: models.StatusPublished}
+
+	// Who may take each action, on an article written by author where there
+	// is one. The columns are anonymous, author, other author, editor and
+	// admin.
+	testCases := []struct {
+		action  Action
+		article *models.Article
+		allowed [5]bool
+	}{
+		{action: ViewArticle, article: draft, allowed: [5]bool{false, true, false, true, true}},
+		{action: ViewArticle, article: published, allowed: [5]bool{true, true, true, true, true}},
+		{action: ViewAllArticles, allowed: [5]bool{false, false, false, true, true}},
+		{action: CreateArticle, allowed: [5]bool{false, true, true, true, true}},
+		{action: EditArticle, article: draft, allowed: [5]bool{false, true, false, true, true}},
+		{action: EditArticle, article: published, allowed: [5]bool{false, true, false, true, true}},
+		{action: DeleteArticle, article: draft, allowed: [5]bool{false, true, false, true, true}},
+		{action: SubmitArticle, article: draft, allowed: [5]bool{false, true, false, true, true}},
+		{action: PublishArticle, article: draft, allowed: [5]bool{false, false, false, true, true}},
+		{action: ArchiveArticle, article: draft, allowed: [5]bool{false, false, false, true, true}},
+		{action: AssignAuthor, article: draft, allowed: [5]bool{false, false, false, true, true}},
+		{action: ManageUsers, allowed: [5]bool{false, false, false, false, true}},
+		{action: ManageAPIKeys, allowed: [5]bool{false, true, true, true, true}},
+		{action: PostComment, allowed: [5]bool{false, true, true, true, true}},
+		{action: ModerateComments, allowed: [5]bool{false, false, false, true, true}},
+		{action: ManageTaxonomy, allowed: [5]bool{false, false, false, false, true}},
+		{action: UploadMedia, allowed: [5]bool{false, true, true, true, true}},
+		{action: "unknown", article: draft, allowed: [5]bool{false, false, false, false, false}},
+	}
+
+	actors := []struct {
+		name      string
+		principal models.Principal
+	}{
+		{"anonymous", anonymous},
+		{"author", author},
+		{"other author", other},
+		{"editor", editor},
+		{"admin", admin},
+	}
+
+	for _, testCase := range testCases {
+		for i, actor := range actors {
+			name := string(testCase.action) + " by " + actor.name
+			if testCase.article != nil {
+				name += " on " + testCase.article.Status
+			}
+			t.Run(name, func(t *testing.T) {
+				assert.Equal(t, testCase.allowed[i], Can(actor.principal, testCase.action, testCase.article))
+			})
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	draft := &models.Article{ID: 1, AuthorID: 1, Status: models.StatusDraft}
+
+	assert.NoError(t, Authorize(editor, PublishArticle, draft))
+	assert.ErrorIs(t, Authorize(anonymous, PublishArticle, draft), apperrors.ErrUnauthorized)
+	assert.ErrorIs(t, Authorize(author, PublishArticle, draft), apperrors.ErrForbidden)
+	// A user without a known role only has the rights of an author over
+	// their own articles
+	assert.NoError(t, Authorize(models.Principal{UserID: 1, Role: "guest"}, EditArticle, draft))
+	assert.ErrorIs(t, Authorize(models.Principal{UserID: 1, Role: "guest"}, CreateArticle, nil), apperrors.ErrForbidden)
+}
+
+func TestAuthorize_Scopes(t *testing.T) {
+	draft := &models.Article{ID: 1, AuthorID: 1, Status: models.StatusDraft}
+	published := &models.Article{ID: 2, AuthorID: 1, Status: models.StatusPublished}
+
+	// keyOf is a principal authenticated with an API key of a user
+	keyOf := func(user models.Principal, scopes ...string) models.Principal {
+		user.Scopes = scopes
+		return user
+	}
+
+	testCases := []struct {
+		name    string
+		actor   models.Principal
+		action  Action
+		article *models.Article
+		allowed bool
+	}{
+		{name: "Read key sees the author's draft", actor: keyOf(author, models.ScopeArticlesRead), action: ViewArticle, article: draft, allowed: true},
+		{name: "Write key sees the author's own draft", actor: keyOf(author, models.ScopeArticlesWrite), action: ViewArticle, article: draft, allowed: true},
+		{name: "Write key of an editor can't see others' drafts", actor: keyOf(editor, models.ScopeArticlesWrite), action: ViewArticle, article: draft},
+		{name: "Write key can't list every article", actor: keyOf(editor, models.ScopeArticlesWrite), action: ViewAllArticles},
+		{name: "Key without scopes reads published articles", actor: keyOf(author), action: ViewArticle, article: published, allowed: true},
+		{name: "Write key edits the author's draft", actor: keyOf(author, models.ScopeArticlesWrite), action: EditArticle, article: draft, allowed: true},
+		{name: "Read key can't edit", actor: keyOf(author, models.ScopeArticlesRead), action: EditArticle, article: draft},
+		{name: "Write key can't do more than the role", actor: keyOf(author, models.ScopeArticlesWrite), action: PublishArticle, article: draft},
+		{name: "Admin scope of an author", actor: keyOf(author, models.ScopeAdmin), action: ManageUsers},
+		{name: "Admin key of an admin", actor: keyOf(admin, models.ScopeAdmin), action: ManageUsers, allowed: true},
+		{name: "Write key of an admin", actor: keyOf(admin, models.ScopeArticlesWrite), action: ManageUsers},
+		{name: "Keys manage keys with the admin scope only", actor: keyOf(author, models.ScopeArticlesWrite), action: ManageAPIKeys},
+		{name: "Signed in users manage their keys", actor: author, action: ManageAPIKeys, allowed: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := Authorize(testCase.actor, testCase.action, testCase.article)
+			if testCase.allowed {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, apperrors.ErrForbidden)
+			}
+		})
+	}
+
+	// A missing scope is named so the key can be fixed
+	message, _ := apperrors.Message(Authorize(keyOf(author, models.ScopeArticlesRead), EditArticle, draft))
+	assert.Equal(t, "the API key does not have the articles:write scope", message)
+}
@@ -0,0 +1,166 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+)
+
+// apiKeyColumns are the columns of an API key, in the order of apiKeyFields
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, created_at, last_used_at, revoked_at`
+
+// apiKeyFields returns the scan destinations for apiKeyColumns. Scopes are
+// stored separated by spaces and read into scopes, to be split by
+// splitScopes.
+func apiKeyFields(key *models.APIKey, scopes *string) []interface{} {
+	return []interface{}{
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		scopes,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	}
+}
+
+func splitScopes(scopes string) []string {
+	return strings.Fields(scopes)
+}
+
+// Store a new API key
+func (m *PostgresDBRepo) CreateAPIKey(key *models.APIKey) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, key.UserID, key.Name, key.Prefix, key.KeyHash, strings.Join(key.Scopes, " ")).
+		Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Retrieve the API keys of a user, newest first, revoked ones included
+func (m *PostgresDBRepo) APIKeys(userID int) ([]models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + apiKeyColumns + `
+        FROM
+            api_keys
+        WHERE
+            user_id = $1
+        ORDER BY
+            created_at DESC, id DESC
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	keys := []models.APIKey{}
+	for rows.Next() {
+		var key models.APIKey
+		var scopes string
+		if err := rows.Scan(apiKeyFields(&key, &scopes)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		key.Scopes = splitScopes(scopes)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return keys, nil
+}
+
+// Retrieve one API key
+func (m *PostgresDBRepo) OneAPIKey(id int) (*models.APIKey, error) {
+	return m.oneAPIKey(`
+        SELECT
+            `+apiKeyColumns+`
+        FROM
+            api_keys
+        WHERE
+            id = $1
+    `, id)
+}
+
+// RevokeAPIKey revokes an API key for good. Revoking a revoked key keeps
+// the time it was first revoked.
+func (m *PostgresDBRepo) RevokeAPIKey(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE api_keys
+        SET revoked_at = COALESCE(revoked_at, now())
+        WHERE id = $1
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return apperrors.NotFound(appconst.Noapikey, sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// UseAPIKey records that an API key was used and returns it. Revoked and
+// unknown keys are not found.
+func (m *PostgresDBRepo) UseAPIKey(hash string) (*models.APIKey, error) {
+	return m.oneAPIKey(`
+        UPDATE api_keys
+        SET last_used_at = now()
+        WHERE key_hash = $1
+            AND revoked_at IS NULL
+        RETURNING `+apiKeyColumns+`
+    `, hash)
+}
+
+func (m *PostgresDBRepo) oneAPIKey(query string, arg interface{}) (*models.APIKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var key models.APIKey
+	var scopes string
+	err := m.DB.QueryRowContext(ctx, query, arg).Scan(apiKeyFields(&key, &scopes)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Noapikey, err)
+			return nil, apperrors.NotFound(appconst.Noapikey, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	key.Scopes = splitScopes(scopes)
+
+	return &key, nil
+}
@@ -0,0 +1,117 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyRowColumns = []string{"id", "user_id", "name", "prefix", "key_hash", "scopes", "created_at", "last_used_at", "revoked_at"}
+
+func TestCreateAPIKey(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO api_keys \\(user_id, name, prefix, key_hash, scopes\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id, created_at").
+		WithArgs(4, "ci", "ak_0123abcd", "hash", "articles:read articles:write").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+	key := &models.APIKey{UserID: 4, Name: "ci", Prefix: "ak_0123abcd", KeyHash: "hash", Scopes: []string{models.ScopeArticlesRead, models.ScopeArticlesWrite}}
+
+	assert.NoError(t, repo.CreateAPIKey(key))
+	assert.Equal(t, 7, key.ID)
+	assert.Equal(t, &stamp, key.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeys(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, user_id, name, prefix, key_hash, scopes, created_at, last_used_at, revoked_at FROM api_keys WHERE user_id = \\$1 ORDER BY created_at DESC, id DESC").
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).
+			AddRow(8, 4, "deploy", "ak_89abcdef", "hash2", "admin", stamp, nil, stamp).
+			AddRow(7, 4, "ci", "ak_0123abcd", "hash1", "articles:read articles:write", stamp, stamp, nil))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	keys, err := repo.APIKeys(4)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.APIKey{
+		{ID: 8, UserID: 4, Name: "deploy", Prefix: "ak_89abcdef", KeyHash: "hash2", Scopes: []string{models.ScopeAdmin}, CreatedAt: &stamp, RevokedAt: &stamp},
+		{ID: 7, UserID: 4, Name: "ci", Prefix: "ak_0123abcd", KeyHash: "hash1", Scopes: []string{models.ScopeArticlesRead, models.ScopeArticlesWrite}, CreatedAt: &stamp, LastUsedAt: &stamp},
+	}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOneAPIKey(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "SELECT id, user_id, name, prefix, key_hash, scopes, created_at, last_used_at, revoked_at FROM api_keys WHERE id = \\$1"
+	mock.ExpectQuery(query).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).AddRow(7, 4, "ci", "ak_0123abcd", "hash", "articles:read", stamp, nil, nil))
+	mock.ExpectQuery(query).
+		WithArgs(8).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	key, err := repo.OneAPIKey(7)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.APIKey{ID: 7, UserID: 4, Name: "ci", Prefix: "ak_0123abcd", KeyHash: "hash", Scopes: []string{models.ScopeArticlesRead}, CreatedAt: &stamp}, key)
+
+	_, err = repo.OneAPIKey(8)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE api_keys SET revoked_at = COALESCE\\(revoked_at, now\\(\\)\\) WHERE id = \\$1"
+	mock.ExpectExec(query).
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs(8).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.RevokeAPIKey(7))
+	assert.ErrorIs(t, repo.RevokeAPIKey(8), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseAPIKey(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// Revoked keys can not be used
+	query := "UPDATE api_keys SET last_used_at = now\\(\\) WHERE key_hash = \\$1 AND revoked_at IS NULL RETURNING id, user_id, name, prefix, key_hash, scopes, created_at, last_used_at, revoked_at"
+	mock.ExpectQuery(query).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).AddRow(7, 4, "ci", "ak_0123abcd", "hash", "articles:read", stamp, stamp, nil))
+	mock.ExpectQuery(query).
+		WithArgs("revoked").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	key, err := repo.UseAPIKey("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.APIKey{ID: 7, UserID: 4, Name: "ci", Prefix: "ak_0123abcd", KeyHash: "hash", Scopes: []string{models.ScopeArticlesRead}, CreatedAt: &stamp, LastUsedAt: &stamp}, key)
+
+	_, err = repo.UseAPIKey("revoked")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,375 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// CommentRepo stores the comments on articles. PostgresDBRepo implements
+// it next to the article queries.
+type CommentRepo interface {
+	CreateComment(comment *models.Comment) error
+	OneComment(id int) (*models.Comment, error)
+	ArticleComments(articleID int, params models.ListParams) (*models.CommentPage, error)
+	CommentsByArticles(articleIDs []int, limit int) ([]models.Comment, error)
+	UpdateComment(comment *models.Comment) error
+	DeleteComment(id int) error
+	CommentQueue(status string, params models.ListParams) (*models.CommentPage, error)
+	SetCommentStatus(id int, status, moderatedBy string) (*models.Comment, error)
+}
+
+// commentColumns are the columns of a comment joined with its author, in
+// the order of commentFields
+const commentColumns = `c.id, c.article_id, c.parent_id, c.author_id, u.username, c.depth, c.body, c.deleted_at IS NOT NULL, c.status, c.spam_score, c.spam_reasons, COALESCE(c.moderated_by, ''), c.moderated_at, c.created_at, c.updated_at`
+
+// commentFields returns the scan destinations for commentColumns. The spam
+// reasons are stored separated by spaces and read into reasons, to be split
+// by strings.Fields.
+func commentFields(comment *models.Comment, reasons *string) []interface{} {
+	return []interface{}{
+		&comment.ID,
+		&comment.ArticleID,
+		&comment.ParentID,
+		&comment.AuthorID,
+		&comment.Author,
+		&comment.Depth,
+		&comment.Body,
+		&comment.Deleted,
+		&comment.Status,
+		&comment.SpamScore,
+		reasons,
+		&comment.ModeratedBy,
+		&comment.ModeratedAt,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	}
+}
+
+// Create a new comment with its moderation status. Replies are one level
+// deeper than the comment they answer.
+func (m *PostgresDBRepo) CreateComment(comment *models.Comment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        INSERT INTO comments (article_id, parent_id, author_id, depth, body, status, spam_score, spam_reasons)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, created_at, updated_at
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, comment.ArticleID, comment.ParentID, comment.AuthorID, comment.Depth, comment.Body,
+		comment.Status, comment.SpamScore, strings.Join(comment.SpamReasons, " ")).
+		Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Retrieve one comment
+func (m *PostgresDBRepo) OneComment(id int) (*models.Comment, error) {
+	return m.oneComment(`
+        SELECT
+            `+commentColumns+`
+        FROM
+            comments c
+            JOIN users u ON u.id = c.author_id
+        WHERE
+            c.id = $1
+    `, id)
+}
+
+// Retrieve a page of the approved threads on an article. The page holds
+// Limit threads, oldest first, each with all of its approved replies; the
+// comments come depth first, replies oldest first.
+func (m *PostgresDBRepo) ArticleComments(articleID int, params models.ListParams) (*models.CommentPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        WITH RECURSIVE threads AS (
+            SELECT
+                id,
+                COUNT(*) OVER() AS total
+            FROM
+                comments
+            WHERE
+                article_id = $1
+                AND parent_id IS NULL
+                AND status = 'approved'
+            ORDER BY
+                created_at, id
+            LIMIT $2 OFFSET $3
+        ), thread AS (
+            SELECT
+                c.id,
+                ARRAY[ROW_NUMBER() OVER (ORDER BY c.created_at, c.id)] AS path,
+                threads.total
+            FROM
+                comments c
+                JOIN threads ON threads.id = c.id
+            UNION ALL
+            SELECT
+                c.id,
+                thread.path || c.id::bigint,
+                thread.total
+            FROM
+                comments c
+                JOIN thread ON c.parent_id = thread.id
+            WHERE
+                c.status = 'approved'
+        )
+        SELECT
+            ` + commentColumns + `,
+            thread.total
+        FROM
+            thread
+            JOIN comments c ON c.id = thread.id
+            JOIN users u ON u.id = c.author_id
+        ORDER BY
+            thread.path
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, articleID, params.Limit, params.Offset)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.CommentPage{Comments: []models.Comment{}}
+	threads := 0
+	for rows.Next() {
+		var comment models.Comment
+		var reasons string
+		if err := rows.Scan(append(commentFields(&comment, &reasons), &page.Total)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		comment.SpamReasons = strings.Fields(reasons)
+		if comment.ParentID == nil {
+			threads++
+		}
+		page.Comments = append(page.Comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	page.HasNext = params.Offset+threads < page.Total
+	page.HasPrev = params.Offset > 0
+
+	return page, nil
+}
+
+// Return the first limit approved comments on each of the articles in one
+// query, in the order they were made, with the article they are on first
+func (m *PostgresDBRepo) CommentsByArticles(articleIDs []int, limit int) ([]models.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + commentColumns + `
+        FROM (
+            SELECT
+                id,
+                ROW_NUMBER() OVER (PARTITION BY article_id ORDER BY created_at, id) AS n
+            FROM
+                comments
+            WHERE
+                article_id = ANY($1)
+                AND status = 'approved'
+        ) ranked
+            JOIN comments c ON c.id = ranked.id
+            JOIN users u ON u.id = c.author_id
+        WHERE
+            ranked.n <= $2
+        ORDER BY
+            c.article_id, c.created_at, c.id
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(articleIDs), limit)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		var comment models.Comment
+		var reasons string
+		if err := rows.Scan(commentFields(&comment, &reasons)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		comment.SpamReasons = strings.Fields(reasons)
+		comments = append(comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return comments, nil
+}
+
+// Change the body and moderation status of a comment that is not deleted
+func (m *PostgresDBRepo) UpdateComment(comment *models.Comment) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE comments
+        SET body = $2, status = $3, spam_score = $4, spam_reasons = $5
+        WHERE id = $1
+            AND deleted_at IS NULL
+        RETURNING updated_at
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, comment.ID, comment.Body, comment.Status, comment.SpamScore, strings.Join(comment.SpamReasons, " ")).
+		Scan(&comment.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Nocomment, err)
+			return apperrors.NotFound(appconst.Nocomment, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// DeleteComment deletes a comment without replies. A comment with replies
+// is kept in their thread with an empty body and marked deleted instead.
+func (m *PostgresDBRepo) DeleteComment(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        WITH replied AS (
+            UPDATE comments
+            SET body = '', deleted_at = COALESCE(deleted_at, now())
+            WHERE id = $1
+                AND EXISTS (SELECT 1 FROM comments WHERE parent_id = $1)
+            RETURNING id
+        )
+        DELETE FROM comments
+        WHERE id = $1
+            AND NOT EXISTS (SELECT 1 FROM comments WHERE parent_id = $1)
+        RETURNING id
+    `
+
+	// No row comes back when the comment was kept or does not exist
+	var deleted int
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(&deleted)
+	if err == sql.ErrNoRows {
+		_, err = m.OneComment(id)
+		return err
+	}
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Retrieve a page of the comments with a moderation status, oldest first.
+// Deleted comments are left out.
+func (m *PostgresDBRepo) CommentQueue(status string, params models.ListParams) (*models.CommentPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + commentColumns + `,
+            COUNT(*) OVER() AS total
+        FROM
+            comments c
+            JOIN users u ON u.id = c.author_id
+        WHERE
+            c.status = $1
+            AND c.deleted_at IS NULL
+        ORDER BY
+            c.created_at, c.id
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, status, params.Limit+1, params.Offset)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.CommentPage{Comments: []models.Comment{}}
+	for rows.Next() {
+		var comment models.Comment
+		var reasons string
+		if err := rows.Scan(append(commentFields(&comment, &reasons), &page.Total)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		comment.SpamReasons = strings.Fields(reasons)
+		page.Comments = append(page.Comments, comment)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if len(page.Comments) > params.Limit {
+		page.Comments = page.Comments[:params.Limit]
+		page.HasNext = true
+	}
+	page.HasPrev = params.Offset > 0
+
+	return page, nil
+}
+
+// Approve, reject or mark a comment that is not deleted as spam, and return
+// the moderated comment
+func (m *PostgresDBRepo) SetCommentStatus(id int, status, moderatedBy string) (*models.Comment, error) {
+	return m.oneComment(`
+        UPDATE comments c
+        SET status = $2, moderated_by = $3, moderated_at = now()
+        FROM users u
+        WHERE c.id = $1
+            AND c.deleted_at IS NULL
+            AND u.id = c.author_id
+        RETURNING `+commentColumns+`
+    `, id, status, moderatedBy)
+}
+
+func (m *PostgresDBRepo) oneComment(query string, args ...interface{}) (*models.Comment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var comment models.Comment
+	var reasons string
+	err := m.DB.QueryRowContext(ctx, query, args...).Scan(commentFields(&comment, &reasons)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Nocomment, err)
+			return nil, apperrors.NotFound(appconst.Nocomment, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	comment.SpamReasons = strings.Fields(reasons)
+
+	return &comment, nil
+}
@@ -0,0 +1,207 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var commentRowColumns = []string{"id", "article_id", "parent_id", "author_id", "username", "depth", "body", "deleted", "status", "spam_score", "spam_reasons", "moderated_by", "moderated_at", "created_at", "updated_at"}
+
+const (
+	commentSelect   = "SELECT c.id, c.article_id, c.parent_id, c.author_id, u.username, c.depth, c.body, c.deleted_at IS NOT NULL, c.status, c.spam_score, c.spam_reasons, COALESCE\\(c.moderated_by, ''\\), c.moderated_at, c.created_at, c.updated_at"
+	oneCommentQuery = commentSelect + " FROM comments c JOIN users u ON u.id = c.author_id WHERE c.id = \\$1"
+)
+
+func TestCreateComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	parent := 3
+	mock.ExpectQuery("INSERT INTO comments \\(article_id, parent_id, author_id, depth, body, status, spam_score, spam_reasons\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8\\) RETURNING id, created_at, updated_at").
+		WithArgs(1, &parent, 2, 1, "Reply", "pending", 0.6, "links new_account").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(4, stamp, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+	comment := &models.Comment{ArticleID: 1, ParentID: &parent, AuthorID: 2, Depth: 1, Body: "Reply", Status: models.CommentPending, SpamScore: 0.6, SpamReasons: []string{"links", "new_account"}}
+
+	assert.NoError(t, repo.CreateComment(comment))
+	assert.Equal(t, 4, comment.ID)
+	assert.Equal(t, &stamp, comment.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOneComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery(oneCommentQuery).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows(commentRowColumns).AddRow(4, 1, 3, 2, "grace", 1, "Reply", false, "approved", 0.6, "links new_account", "edith", stamp, stamp, stamp))
+	mock.ExpectQuery(oneCommentQuery).
+		WithArgs(5).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	comment, err := repo.OneComment(4)
+	assert.NoError(t, err)
+	parent := 3
+	assert.Equal(t, &models.Comment{ID: 4, ArticleID: 1, ParentID: &parent, AuthorID: 2, Author: "grace", Depth: 1, Body: "Reply", Status: models.CommentApproved,
+		SpamScore: 0.6, SpamReasons: []string{"links", "new_account"}, ModeratedBy: "edith", ModeratedAt: &stamp, CreatedAt: &stamp, UpdatedAt: &stamp}, comment)
+
+	_, err = repo.OneComment(5)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleComments(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// Approved threads are paged by their first comment and come with all
+	// approved replies, depth first
+	mock.ExpectQuery("WITH RECURSIVE threads AS \\( SELECT id, COUNT\\(\\*\\) OVER\\(\\) AS total FROM comments WHERE article_id = \\$1 AND parent_id IS NULL AND status = 'approved' ORDER BY created_at, id LIMIT \\$2 OFFSET \\$3 \\), thread AS \\(.+UNION ALL.+JOIN thread ON c.parent_id = thread.id WHERE c.status = 'approved' \\) SELECT .+ FROM thread JOIN comments c ON c.id = thread.id JOIN users u ON u.id = c.author_id ORDER BY thread.path").
+		WithArgs(1, 2, 2).
+		WillReturnRows(sqlmock.NewRows(append(commentRowColumns, "total")).
+			AddRow(5, 1, nil, 2, "grace", 0, "", true, "approved", 0, "", "", nil, stamp, stamp, 5).
+			AddRow(6, 1, 5, 1, "ada", 1, "Reply", false, "approved", 0, "", "", nil, stamp, stamp, 5).
+			AddRow(7, 1, nil, 1, "ada", 0, "Third", false, "approved", 0, "", "", nil, stamp, stamp, 5))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	page, err := repo.ArticleComments(1, models.ListParams{Limit: 2, Offset: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Comments, 3)
+	assert.True(t, page.Comments[0].Deleted)
+	assert.Equal(t, 1, page.Comments[1].Depth)
+	// Two of five threads were skipped and two are on the page
+	assert.Equal(t, models.PageInfo{Total: 5, HasNext: true, HasPrev: true}, page.PageInfo)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentsByArticles(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// The first approved comments of each article, numbered per article
+	mock.ExpectQuery(commentSelect+" FROM \\( SELECT id, ROW_NUMBER\\(\\) OVER \\(PARTITION BY article_id ORDER BY created_at, id\\) AS n FROM comments WHERE article_id = ANY\\(\\$1\\) AND status = 'approved' \\) ranked JOIN comments c ON c.id = ranked.id JOIN users u ON u.id = c.author_id WHERE ranked.n <= \\$2 ORDER BY c.article_id, c.created_at, c.id").
+		WithArgs("{1,2}", 2).
+		WillReturnRows(sqlmock.NewRows(commentRowColumns).
+			AddRow(5, 1, nil, 2, "grace", 0, "First", false, "approved", 0, "", "", nil, stamp, stamp).
+			AddRow(6, 1, 5, 1, "ada", 1, "Reply", false, "approved", 0.2, "links", "", nil, stamp, stamp).
+			AddRow(9, 2, nil, 1, "ada", 0, "Other", false, "approved", 0, "", "", nil, stamp, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	comments, err := repo.CommentsByArticles([]int{1, 2}, 2)
+	assert.NoError(t, err)
+	assert.Len(t, comments, 3)
+	assert.Equal(t, 2, comments[2].ArticleID)
+	assert.Equal(t, []string{"links"}, comments[1].SpamReasons)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE comments SET body = \\$2, status = \\$3, spam_score = \\$4, spam_reasons = \\$5 WHERE id = \\$1 AND deleted_at IS NULL RETURNING updated_at"
+	mock.ExpectQuery(query).
+		WithArgs(4, "Edited", "approved", 0.1, "").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(stamp))
+	mock.ExpectQuery(query).
+		WithArgs(5, "Edited", "approved", 0.1, "").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	comment := &models.Comment{ID: 4, Body: "Edited", Status: models.CommentApproved, SpamScore: 0.1}
+	assert.NoError(t, repo.UpdateComment(comment))
+	assert.Equal(t, &stamp, comment.UpdatedAt)
+
+	assert.ErrorIs(t, repo.UpdateComment(&models.Comment{ID: 5, Body: "Edited", Status: models.CommentApproved, SpamScore: 0.1}), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteComment(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// Comments with replies are kept with an empty body
+	query := "WITH replied AS \\( UPDATE comments SET body = '', deleted_at = COALESCE\\(deleted_at, now\\(\\)\\) WHERE id = \\$1 AND EXISTS \\(SELECT 1 FROM comments WHERE parent_id = \\$1\\) RETURNING id \\) DELETE FROM comments WHERE id = \\$1 AND NOT EXISTS \\(SELECT 1 FROM comments WHERE parent_id = \\$1\\) RETURNING id"
+
+	// Deleted
+	mock.ExpectQuery(query).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+	// Kept
+	mock.ExpectQuery(query).
+		WithArgs(5).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(oneCommentQuery).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(commentRowColumns).AddRow(5, 1, nil, 2, "grace", 0, "", true, "approved", 0, "", "", nil, stamp, stamp))
+	// Missing
+	mock.ExpectQuery(query).
+		WithArgs(6).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectQuery(oneCommentQuery).
+		WithArgs(6).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.DeleteComment(4))
+	assert.NoError(t, repo.DeleteComment(5))
+	assert.ErrorIs(t, repo.DeleteComment(6), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentQueue(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery(commentSelect+", COUNT\\(\\*\\) OVER\\(\\) AS total FROM comments c JOIN users u ON u.id = c.author_id WHERE c.status = \\$1 AND c.deleted_at IS NULL ORDER BY c.created_at, c.id LIMIT \\$2 OFFSET \\$3").
+		WithArgs("pending", 2, 0).
+		WillReturnRows(sqlmock.NewRows(append(commentRowColumns, "total")).
+			AddRow(4, 1, nil, 2, "grace", 0, "Cheap pills", false, "pending", 0.6, "blocked_words", "", nil, stamp, stamp, 2).
+			AddRow(5, 1, nil, 2, "grace", 0, "Casino", false, "pending", 0.6, "blocked_words", "", nil, stamp, stamp, 2))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	page, err := repo.CommentQueue(models.CommentPending, models.ListParams{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Comments, 1)
+	assert.Equal(t, []string{"blocked_words"}, page.Comments[0].SpamReasons)
+	assert.Equal(t, models.PageInfo{Total: 2, HasNext: true}, page.PageInfo)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetCommentStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE comments c SET status = \\$2, moderated_by = \\$3, moderated_at = now\\(\\) FROM users u WHERE c.id = \\$1 AND c.deleted_at IS NULL AND u.id = c.author_id RETURNING c.id, .+, c.updated_at"
+	mock.ExpectQuery(query).
+		WithArgs(4, "spam", "edith").
+		WillReturnRows(sqlmock.NewRows(commentRowColumns).AddRow(4, 1, nil, 2, "grace", 0, "Cheap pills", false, "spam", 0.6, "blocked_words", "edith", stamp, stamp, stamp))
+	mock.ExpectQuery(query).
+		WithArgs(5, "spam", "edith").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	comment, err := repo.SetCommentStatus(4, models.CommentSpam, "edith")
+	assert.NoError(t, err)
+	assert.Equal(t, models.CommentSpam, comment.Status)
+	assert.Equal(t, "edith", comment.ModeratedBy)
+
+	_, err = repo.SetCommentStatus(5, models.CommentSpam, "edith")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
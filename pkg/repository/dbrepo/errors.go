@@ -0,0 +1,70 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/jackc/pgconn"
+)
+
+// Postgres error codes and classes that map to a domain error.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgNotNullViolation     = "23502"
+	pgCheckViolation       = "23514"
+	pgQueryCanceled        = "57014"
+	pgConnectionException  = "08"
+	pgInsufficientResource = "53"
+	pgOperatorIntervention = "57P"
+)
+
+// translateError converts a database/sql or driver error into a domain error
+// so that callers never depend on the driver in use.
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperrors.NotFound(appconst.NoArticleforid, err)
+	}
+	if pgconn.Timeout(err) {
+		return apperrors.Timeout(appconst.Timeouterror, err)
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return apperrors.Unavailable(appconst.Unavailableerror, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch {
+		case pgErr.Code == pgUniqueViolation:
+			return apperrors.Conflict(appconst.Conflicterror, err)
+		case pgErr.Code == pgForeignKeyViolation, pgErr.Code == pgNotNullViolation, pgErr.Code == pgCheckViolation:
+			return apperrors.Validation(appconst.Validationerror, err)
+		case pgErr.Code == pgQueryCanceled:
+			return apperrors.Timeout(appconst.Timeouterror, err)
+		case strings.HasPrefix(pgErr.Code, pgConnectionException),
+			strings.HasPrefix(pgErr.Code, pgInsufficientResource),
+			strings.HasPrefix(pgErr.Code, pgOperatorIntervention):
+			return apperrors.Unavailable(appconst.Unavailableerror, err)
+		}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return apperrors.Timeout(appconst.Timeouterror, err)
+		}
+		return apperrors.Unavailable(appconst.Unavailableerror, err)
+	}
+
+	return err
+}
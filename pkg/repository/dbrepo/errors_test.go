@@ -0,0 +1,47 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedKind error
+	}{
+		{name: "No rows", err: sql.ErrNoRows, expectedKind: apperrors.ErrNotFound},
+		{name: "Context deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), expectedKind: apperrors.ErrTimeout},
+		{name: "Bad connection", err: driver.ErrBadConn, expectedKind: apperrors.ErrUnavailable},
+		{name: "Unique violation", err: &pgconn.PgError{Code: "23505"}, expectedKind: apperrors.ErrConflict},
+		{name: "Not null violation", err: &pgconn.PgError{Code: "23502"}, expectedKind: apperrors.ErrValidation},
+		{name: "Statement timeout", err: &pgconn.PgError{Code: "57014"}, expectedKind: apperrors.ErrTimeout},
+		{name: "Admin shutdown", err: &pgconn.PgError{Code: "57P01"}, expectedKind: apperrors.ErrUnavailable},
+		{name: "Connection failure", err: &pgconn.PgError{Code: "08006"}, expectedKind: apperrors.ErrUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			translated := translateError(test.err)
+
+			assert.ErrorIs(t, translated, test.expectedKind)
+			assert.ErrorIs(t, translated, test.err)
+		})
+	}
+}
+
+func TestTranslateErrorPassthrough(t *testing.T) {
+	err := errors.New("unexpected")
+
+	assert.Nil(t, translateError(nil))
+	assert.Equal(t, err, translateError(err))
+}
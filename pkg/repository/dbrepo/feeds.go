@@ -0,0 +1,58 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"context"
+	"fmt"
+	"log"
+)
+
+// Return up to limit published articles matching filter, the most recently
+// published first, for feeds
+func (m *PostgresDBRepo) LatestArticles(filter models.ArticleFilter, limit int) ([]models.Article, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var b queryBuilder
+	b.visibleTo(models.Principal{}, false)
+	b.filterArticles(filter)
+
+	query := fmt.Sprintf(`
+        SELECT
+            %s
+        FROM
+            articles
+        %s
+        ORDER BY
+            published_at DESC, id DESC
+        LIMIT %s
+    `, articleColumns, b.whereClause(), b.arg(limit))
+
+	rows, err := m.DB.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	articles := []models.Article{}
+	for rows.Next() {
+		var article models.Article
+		if err := rows.Scan(articleFields(&article)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		articles = append(articles, article)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if err := m.attachRelations(ctx, articlePointers(articles)...); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,42 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestArticles(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	rows := sqlmock.NewRows(articleRowColumns()).
+		AddRow(2, "Newer", "", "Ada", "published", stamp, stamp, stamp, nil, "Ada", "Ada", 1, 1, "newer", "<p>New</p>", nil).
+		AddRow(1, "Older", "", "Ada", "published", stamp, stamp, stamp, nil, "Ada", "Ada", 1, 1, "older", "<p>Old</p>", nil)
+
+	mock.ExpectQuery("SELECT id, title, .+ FROM articles WHERE status = \\$1 AND author = \\$2 AND EXISTS \\(SELECT 1 FROM article_tags .+ tags.slug = \\$3\\) ORDER BY published_at DESC, id DESC LIMIT \\$4").
+		WithArgs(models.StatusPublished, "Ada", "go", 20).
+		WillReturnRows(rows)
+	expectTaxonomy(mock, "{2,1}")
+
+	repo := &PostgresDBRepo{DB: db}
+	articles, err := repo.LatestArticles(models.ArticleFilter{Author: "Ada", Tag: "go"}, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, articles, 2)
+	assert.Equal(t, "Newer", articles[0].Title)
+	assert.Equal(t, "<p>New</p>", articles[0].ContentHTML)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectQuery("FROM articles WHERE status = \\$1 ORDER BY published_at DESC").
+		WithArgs(models.StatusPublished, 20).
+		WillReturnError(sql.ErrConnDone)
+
+	_, err = repo.LatestArticles(models.ArticleFilter{}, 20)
+	assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,330 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type MediaRepo interface {
+	CreateMedia(media *models.Media) error
+	OneMedia(id int) (*models.Media, error)
+	MediaByChecksum(checksum string) (*models.Media, error)
+	ClaimPendingMedia(limit int) ([]models.Media, error)
+	SaveMediaVariants(media *models.Media, variants []models.MediaVariant) error
+	SetMediaStatus(id int, status string) error
+	MediaVariant(mediaID, width int) (*models.MediaVariant, error)
+}
+
+// mediaColumns are the columns of a media file, in the order of mediaFields
+const mediaColumns = `id, checksum, content_type, size, filename, storage_key, uploaded_by, created_at, width, height, placeholder, status`
+
+// mediaFields returns the scan destinations for mediaColumns
+func mediaFields(media *models.Media) []interface{} {
+	return []interface{}{
+		&media.ID,
+		&media.Checksum,
+		&media.ContentType,
+		&media.Size,
+		&media.Filename,
+		&media.StorageKey,
+		&media.UploadedBy,
+		&media.CreatedAt,
+		&media.Width,
+		&media.Height,
+		&media.Placeholder,
+		&media.Status,
+	}
+}
+
+// Store the metadata of an uploaded file. A file with the same checksum is
+// a conflict.
+func (m *PostgresDBRepo) CreateMedia(media *models.Media) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        INSERT INTO media (checksum, content_type, size, filename, storage_key, uploaded_by, status)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id, created_at
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, media.Checksum, media.ContentType, media.Size, media.Filename, media.StorageKey, media.UploadedBy, media.Status).
+		Scan(&media.ID, &media.CreatedAt)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Retrieve one media file
+func (m *PostgresDBRepo) OneMedia(id int) (*models.Media, error) {
+	return m.oneMedia(`
+        SELECT
+            `+mediaColumns+`
+        FROM
+            media
+        WHERE
+            id = $1
+    `, id)
+}
+
+// Retrieve the media file with a checksum
+func (m *PostgresDBRepo) MediaByChecksum(checksum string) (*models.Media, error) {
+	return m.oneMedia(`
+        SELECT
+            `+mediaColumns+`
+        FROM
+            media
+        WHERE
+            checksum = $1
+    `, checksum)
+}
+
+func (m *PostgresDBRepo) oneMedia(query string, arg interface{}) (*models.Media, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var media models.Media
+	err := m.DB.QueryRowContext(ctx, query, arg).Scan(mediaFields(&media)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Nomedia, err)
+			return nil, apperrors.NotFound(appconst.Nomedia, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &media, nil
+}
+
+// ClaimPendingMedia marks up to limit images waiting to be processed as
+// processing and returns them, oldest first. Rows claimed by another
+// replica are skipped; claims older than ten minutes are taken to be
+// abandoned by a replica that stopped and are claimed again.
+func (m *PostgresDBRepo) ClaimPendingMedia(limit int) ([]models.Media, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE media
+        SET status = 'processing', claimed_at = now()
+        WHERE id IN (
+            SELECT id
+            FROM media
+            WHERE status = 'pending'
+                OR (status = 'processing' AND claimed_at < now() - interval '10 minutes')
+            ORDER BY id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING ` + mediaColumns + `
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var claimed []models.Media
+	for rows.Next() {
+		var media models.Media
+		if err := rows.Scan(mediaFields(&media)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		claimed = append(claimed, media)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return claimed, nil
+}
+
+// SaveMediaVariants replaces the variants of an image and stores its size
+// and placeholder, marking it ready.
+func (m *PostgresDBRepo) SaveMediaVariants(media *models.Media, variants []models.MediaVariant) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM media_variants WHERE media_id = $1`, media.ID); err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	for _, variant := range variants {
+		_, err := tx.ExecContext(ctx, `
+            INSERT INTO media_variants (media_id, width, height, content_type, size, storage_key)
+            VALUES ($1, $2, $3, $4, $5, $6)
+        `, media.ID, variant.Width, variant.Height, variant.ContentType, variant.Size, variant.StorageKey)
+		if err != nil {
+			log.Println(appconst.Queryerror, err)
+			return translateError(err)
+		}
+	}
+
+	query := `
+        UPDATE media
+        SET width = $1, height = $2, placeholder = $3, status = 'ready', claimed_at = NULL
+        WHERE id = $4
+    `
+
+	result, err := tx.ExecContext(ctx, query, media.Width, media.Height, media.Placeholder, media.ID)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	if err := checkAffected(result, appconst.Nomedia); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	media.Status = models.MediaReady
+	return nil
+}
+
+// SetMediaStatus sets the processing status of a media file, releasing
+// its claim
+func (m *PostgresDBRepo) SetMediaStatus(id int, status string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE media
+        SET status = $1, claimed_at = NULL
+        WHERE id = $2
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, status, id)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return checkAffected(result, appconst.Nomedia)
+}
+
+// Retrieve the variant of an image with a width
+func (m *PostgresDBRepo) MediaVariant(mediaID, width int) (*models.MediaVariant, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT media_id, width, height, content_type, size, storage_key
+        FROM media_variants
+        WHERE media_id = $1 AND width = $2
+    `
+
+	var variant models.MediaVariant
+	err := m.DB.QueryRowContext(ctx, query, mediaID, width).
+		Scan(&variant.MediaID, &variant.Width, &variant.Height, &variant.ContentType, &variant.Size, &variant.StorageKey)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Novariant, err)
+			return nil, apperrors.NotFound(appconst.Novariant, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	variant.URL = models.VariantURL(variant.MediaID, variant.Width)
+
+	return &variant, nil
+}
+
+// attachCovers reads the cover images of articles with their variants,
+// with one query for all of them. Articles without a cover cost no query.
+func (m *PostgresDBRepo) attachCovers(ctx context.Context, articles ...*models.Article) error {
+	var ids []int
+	for _, article := range articles {
+		if article.CoverID != nil {
+			ids = append(ids, *article.CoverID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `
+        SELECT media.id, media.width, media.height, media.placeholder,
+            media_variants.width, media_variants.height, media_variants.content_type, media_variants.size
+        FROM media LEFT JOIN media_variants ON media_variants.media_id = media.id
+        WHERE media.id = ANY($1)
+        ORDER BY media.id, media_variants.width
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	defer rows.Close()
+
+	covers := make(map[int]*models.Cover)
+	for rows.Next() {
+		var id int
+		var cover models.Cover
+		var width, height, size sql.NullInt64
+		var contentType sql.NullString
+		if err := rows.Scan(&id, &cover.Width, &cover.Height, &cover.Placeholder, &width, &height, &contentType, &size); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return translateError(err)
+		}
+		if covers[id] == nil {
+			cover.Variants = []models.MediaVariant{}
+			covers[id] = &cover
+		}
+		if width.Valid {
+			covers[id].Variants = append(covers[id].Variants, models.MediaVariant{
+				URL:         models.VariantURL(id, int(width.Int64)),
+				Width:       int(width.Int64),
+				Height:      int(height.Int64),
+				ContentType: contentType.String,
+				Size:        size.Int64,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return translateError(err)
+	}
+
+	for id, cover := range covers {
+		cover.URL = models.MediaURL(id)
+		srcset := make([]string, len(cover.Variants))
+		for i, variant := range cover.Variants {
+			srcset[i] = fmt.Sprintf("%s %dw", variant.URL, variant.Width)
+			cover.URL = variant.URL
+		}
+		cover.Srcset = strings.Join(srcset, ", ")
+	}
+	for _, article := range articles {
+		if article.CoverID != nil {
+			article.Cover = covers[*article.CoverID]
+		}
+	}
+
+	return nil
+}
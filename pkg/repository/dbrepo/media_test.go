@@ -0,0 +1,213 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgconn"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var mediaRowColumns = []string{"id", "checksum", "content_type", "size", "filename", "storage_key", "uploaded_by", "created_at", "width", "height", "placeholder", "status"}
+
+func TestCreateMedia(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "INSERT INTO media \\(checksum, content_type, size, filename, storage_key, uploaded_by, status\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\) RETURNING id, created_at"
+	mock.ExpectQuery(query).
+		WithArgs("abc123", "image/png", int64(68), "dot.png", "ab/abc123", 4, "pending").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(3, stamp))
+	mock.ExpectQuery(query).
+		WithArgs("abc123", "image/png", int64(68), "dot.png", "ab/abc123", 4, "pending").
+		WillReturnError(&pgconn.PgError{Code: pgUniqueViolation})
+
+	repo := &PostgresDBRepo{DB: db}
+	uploader := 4
+	media := &models.Media{Checksum: "abc123", ContentType: "image/png", Size: 68, Filename: "dot.png", StorageKey: "ab/abc123", UploadedBy: &uploader, Status: models.MediaPending}
+
+	assert.NoError(t, repo.CreateMedia(media))
+	assert.Equal(t, 3, media.ID)
+	assert.Equal(t, &stamp, media.CreatedAt)
+
+	assert.ErrorIs(t, repo.CreateMedia(media), apperrors.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOneMedia(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "SELECT id, checksum, content_type, size, filename, storage_key, uploaded_by, created_at, width, height, placeholder, status FROM media WHERE id = \\$1"
+	mock.ExpectQuery(query).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(mediaRowColumns).AddRow(3, "abc123", "image/png", 68, "dot.png", "ab/abc123", nil, stamp, 2, 1, "LEHV6n", "ready"))
+	mock.ExpectQuery(query).
+		WithArgs(4).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	media, err := repo.OneMedia(3)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Media{ID: 3, Checksum: "abc123", ContentType: "image/png", Size: 68, Filename: "dot.png", StorageKey: "ab/abc123", CreatedAt: &stamp, Width: 2, Height: 1, Placeholder: "LEHV6n", Status: models.MediaReady}, media)
+
+	_, err = repo.OneMedia(4)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaByChecksum(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, checksum, content_type, size, filename, storage_key, uploaded_by, created_at, width, height, placeholder, status FROM media WHERE checksum = \\$1").
+		WithArgs("abc123").
+		WillReturnRows(sqlmock.NewRows(mediaRowColumns).AddRow(3, "abc123", "image/png", 68, "dot.png", "ab/abc123", 4, stamp, 0, 0, "", "pending"))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	media, err := repo.MediaByChecksum("abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, media.ID)
+	assert.Equal(t, 4, *media.UploadedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimPendingMedia(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE media SET status = 'processing', claimed_at = now\\(\\) WHERE id IN \\( SELECT id FROM media WHERE status = 'pending' " +
+		"OR \\(status = 'processing' AND claimed_at < now\\(\\) - interval '10 minutes'\\) ORDER BY id LIMIT \\$1 FOR UPDATE SKIP LOCKED \\) RETURNING id, checksum, .+, status").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows(mediaRowColumns).
+			AddRow(3, "abc123", "image/png", 68, "dot.png", "ab/abc123", 4, stamp, 0, 0, "", "processing").
+			AddRow(5, "def456", "image/jpeg", 90, "dot.jpg", "de/def456", 4, stamp, 0, 0, "", "processing"))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	claimed, err := repo.ClaimPendingMedia(10)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 2)
+	assert.Equal(t, "de/def456", claimed[1].StorageKey)
+	assert.Equal(t, models.MediaProcessing, claimed[1].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveMediaVariants(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM media_variants WHERE media_id = \\$1").
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	insert := "INSERT INTO media_variants \\(media_id, width, height, content_type, size, storage_key\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6\\)"
+	mock.ExpectExec(insert).
+		WithArgs(3, 320, 180, "image/jpeg", int64(900), "ab/abc123-320").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(insert).
+		WithArgs(3, 640, 360, "image/jpeg", int64(2400), "ab/abc123-640").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE media SET width = \\$1, height = \\$2, placeholder = \\$3, status = 'ready', claimed_at = NULL WHERE id = \\$4").
+		WithArgs(640, 360, "LEHV6n", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := &PostgresDBRepo{DB: db}
+	media := &models.Media{ID: 3, Width: 640, Height: 360, Placeholder: "LEHV6n", Status: models.MediaProcessing}
+
+	err := repo.SaveMediaVariants(media, []models.MediaVariant{
+		{Width: 320, Height: 180, ContentType: "image/jpeg", Size: 900, StorageKey: "ab/abc123-320"},
+		{Width: 640, Height: 360, ContentType: "image/jpeg", Size: 2400, StorageKey: "ab/abc123-640"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, models.MediaReady, media.Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetMediaStatus(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE media SET status = \\$1, claimed_at = NULL WHERE id = \\$2"
+	mock.ExpectExec(query).
+		WithArgs("failed", 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs("pending", 4).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.SetMediaStatus(3, models.MediaFailed))
+	assert.ErrorIs(t, repo.SetMediaStatus(4, models.MediaPending), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMediaVariant(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "SELECT media_id, width, height, content_type, size, storage_key FROM media_variants WHERE media_id = \\$1 AND width = \\$2"
+	mock.ExpectQuery(query).
+		WithArgs(3, 320).
+		WillReturnRows(sqlmock.NewRows([]string{"media_id", "width", "height", "content_type", "size", "storage_key"}).AddRow(3, 320, 180, "image/jpeg", 900, "ab/abc123-320"))
+	mock.ExpectQuery(query).
+		WithArgs(3, 321).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	variant, err := repo.MediaVariant(3, 320)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.MediaVariant{URL: "/media/3/variants/320", Width: 320, Height: 180, ContentType: "image/jpeg", Size: 900, MediaID: 3, StorageKey: "ab/abc123-320"}, variant)
+
+	_, err = repo.MediaVariant(3, 321)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachCovers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT media.id, media.width, media.height, media.placeholder, media_variants.width, media_variants.height, media_variants.content_type, media_variants.size " +
+		"FROM media LEFT JOIN media_variants ON media_variants.media_id = media.id WHERE media.id = ANY\\(\\$1\\) ORDER BY media.id, media_variants.width").
+		WithArgs(pq.Array([]int{3, 5, 3})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "width", "height", "placeholder", "variant_width", "variant_height", "content_type", "size"}).
+			AddRow(3, 1280, 720, "LEHV6n", 320, 180, "image/jpeg", 900).
+			AddRow(3, 1280, 720, "LEHV6n", 640, 360, "image/jpeg", 2400).
+			AddRow(5, 0, 0, "", nil, nil, nil, nil))
+
+	repo := &PostgresDBRepo{DB: db}
+	three, five := 3, 5
+	articles := []models.Article{{ID: 1, CoverID: &three}, {ID: 2}, {ID: 3, CoverID: &five}, {ID: 4, CoverID: &three}}
+
+	assert.NoError(t, repo.attachCovers(context.Background(), articlePointers(articles)...))
+	assert.Equal(t, &models.Cover{
+		URL:         "/media/3/variants/640",
+		Width:       1280,
+		Height:      720,
+		Placeholder: "LEHV6n",
+		Variants: []models.MediaVariant{
+			{URL: "/media/3/variants/320", Width: 320, Height: 180, ContentType: "image/jpeg", Size: 900},
+			{URL: "/media/3/variants/640", Width: 640, Height: 360, ContentType: "image/jpeg", Size: 2400},
+		},
+		Srcset: "/media/3/variants/320 320w, /media/3/variants/640 640w",
+	}, articles[0].Cover)
+	assert.Nil(t, articles[1].Cover)
+	// Until its variants are made a cover is the uploaded image
+	assert.Equal(t, &models.Cover{URL: "/media/5", Variants: []models.MediaVariant{}}, articles[2].Cover)
+	assert.Equal(t, articles[0].Cover, articles[3].Cover)
+
+	// Articles without a cover need no query
+	assert.NoError(t, repo.attachCovers(context.Background(), &articles[1]))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
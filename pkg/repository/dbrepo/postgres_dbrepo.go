@@ -2,6 +2,8 @@ package dbrepo
 
 import (
 	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/markdown"
 	"backend/pkg/models"
 	"context"
 	"database/sql"
@@ -14,78 +16,212 @@ type PostgresDBRepo struct {
 	DB *sql.DB
 }
 type DatabaseRepo interface {
+	UserRepo
+	CommentRepo
+	TaxonomyRepo
+	MediaRepo
 	Connection() *sql.DB
-	CreateTable()
-	AllArticles() ([]models.Article, error)
+	AllArticles(params models.ListParams) (*models.ArticlePage, error)
 	CreateArticle(article *models.Article) (int, error)
 	OneArticle(id int) (*models.Article, error)
+	ArticleBySlug(slug string) (*models.Article, error)
+	UpdateArticle(article *models.Article) error
+	SetArticleStatus(article *models.Article, from string) error
+	DeleteArticle(id int) error
+	SearchArticles(query string, params models.ListParams) (*models.SearchPage, error)
+	ScheduledArticles(params models.ListParams) (*models.ArticlePage, error)
+	PublishDueArticles(limit int, publishedBy string) ([]models.Article, error)
+	LatestArticles(filter models.ArticleFilter, limit int) ([]models.Article, error)
+	SitemapPages(size int) ([]models.SitemapPage, error)
+	SitemapEntries(page, size int) ([]models.SitemapEntry, error)
+	ArticleRevisions(articleID int, params models.ListParams) (*models.RevisionPage, error)
+	ArticleRevision(articleID, revision int) (*models.Revision, error)
 }
 
 const dbTimeout = time.Second * 3
 
+// articleColumns are the columns of an article, in the order of articleFields
+const articleColumns = `id, title, content, author, status, created_at, updated_at, published_at, publish_at, created_by, updated_by, revision, author_id, slug, content_html, cover_id`
+
+// articleFields returns the scan destinations for articleColumns followed by extra
+func articleFields(article *models.Article, extra ...interface{}) []interface{} {
+	return append([]interface{}{
+		&article.ID,
+		&article.Title,
+		&article.Content,
+		&article.Author,
+		&article.Status,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&article.PublishedAt,
+		&article.PublishAt,
+		&article.CreatedBy,
+		&article.UpdatedBy,
+		&article.Revision,
+		&article.AuthorID,
+		&article.Slug,
+		&article.ContentHTML,
+		&article.CoverID,
+	}, extra...)
+}
+
 // Connection returns underlying connection pool.
 func (m *PostgresDBRepo) Connection() *sql.DB {
 	return m.DB
 }
 
-// Create a table if not exist
-func (m *PostgresDBRepo) CreateTable() {
+// Return one page of the articles matching a filter in the requested order
+func (m *PostgresDBRepo) AllArticles(params models.ListParams) (*models.ArticlePage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var filter queryBuilder
+	filter.visibleTo(params.Viewer, params.AllStatuses)
+	filter.filterArticles(params.Filter)
 
-	createTableSQL := `
-        CREATE TABLE IF NOT EXISTS articles (
-            id SERIAL PRIMARY KEY,
-            title TEXT NOT NULL,
-            content TEXT NOT NULL,
-            author TEXT NOT NULL
-        );`
-	_, err := m.DB.Exec(createTableSQL)
+	var total int
+	err := m.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM articles `+filter.whereClause(), filter.args...).Scan(&total)
 	if err != nil {
-		log.Fatal(err)
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
 	}
 
-	fmt.Println(appconst.CreateArticleTable)
-}
+	page := filter
+	column := sortColumnFor(params.Sort)
+	orderBy := page.keyset(params.Sort, params.Cursor)
 
-// Return all articles
-func (m *PostgresDBRepo) AllArticles() ([]models.Article, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
-	defer cancel()
+	// One extra row is fetched to find out whether another page follows
+	limit := "LIMIT " + page.arg(params.Limit+1)
+	if params.Cursor == nil {
+		limit += " OFFSET " + page.arg(params.Offset)
+	}
 
-	query := `
+	query := fmt.Sprintf(`
         SELECT
-            id, title, content, author
+            %s, CAST(%s AS TEXT)
         FROM
             articles
-        ORDER BY
-            title
-    `
+        %s
+        %s
+        %s
+    `, articleColumns, column.column, page.whereClause(), orderBy, limit)
 
-	rows, err := m.DB.QueryContext(ctx, query)
+	rows, err := m.DB.QueryContext(ctx, query, page.args...)
 	if err != nil {
 		log.Println(appconst.Queryerror, err)
-		return nil, err
+		return nil, translateError(err)
 	}
 	defer rows.Close()
 
 	var articlesList []models.Article
-
+	var sortKeys []string
 	for rows.Next() {
 		var article models.Article
-		err := rows.Scan(
-			&article.ID,
-			&article.Title,
-			&article.Content,
-			&article.Author,
-		)
+		var sortKey string
+		err := rows.Scan(articleFields(&article, &sortKey)...)
 		if err != nil {
 			log.Println(appconst.Nextrow, err)
-			return nil, err
+			return nil, translateError(err)
 		}
 
 		articlesList = append(articlesList, article)
+		sortKeys = append(sortKeys, sortKey)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	result := buildPage(params, articlesList, sortKeys, total)
+	if err := m.attachRelations(ctx, articlePointers(result.Articles)...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// buildPage trims the look-ahead row and works out the neighbouring pages
+func buildPage(params models.ListParams, articlesList []models.Article, sortKeys []string, total int) *models.ArticlePage {
+	hasMore := len(articlesList) > params.Limit
+	if hasMore {
+		articlesList = articlesList[:params.Limit]
+		sortKeys = sortKeys[:params.Limit]
+	}
+
+	page := &models.ArticlePage{Articles: articlesList, PageInfo: models.PageInfo{Total: total}}
+	switch {
+	case params.Cursor == nil:
+		page.HasNext = hasMore
+		page.HasPrev = params.Offset > 0
+	case params.Cursor.Direction == models.CursorPrev:
+		// Rows were read backwards from the cursor
+		for i, j := 0, len(articlesList)-1; i < j; i, j = i+1, j-1 {
+			articlesList[i], articlesList[j] = articlesList[j], articlesList[i]
+			sortKeys[i], sortKeys[j] = sortKeys[j], sortKeys[i]
+		}
+		page.HasNext = true
+		page.HasPrev = hasMore
+	default:
+		page.HasNext = hasMore
+		page.HasPrev = true
+	}
+
+	if len(articlesList) > 0 {
+		first, last := 0, len(articlesList)-1
+		sort := params.Sort.String()
+		if page.HasNext {
+			page.NextCursor = models.Cursor{Sort: sort, Value: sortKeys[last], ID: articlesList[last].ID, Direction: models.CursorNext}.Encode()
+		}
+		if page.HasPrev {
+			page.PrevCursor = models.Cursor{Sort: sort, Value: sortKeys[first], ID: articlesList[first].ID, Direction: models.CursorPrev}.Encode()
+		}
+	}
+
+	if page.Articles == nil {
+		page.Articles = []models.Article{}
+	}
+
+	return page
+}
+
+// articlePointers returns pointers to the articles of a page, to read
+// their tags and categories into
+func articlePointers(articles []models.Article) []*models.Article {
+	pointers := make([]*models.Article, len(articles))
+	for i := range articles {
+		pointers[i] = &articles[i]
 	}
+	return pointers
+}
+
+// attachRelations reads what articles refer to: their tags and categories
+// and their cover image.
+func (m *PostgresDBRepo) attachRelations(ctx context.Context, articles ...*models.Article) error {
+	if err := renderMissingHTML(articles...); err != nil {
+		return err
+	}
+	if err := m.attachTaxonomy(ctx, articles...); err != nil {
+		return err
+	}
+	return m.attachCovers(ctx, articles...)
+}
 
-	return articlesList, nil
+// renderMissingHTML renders the content of articles saved before their HTML
+// was kept with them, so every reader gets the HTML until they are saved
+// again.
+func renderMissingHTML(articles ...*models.Article) error {
+	for _, article := range articles {
+		if article.ContentHTML != "" || article.Content == "" {
+			continue
+		}
+		html, err := markdown.Render(article.Content)
+		if err != nil {
+			log.Println(appconst.Rendererror, err)
+			return err
+		}
+		article.ContentHTML = html
+	}
+	return nil
 }
 
 // Retrive one article
@@ -95,7 +231,7 @@ func (m *PostgresDBRepo) OneArticle(id int) (*models.Article, error) {
 
 	query := `
         SELECT
-            id, title, content, author
+            ` + articleColumns + `
         FROM
             articles
         WHERE
@@ -103,41 +239,199 @@ func (m *PostgresDBRepo) OneArticle(id int) (*models.Article, error) {
     `
 
 	var article models.Article
-	err := m.DB.QueryRowContext(ctx, query, id).Scan(
-		&article.ID,
-		&article.Title,
-		&article.Content,
-		&article.Author,
-	)
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(articleFields(&article)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Println(appconst.NoArticleforid, err)
-			return nil, err // Article not found
+			return nil, translateError(err) // Article not found
 		}
 		log.Println(appconst.Queryerror, err)
-		return nil, err // Other error
+		return nil, translateError(err) // Other error
+	}
+
+	if err := m.attachRelations(ctx, &article); err != nil {
+		return nil, err
 	}
 
 	return &article, nil
 }
 
-// Create new article
+// Create new article with its tags and categories
 func (m *PostgresDBRepo) CreateArticle(article *models.Article) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return 0, translateError(err)
+	}
+	defer tx.Rollback()
+
+	if article.Slug, err = uniqueSlug(ctx, tx, article.Slug, 0); err != nil {
+		return 0, err
+	}
+
+	// Articles start as unpublished drafts at their first revision
 	query := `
-        INSERT INTO articles (title, content, author)
-        VALUES ($1, $2, $3)
-        RETURNING id
+        WITH changed AS (
+            INSERT INTO articles (title, content, author, author_id, publish_at, created_by, updated_by, slug, content_html, cover_id)
+            VALUES ($1, $2, $3, $4, $5, $6, $6, $7, $8, $9)
+            RETURNING ` + articleColumns + `
+        ), ` + recordRevision + `
+        SELECT id, status, created_at, updated_at, revision FROM changed
     `
 
-	var articleID int
-	err := m.DB.QueryRowContext(ctx, query, article.Title, article.Content, article.Author).Scan(&articleID)
+	err = tx.QueryRowContext(ctx, query, article.Title, article.Content, article.Author, article.AuthorID, article.PublishAt, article.CreatedBy, article.Slug, article.ContentHTML, article.CoverID).
+		Scan(&article.ID, &article.Status, &article.CreatedAt, &article.UpdatedAt, &article.Revision)
 	if err != nil {
 		log.Println(appconst.Queryerror, err)
+		return 0, translateError(err)
+	}
+	article.UpdatedBy = article.CreatedBy
+
+	if err := saveTaxonomy(ctx, tx, article); err != nil {
 		return 0, err
 	}
+	if err := tx.Commit(); err != nil {
+		log.Println(appconst.Queryerror, err)
+		return 0, translateError(err)
+	}
+
+	return article.ID, nil
+}
+
+// Update an existing article, replacing its tags and categories, and record
+// the change as a new revision. updated_at is maintained by a trigger; the
+// audit columns the statement does not write are read back into article.
+func (m *PostgresDBRepo) UpdateArticle(article *models.Article) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	defer tx.Rollback()
+
+	if article.Slug, err = uniqueSlug(ctx, tx, article.Slug, article.ID); err != nil {
+		return err
+	}
+
+	// A changed slug is kept in article_slugs by a trigger
+	query := `
+        WITH changed AS (
+            UPDATE articles
+            SET title = $1, content = $2, author = $3, author_id = $4, publish_at = $5, updated_by = $6, slug = $8, content_html = $9, cover_id = $10, revision = revision + 1
+            WHERE id = $7
+            RETURNING ` + articleColumns + `
+        ), ` + recordRevision + `
+        SELECT status, created_at, updated_at, published_at, created_by, revision FROM changed
+    `
+
+	err = tx.QueryRowContext(ctx, query, article.Title, article.Content, article.Author, article.AuthorID, article.PublishAt, article.UpdatedBy, article.ID, article.Slug, article.ContentHTML, article.CoverID).
+		Scan(&article.Status, &article.CreatedAt, &article.UpdatedAt, &article.PublishedAt, &article.CreatedBy, &article.Revision)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.NoArticleforid, err)
+			return translateError(err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	if err := saveTaxonomy(ctx, tx, article); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Move an article to article.Status if it is still in the from status, so
+// two concurrent transitions cannot both succeed. Publishing stamps
+// published_at and going back to draft clears it; a pending schedule is
+// dropped once the article is published or archived. The change is recorded
+// as a new revision and the stored article is read back into article.
+func (m *PostgresDBRepo) SetArticleStatus(article *models.Article, from string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        WITH changed AS (
+            UPDATE articles
+            SET
+                status = $1,
+                published_at = CASE $1
+                    WHEN 'published' THEN now()
+                    WHEN 'draft' THEN NULL
+                    ELSE published_at
+                END,
+                publish_at = CASE $1
+                    WHEN 'published' THEN NULL
+                    WHEN 'archived' THEN NULL
+                    ELSE publish_at
+                END,
+                updated_by = $2,
+                revision = revision + 1
+            WHERE id = $3 AND status = $4
+            RETURNING ` + articleColumns + `
+        ), ` + recordRevision + `
+        SELECT ` + articleColumns + ` FROM changed
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, article.Status, article.UpdatedBy, article.ID, from).Scan(articleFields(article)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Statuschanged, err)
+			return apperrors.Conflict(appconst.Statuschanged, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return m.attachRelations(ctx, article)
+}
+
+// Delete an article
+func (m *PostgresDBRepo) DeleteArticle(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        DELETE FROM articles
+        WHERE id = $1
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return checkRowsAffected(result)
+}
+
+// checkRowsAffected reports a not found error when a statement did not touch any article
+func checkRowsAffected(result sql.Result) error {
+	return checkAffected(result, appconst.NoArticleforid)
+}
+
+// checkAffected reports notFound when a statement did not touch any row
+func checkAffected(result sql.Result, notFound string) error {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	if rows == 0 {
+		log.Println(notFound, sql.ErrNoRows)
+		return apperrors.NotFound(notFound, sql.ErrNoRows)
+	}
 
-	return articleID, nil
+	return nil
 }
@@ -1,10 +1,12 @@
 package dbrepo
 
 import (
+	"backend/pkg/apperrors"
 	"backend/pkg/models"
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	_ "github.com/jackc/pgx/v4/stdlib" // Import the PostgreSQL driver
@@ -15,6 +17,14 @@ type DBRepo interface {
 	OneArticle(id int) (*models.Article, error)
 }
 
+// stamp is the audit timestamp of the article rows returned by the mocks
+var stamp = time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+// articleRowColumns returns the names of articleColumns followed by extra
+func articleRowColumns(extra ...string) []string {
+	return append([]string{"id", "title", "content", "author", "status", "created_at", "updated_at", "published_at", "publish_at", "created_by", "updated_by", "revision", "author_id", "slug", "content_html", "cover_id"}, extra...)
+}
+
 // Test case using the table driven test
 func TestPostgresDBRepo(t *testing.T) {
 	tests := []struct {
@@ -23,30 +33,21 @@ func TestPostgresDBRepo(t *testing.T) {
 		repoAction  func(repo *PostgresDBRepo) error
 		expectedErr error
 	}{
-		{
-			name: "Test CreateTable",
-			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec("CREATE TABLE IF NOT EXISTS articles").
-					WillReturnResult(sqlmock.NewResult(0, 0))
-			},
-			repoAction: func(repo *PostgresDBRepo) error {
-				repo.CreateTable()
-				return nil
-			},
-			expectedErr: nil,
-		},
 		{
 			name: "Test AllArticles",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "content", "author"}).
-					AddRow(1, "Title1", "Content1", "Author1").
-					AddRow(2, "Title2", "Content2", "Author2")
+				rows := sqlmock.NewRows(articleRowColumns("sort_key")).
+					AddRow(1, "Title1", "Content1", "Author1", "published", stamp, stamp, stamp, nil, "Author1", "Author1", 1, 1, "title1", "<p>Content1</p>\n", nil, "Title1").
+					AddRow(2, "Title2", "Content2", "Author2", "published", stamp, stamp, nil, nil, "Author2", "Author2", 1, 1, "title2", "<p>Content2</p>\n", nil, "Title2")
 
-				mock.ExpectQuery("SELECT id, title, content, author FROM articles").
+				mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles").
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+				mock.ExpectQuery("SELECT id, title, content, author, status, created_at, updated_at, published_at, publish_at, created_by, updated_by, revision, author_id, slug, content_html, cover_id, CAST\\(title AS TEXT\\) FROM articles").
 					WillReturnRows(rows)
+				expectTaxonomy(mock, "{1,2}")
 			},
 			repoAction: func(repo *PostgresDBRepo) error {
-				_, err := repo.AllArticles()
+				_, err := repo.AllArticles(models.ListParams{Limit: 10})
 				return err
 			},
 			expectedErr: nil,
@@ -54,12 +55,13 @@ func TestPostgresDBRepo(t *testing.T) {
 		{
 			name: "Test OneArticle (article found)",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "title", "content", "author"}).
-					AddRow(1, "Title1", "Content1", "Author1")
+				rows := sqlmock.NewRows(articleRowColumns()).
+					AddRow(1, "Title1", "Content1", "Author1", "published", stamp, stamp, stamp, nil, "Author1", "Author1", 1, 1, "title1", "<p>Content1</p>\n", nil)
 
-				mock.ExpectQuery("SELECT id, title, content, author FROM articles WHERE id = \\$1").
+				mock.ExpectQuery("SELECT id, title, content, author, status, created_at, updated_at, published_at, publish_at, created_by, updated_by, revision, author_id, slug, content_html, cover_id FROM articles WHERE id = \\$1").
 					WithArgs(1).
 					WillReturnRows(rows)
+				expectTaxonomy(mock, "{1}")
 			},
 			repoAction: func(repo *PostgresDBRepo) error {
 				_, err := repo.OneArticle(1)
@@ -70,7 +72,7 @@ func TestPostgresDBRepo(t *testing.T) {
 		{
 			name: "Test OneArticle (article not found)",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("SELECT id, title, content, author FROM articles WHERE id = \\$1").
+				mock.ExpectQuery("SELECT id, title, content, author, status, created_at, updated_at, published_at, publish_at, created_by, updated_by, revision, author_id, slug, content_html, cover_id FROM articles WHERE id = \\$1").
 					WithArgs(2).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -82,16 +84,29 @@ func TestPostgresDBRepo(t *testing.T) {
 		{
 			name: "Test CreateArticle",
 			setupMock: func(mock sqlmock.Sqlmock) {
-				mock.ExpectQuery("INSERT INTO articles").
-					WithArgs("Title1", "Content1", "Author1").
-					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectBegin()
+				expectUniqueSlug(mock, "title1", 0, "title1")
+				mock.ExpectQuery("WITH changed AS \\( INSERT INTO articles \\(title, content, author, author_id, publish_at, created_by, updated_by, slug, content_html, cover_id\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$6, \\$7, \\$8, \\$9\\) RETURNING id, title, .+ \\), "+
+					"history AS \\( INSERT INTO article_revisions .+ FROM changed \\) SELECT id, status, created_at, updated_at, revision FROM changed").
+					WithArgs("Title1", "Content1", "Author1", 1, nil, "Author1", "title1-2", "<p>Content1</p>\n", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "status", "created_at", "updated_at", "revision"}).AddRow(1, "draft", stamp, stamp, 1))
+				expectSaveTaxonomy(mock, 1)
+				mock.ExpectCommit()
 			},
 			repoAction: func(repo *PostgresDBRepo) error {
-				_, err := repo.CreateArticle(&models.Article{
-					Title:   "Title1",
-					Content: "Content1",
-					Author:  "Author1",
-				})
+				article := &models.Article{
+					Title:       "Title1",
+					Content:     "Content1",
+					Author:      "Author1",
+					AuthorID:    1,
+					CreatedBy:   "Author1",
+					Slug:        "title1",
+					ContentHTML: "<p>Content1</p>\n",
+				}
+				_, err := repo.CreateArticle(article)
+				if err == nil && (article.ID != 1 || article.Revision != 1 || article.Status != models.StatusDraft || !article.CreatedAt.Equal(stamp) || article.UpdatedBy != "Author1" || article.Slug != "title1-2") {
+					return fmt.Errorf("audit fields not read back: %+v", article)
+				}
 				return err
 			},
 			expectedErr: nil,
@@ -111,10 +126,9 @@ func TestPostgresDBRepo(t *testing.T) {
 			err = test.repoAction(repo)
 
 			if err != test.expectedErr {
-				expectedErrorMessage := "sql: no rows in result set"
-				if err.Error() != expectedErrorMessage {
-					t.Errorf("Expected error message: %s, got: %s", expectedErrorMessage, err.Error())
-				} //	t.Errorf("Expected error: %v, got: %v", test.expectedErr, err)
+				// A missing article is reported as a domain not found error
+				assert.ErrorIs(t, err, apperrors.ErrNotFound)
+				assert.ErrorIs(t, err, sql.ErrNoRows)
 			}
 		})
 	}
@@ -132,7 +146,7 @@ func TestOneArticle_error(t *testing.T) {
 	repo := &PostgresDBRepo{DB: db}
 
 	// Define the expected SQL query
-	query := "SELECT id, title, content, author FROM articles WHERE id = ?"
+	query := "SELECT id, title, content, author, (.+) FROM articles WHERE id = ?"
 
 	// Expect the SQL query with id = 1 to return sql.ErrNoRows
 	mock.ExpectQuery(query).
@@ -151,6 +165,25 @@ func TestOneArticle_error(t *testing.T) {
 	}
 }
 
+func TestOneArticle_RendersMissingHTML(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// Articles saved before their HTML was kept with them have none stored
+	mock.ExpectQuery("SELECT id, title, content, author, (.+) FROM articles WHERE id = ?").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(articleRowColumns()).
+			AddRow(1, "Old", "*Hi*", "Ada", "published", stamp, stamp, stamp, nil, "Ada", "Ada", 1, 1, "old", "", nil))
+	expectTaxonomy(mock, "{1}")
+
+	repo := &PostgresDBRepo{DB: db}
+	article, err := repo.OneArticle(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<p><em>Hi</em></p>\n", article.ContentHTML)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestConnections(t *testing.T) {
 	// Create a new mock DB connection
 	db, _, _ := sqlmock.New()
@@ -170,15 +203,19 @@ func TestCreateArticleError(t *testing.T) {
 
 	repo := &PostgresDBRepo{DB: db}
 
-	// Expect the INSERT statement to return an error
+	// Expect the INSERT statement to return an error and roll back
+	mock.ExpectBegin()
+	expectUniqueSlug(mock, "test-article", 0)
 	mock.ExpectQuery("INSERT INTO articles").
 		WillReturnError(fmt.Errorf("Test error"))
+	mock.ExpectRollback()
 
 	// Create a sample article
 	article := &models.Article{
 		Title:   "Test Article",
 		Content: "Test Content",
 		Author:  "Test Author",
+		Slug:    "test-article",
 	}
 
 	// Call CreateArticle, which should return an error
@@ -190,6 +227,7 @@ func TestCreateArticleError(t *testing.T) {
 
 	// Ensure that articleID is 0
 	assert.Equal(t, 0, articleID, "Expected articleID to be 0")
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 func TestAllArticlesError(t *testing.T) {
 	// Create a new mock DB connection
@@ -203,7 +241,7 @@ func TestAllArticlesError(t *testing.T) {
 		WillReturnError(fmt.Errorf("Test query error"))
 
 	// Call AllArticles, which should return an error
-	articles, err := repo.AllArticles()
+	articles, err := repo.AllArticles(models.ListParams{Limit: 10})
 
 	// Check if the returned error is as expected
 	assert.Error(t, err, "Expected an error")
@@ -217,3 +255,356 @@ func TestAllArticlesError(t *testing.T) {
 		t.Errorf("Unfulfilled expectations: %s", err)
 	}
 }
+
+func TestUpdateArticle(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "Article updated",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectUniqueSlug(mock, "title1", 1)
+				mock.ExpectQuery("WITH changed AS \\( UPDATE articles SET title = \\$1, content = \\$2, author = \\$3, author_id = \\$4, publish_at = \\$5, updated_by = \\$6, slug = \\$8, content_html = \\$9, cover_id = \\$10, revision = revision \\+ 1 WHERE id = \\$7 RETURNING id, title, .+ \\), "+
+					"history AS \\( INSERT INTO article_revisions .+ FROM changed \\) SELECT status, created_at, updated_at, published_at, created_by, revision FROM changed").
+					WithArgs("Title1", "Content1", "Author1", 1, &stamp, "Editor", 1, "title1", "<p>Content1</p>\n", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "created_at", "updated_at", "published_at", "created_by", "revision"}).AddRow("draft", stamp, stamp, nil, "Author1", 2))
+				expectSaveTaxonomy(mock, 1)
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Article not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectUniqueSlug(mock, "title1", 1)
+				mock.ExpectQuery("UPDATE articles").
+					WithArgs("Title1", "Content1", "Author1", 1, &stamp, "Editor", 1, "title1", "<p>Content1</p>\n", nil).
+					WillReturnRows(sqlmock.NewRows([]string{"status", "created_at", "updated_at", "published_at", "created_by", "revision"}))
+				mock.ExpectRollback()
+			},
+			expectedErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "Query error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				expectUniqueSlug(mock, "title1", 1)
+				mock.ExpectQuery("UPDATE articles").
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedErr: apperrors.ErrUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			repo := &PostgresDBRepo{DB: db}
+			test.setupMock(mock)
+
+			article := &models.Article{ID: 1, Title: "Title1", Slug: "title1", Content: "Content1", ContentHTML: "<p>Content1</p>\n", Author: "Author1", AuthorID: 1, PublishAt: &stamp, UpdatedBy: "Editor"}
+			err := repo.UpdateArticle(article)
+
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, &stamp, article.UpdatedAt)
+				assert.Nil(t, article.PublishedAt)
+				assert.Equal(t, "Author1", article.CreatedBy)
+				assert.Equal(t, 2, article.Revision)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteArticle(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "Article deleted",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM articles WHERE id = \\$1").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Article not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM articles WHERE id = \\$1").
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: apperrors.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			repo := &PostgresDBRepo{DB: db}
+			test.setupMock(mock)
+
+			err := repo.DeleteArticle(1)
+
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAllArticlesPagination(t *testing.T) {
+	columns := articleRowColumns("sort_key")
+
+	tests := []struct {
+		name            string
+		params          models.ListParams
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedIDs     []int
+		expectedHasNext bool
+		expectedHasPrev bool
+	}{
+		{
+			name:   "Offset page with more rows",
+			params: models.ListParams{Limit: 2, Offset: 2, Sort: models.Sort{Field: models.SortTitle}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM articles WHERE status = \\$1 ORDER BY title ASC, id ASC LIMIT \\$2 OFFSET \\$3").
+					WithArgs("published", 3, 2).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, "C", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "c", "", nil, "C").
+						AddRow(4, "D", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "d", "", nil, "D").
+						AddRow(5, "E", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "e", "", nil, "E"))
+			},
+			expectedIDs:     []int{3, 4},
+			expectedHasNext: true,
+			expectedHasPrev: true,
+		},
+		{
+			name:   "Keyset next page",
+			params: models.ListParams{Limit: 2, Sort: models.Sort{Field: models.SortTitle}, Cursor: &models.Cursor{Sort: "title", Value: "B", ID: 2, Direction: models.CursorNext}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WHERE status = \\$1 AND \\(title, id\\) > \\(CAST\\(CAST\\(\\$2 AS TEXT\\) AS TEXT\\), \\$3\\) ORDER BY title ASC, id ASC LIMIT \\$4$").
+					WithArgs("published", "B", 2, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, "C", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "c", "", nil, "C"))
+			},
+			expectedIDs:     []int{3},
+			expectedHasNext: false,
+			expectedHasPrev: true,
+		},
+		{
+			name:   "Keyset previous page is returned in order",
+			params: models.ListParams{Limit: 2, Sort: models.Sort{Field: models.SortTitle}, Cursor: &models.Cursor{Sort: "title", Value: "E", ID: 5, Direction: models.CursorPrev}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WHERE status = \\$1 AND \\(title, id\\) < \\(CAST\\(CAST\\(\\$2 AS TEXT\\) AS TEXT\\), \\$3\\) ORDER BY title DESC, id DESC LIMIT \\$4").
+					WithArgs("published", "E", 5, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(4, "D", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "d", "", nil, "D").
+						AddRow(3, "C", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "c", "", nil, "C").
+						AddRow(2, "B", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "b", "", nil, "B"))
+			},
+			expectedIDs:     []int{3, 4},
+			expectedHasNext: true,
+			expectedHasPrev: true,
+		},
+		{
+			name: "Descending keyset next page on a timestamp",
+			params: models.ListParams{Limit: 2, Sort: models.Sort{Field: models.SortCreatedAt, Desc: true},
+				Cursor: &models.Cursor{Sort: "-created_at", Value: "2023-05-02 10:00:00+00", ID: 7, Direction: models.CursorNext}},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT id, title, content, author, status, created_at, updated_at, published_at, publish_at, created_by, updated_by, revision, author_id, slug, content_html, cover_id, CAST\\(created_at AS TEXT\\) FROM articles "+
+					"WHERE status = \\$1 AND \\(created_at, id\\) < \\(CAST\\(CAST\\(\\$2 AS TEXT\\) AS TIMESTAMPTZ\\), \\$3\\) ORDER BY created_at DESC, id DESC LIMIT \\$4").
+					WithArgs("published", "2023-05-02 10:00:00+00", 7, 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(6, "F", "", "", "published", stamp, stamp, nil, nil, "", "", 1, 1, "f", "", nil, "2023-05-01 10:00:00+00"))
+			},
+			expectedIDs:     []int{6},
+			expectedHasNext: false,
+			expectedHasPrev: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles").
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+			test.setupMock(mock)
+			mock.ExpectQuery(taxonomyQuery).
+				WillReturnRows(sqlmock.NewRows([]string{"article_id", "kind", "value"}))
+
+			repo := &PostgresDBRepo{DB: db}
+			page, err := repo.AllArticles(test.params)
+
+			assert.NoError(t, err)
+			var ids []int
+			for _, article := range page.Articles {
+				ids = append(ids, article.ID)
+			}
+			assert.Equal(t, test.expectedIDs, ids)
+			assert.Equal(t, 5, page.Total)
+			assert.Equal(t, test.expectedHasNext, page.HasNext)
+			assert.Equal(t, test.expectedHasPrev, page.HasPrev)
+
+			if page.HasNext {
+				next, err := models.DecodeCursor(page.NextCursor)
+				assert.NoError(t, err)
+				assert.Equal(t, ids[len(ids)-1], next.ID)
+				assert.Equal(t, models.CursorNext, next.Direction)
+				assert.Equal(t, test.params.Sort.String(), next.Sort)
+			}
+			if page.HasPrev {
+				prev, err := models.DecodeCursor(page.PrevCursor)
+				assert.NoError(t, err)
+				assert.Equal(t, ids[0], prev.ID)
+				assert.Equal(t, models.CursorPrev, prev.Direction)
+				assert.Equal(t, test.params.Sort.String(), prev.Sort)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAllArticlesFilter(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	after := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := models.ListParams{
+		Limit:  10,
+		Sort:   models.Sort{Field: models.SortAuthor, Desc: true},
+		Filter: models.ArticleFilter{Author: "ada", CreatedAfter: &after, Tag: "go", Category: "backend", Status: models.StatusDraft},
+		Viewer: models.Principal{UserID: 1, Username: "ada"},
+	}
+	// The category includes the categories below it
+	where := "WHERE \\(status = \\$1 OR author_id = \\$2\\) AND author = \\$3 AND created_at > \\$4 AND EXISTS \\(SELECT 1 FROM article_tags JOIN tags ON tags.id = article_tags.tag_id WHERE article_tags.article_id = articles.id AND tags.slug = \\$5\\) " +
+		"AND EXISTS \\(SELECT 1 FROM article_categories WHERE article_categories.article_id = articles.id AND article_categories.category_id IN \\(WITH RECURSIVE subtree AS \\(SELECT id FROM categories WHERE slug = \\$6 " +
+		"UNION ALL SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id\\) SELECT id FROM subtree\\)\\) AND status = \\$7"
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles "+where).
+		WithArgs(models.StatusPublished, 1, "ada", after, "go", "backend", models.StatusDraft).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT id, title, content, author, status, created_at, updated_at, published_at, publish_at, created_by, updated_by, revision, author_id, slug, content_html, cover_id, CAST\\(author AS TEXT\\) FROM articles "+where+" ORDER BY author DESC, id DESC LIMIT \\$8 OFFSET \\$9").
+		WithArgs(models.StatusPublished, 1, "ada", after, "go", "backend", models.StatusDraft, 11, 0).
+		WillReturnRows(sqlmock.NewRows(articleRowColumns("sort_key")).
+			AddRow(1, "Title1", "Content1", "ada", "draft", stamp, stamp, nil, nil, "ada", "ada", 1, 1, "title1", "<p>Content1</p>\n", nil, "ada"))
+	mock.ExpectQuery(taxonomyQuery).
+		WithArgs("{1}").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "kind", "value"}).
+			AddRow(1, "category", "databases").
+			AddRow(1, "tag", "Go"))
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.AllArticles(params)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Articles, 1)
+	assert.Equal(t, 1, page.Total)
+	assert.Equal(t, []string{"Go"}, page.Articles[0].Tags)
+	assert.Equal(t, []string{"databases"}, page.Articles[0].Categories)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAllArticlesAllStatuses(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	params := models.ListParams{
+		Limit:       10,
+		Sort:        models.Sort{Field: models.SortTitle},
+		Viewer:      models.Principal{UserID: 4, Username: "editor"},
+		AllStatuses: true,
+	}
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM articles$").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("FROM articles ORDER BY title ASC, id ASC LIMIT \\$1 OFFSET \\$2").
+		WithArgs(11, 0).
+		WillReturnRows(sqlmock.NewRows(articleRowColumns("sort_key")))
+
+	repo := &PostgresDBRepo{DB: db}
+	_, err := repo.AllArticles(params)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetArticleStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "Status changed",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WITH changed AS \\( UPDATE articles SET status = \\$1, published_at = CASE \\$1 WHEN 'published' THEN now\\(\\) WHEN 'draft' THEN NULL ELSE published_at END, publish_at = CASE \\$1 WHEN 'published' THEN NULL WHEN 'archived' THEN NULL ELSE publish_at END, updated_by = \\$2, revision = revision \\+ 1 WHERE id = \\$3 AND status = \\$4 RETURNING id, title, .+ \\), "+
+					"history AS \\( INSERT INTO article_revisions .+ \\) SELECT id, title, .+ FROM changed").
+					WithArgs("published", "Ada", 1, "in_review").
+					WillReturnRows(sqlmock.NewRows(articleRowColumns()).
+						AddRow(1, "Title1", "Content1", "Ada", "published", stamp, stamp, stamp, nil, "Ada", "Ada", 1, 1, "title1", "<p>Content1</p>\n", nil))
+				expectTaxonomy(mock, "{1}")
+			},
+		},
+		{
+			name: "Status changed concurrently",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE articles").
+					WithArgs("published", "Ada", 1, "in_review").
+					WillReturnRows(sqlmock.NewRows(articleRowColumns()))
+			},
+			expectedErr: apperrors.ErrConflict,
+		},
+		{
+			name: "Query error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UPDATE articles").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: apperrors.ErrUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			repo := &PostgresDBRepo{DB: db}
+			test.setupMock(mock)
+
+			article := &models.Article{ID: 1, Author: "Ada", Status: models.StatusPublished, UpdatedBy: "Ada"}
+			err := repo.SetArticleStatus(article, models.StatusInReview)
+
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "Title1", article.Title)
+				assert.Equal(t, &stamp, article.PublishedAt)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package dbrepo
+
+import (
+	"backend/pkg/models"
+	"fmt"
+	"strings"
+)
+
+// sortColumn is the SQL for a sortable field and the type its cursor values
+// are cast back to.
+type sortColumn struct {
+	column  string
+	sqlType string
+}
+
+// sortColumns whitelists the columns a listing may be ordered by. Sort fields
+// are never interpolated into SQL, only the entries of this map are.
+var sortColumns = map[string]sortColumn{
+	models.SortTitle:     {column: "title", sqlType: "TEXT"},
+	models.SortCreatedAt: {column: "created_at", sqlType: "TIMESTAMPTZ"},
+	models.SortUpdatedAt: {column: "updated_at", sqlType: "TIMESTAMPTZ"},
+	models.SortAuthor:    {column: "author", sqlType: "TEXT"},
+	models.SortID:        {column: "id", sqlType: "INTEGER"},
+}
+
+// sortColumnFor returns the column of a sort, falling back to title for a
+// field that is not whitelisted
+func sortColumnFor(sort models.Sort) sortColumn {
+	if column, ok := sortColumns[sort.Field]; ok {
+		return column
+	}
+	return sortColumns[models.SortTitle]
+}
+
+// queryBuilder collects WHERE conditions and their positional arguments
+type queryBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+// arg adds a positional argument and returns its placeholder
+func (b *queryBuilder) arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// where adds a condition; placeholders must come from arg
+func (b *queryBuilder) where(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+// whereClause returns the WHERE clause, or nothing without conditions
+func (b *queryBuilder) whereClause() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// visibleTo limits the articles to the published ones and those written by
+// viewer, when there is one, unless all statuses are visible
+func (b *queryBuilder) visibleTo(viewer models.Principal, allStatuses bool) {
+	if allStatuses {
+		return
+	}
+	published := "status = " + b.arg(models.StatusPublished)
+	if viewer.Anonymous() {
+		b.where(published)
+		return
+	}
+	b.where("(" + published + " OR author_id = " + b.arg(viewer.UserID) + ")")
+}
+
+// filterArticles adds the conditions of an article filter
+func (b *queryBuilder) filterArticles(filter models.ArticleFilter) {
+	if filter.Author != "" {
+		b.where("author = " + b.arg(filter.Author))
+	}
+	if filter.CreatedAfter != nil {
+		b.where("created_at > " + b.arg(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		b.where("created_at < " + b.arg(*filter.CreatedBefore))
+	}
+	if filter.Tag != "" {
+		b.where("EXISTS (SELECT 1 FROM article_tags JOIN tags ON tags.id = article_tags.tag_id WHERE article_tags.article_id = articles.id AND tags.slug = " + b.arg(filter.Tag) + ")")
+	}
+	if filter.Category != "" {
+		b.where("EXISTS (SELECT 1 FROM article_categories WHERE article_categories.article_id = articles.id AND article_categories.category_id IN (" +
+			"WITH RECURSIVE subtree AS (SELECT id FROM categories WHERE slug = " + b.arg(filter.Category) +
+			" UNION ALL SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id) SELECT id FROM subtree))")
+	}
+	if filter.Status != "" {
+		b.where("status = " + b.arg(filter.Status))
+	}
+}
+
+// keyset adds the condition selecting the rows after or before a cursor and
+// returns the ORDER BY clause reading them in that direction
+func (b *queryBuilder) keyset(sort models.Sort, cursor *models.Cursor) string {
+	column := sortColumnFor(sort)
+
+	// Reading backwards from a cursor flips the order
+	desc := sort.Desc
+	if cursor != nil && cursor.Direction == models.CursorPrev {
+		desc = !desc
+	}
+
+	direction, operator := "ASC", ">"
+	if desc {
+		direction, operator = "DESC", "<"
+	}
+
+	if cursor != nil {
+		b.where(fmt.Sprintf("(%s, id) %s (CAST(CAST(%s AS TEXT) AS %s), %s)",
+			column.column, operator, b.arg(cursor.Value), column.sqlType, b.arg(cursor.ID)))
+	}
+
+	return fmt.Sprintf("ORDER BY %s %s, id %s", column.column, direction, direction)
+}
@@ -0,0 +1,113 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"log"
+)
+
+// recordRevision is a CTE that stores every article row returned by the
+// changed CTE before it as a new revision, so a change and its history are
+// written by the same statement.
+const recordRevision = `history AS (
+            INSERT INTO article_revisions (article_id, revision, title, content, author, status, publish_at, created_by, created_at)
+            SELECT id, revision, title, content, author, status, publish_at, updated_by, updated_at
+            FROM changed
+        )`
+
+// revisionColumns are the columns of a revision, in the order of revisionFields
+const revisionColumns = `article_id, revision, title, content, author, status, publish_at, created_by, created_at`
+
+// revisionFields returns the scan destinations for revisionColumns followed by extra
+func revisionFields(revision *models.Revision, extra ...interface{}) []interface{} {
+	return append([]interface{}{
+		&revision.ArticleID,
+		&revision.Revision,
+		&revision.Title,
+		&revision.Content,
+		&revision.Author,
+		&revision.Status,
+		&revision.PublishAt,
+		&revision.CreatedBy,
+		&revision.CreatedAt,
+	}, extra...)
+}
+
+// Return one page of the revisions of an article, newest first
+func (m *PostgresDBRepo) ArticleRevisions(articleID int, params models.ListParams) (*models.RevisionPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + revisionColumns + `,
+            COUNT(*) OVER() AS total
+        FROM
+            article_revisions
+        WHERE
+            article_id = $1
+        ORDER BY
+            revision DESC
+        LIMIT $2 OFFSET $3
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, articleID, params.Limit+1, params.Offset)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.RevisionPage{Revisions: []models.Revision{}}
+	for rows.Next() {
+		var revision models.Revision
+		if err := rows.Scan(revisionFields(&revision, &page.Total)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		page.Revisions = append(page.Revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if len(page.Revisions) > params.Limit {
+		page.Revisions = page.Revisions[:params.Limit]
+		page.HasNext = true
+	}
+	page.HasPrev = params.Offset > 0
+
+	return page, nil
+}
+
+// Retrieve one revision of an article
+func (m *PostgresDBRepo) ArticleRevision(articleID, revision int) (*models.Revision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + revisionColumns + `
+        FROM
+            article_revisions
+        WHERE
+            article_id = $1 AND revision = $2
+    `
+
+	var result models.Revision
+	err := m.DB.QueryRowContext(ctx, query, articleID, revision).Scan(revisionFields(&result)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Norevision, err)
+			return nil, apperrors.NotFound(appconst.Norevision, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &result, nil
+}
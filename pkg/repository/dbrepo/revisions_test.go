@@ -0,0 +1,90 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var revisionRowColumns = []string{"article_id", "revision", "title", "content", "author", "status", "publish_at", "created_by", "created_at"}
+
+func TestArticleRevisions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	rows := sqlmock.NewRows(append(revisionRowColumns, "total")).
+		AddRow(1, 3, "Title3", "Content3", "Ada", "draft", nil, "Ada", stamp, 3).
+		AddRow(1, 2, "Title2", "Content2", "Ada", "draft", nil, "Ada", stamp, 3)
+
+	mock.ExpectQuery("SELECT article_id, revision, title, content, author, status, publish_at, created_by, created_at, COUNT\\(\\*\\) OVER\\(\\) AS total FROM article_revisions WHERE article_id = \\$1 ORDER BY revision DESC LIMIT \\$2 OFFSET \\$3").
+		WithArgs(1, 2, 0).
+		WillReturnRows(rows)
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.ArticleRevisions(1, models.ListParams{Limit: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Revision{{ArticleID: 1, Revision: 3, Title: "Title3", Content: "Content3", Author: "Ada", Status: "draft", CreatedBy: "Ada", CreatedAt: &stamp}}, page.Revisions)
+	assert.Equal(t, 3, page.Total)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArticleRevision(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "Revision found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM article_revisions WHERE article_id = \\$1 AND revision = \\$2").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows(revisionRowColumns).AddRow(1, 2, "Title2", "Content2", "Ada", "draft", nil, "Ada", stamp))
+			},
+		},
+		{
+			name: "Revision not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM article_revisions").
+					WithArgs(1, 2).
+					WillReturnRows(sqlmock.NewRows(revisionRowColumns))
+			},
+			expectedErr: apperrors.ErrNotFound,
+		},
+		{
+			name: "Query error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM article_revisions").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: apperrors.ErrUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			repo := &PostgresDBRepo{DB: db}
+			test.setupMock(mock)
+
+			revision, err := repo.ArticleRevision(1, 2)
+
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "Title2", revision.Title)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
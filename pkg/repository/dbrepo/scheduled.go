@@ -0,0 +1,124 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"context"
+	"log"
+)
+
+// Return one page of the reviewed articles waiting for their publish_at
+// that the viewer may see, soonest first
+func (m *PostgresDBRepo) ScheduledArticles(params models.ListParams) (*models.ArticlePage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var b queryBuilder
+	b.where("status = 'in_review'")
+	b.where("publish_at > now()")
+	b.visibleTo(params.Viewer, params.AllStatuses)
+
+	query := `
+        SELECT
+            ` + articleColumns + `,
+            COUNT(*) OVER() AS total
+        FROM
+            articles
+        ` + b.whereClause() + `
+        ORDER BY
+            publish_at, id
+        LIMIT ` + b.arg(params.Limit+1) + ` OFFSET ` + b.arg(params.Offset)
+
+	rows, err := m.DB.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.ArticlePage{Articles: []models.Article{}}
+	for rows.Next() {
+		var article models.Article
+		if err := rows.Scan(articleFields(&article, &page.Total)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		page.Articles = append(page.Articles, article)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if len(page.Articles) > params.Limit {
+		page.Articles = page.Articles[:params.Limit]
+		page.HasNext = true
+	}
+	page.HasPrev = params.Offset > 0
+
+	if err := m.attachRelations(ctx, articlePointers(page.Articles)...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// Publish up to limit reviewed articles whose publish_at has passed on
+// behalf of publishedBy and return them. Due rows are claimed with FOR UPDATE SKIP LOCKED so replicas
+// running the scheduler at the same time never publish an article twice;
+// each one moves on to the rows the others have not locked. Articles are
+// published as of now, however late the scheduler gets to them, and every
+// publication is recorded as a new revision.
+func (m *PostgresDBRepo) PublishDueArticles(limit int, publishedBy string) ([]models.Article, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        WITH due AS (
+            SELECT id AS due_id
+            FROM articles
+            WHERE status = 'in_review' AND publish_at <= now()
+            ORDER BY publish_at, id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        ), changed AS (
+            UPDATE articles
+            SET
+                status = 'published',
+                published_at = now(),
+                publish_at = NULL,
+                updated_by = $2,
+                revision = revision + 1
+            FROM due
+            WHERE id = due_id
+            RETURNING ` + articleColumns + `
+        ), ` + recordRevision + `
+        SELECT ` + articleColumns + ` FROM changed
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, limit, publishedBy)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	published := []models.Article{}
+	for rows.Next() {
+		var article models.Article
+		if err := rows.Scan(articleFields(&article)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		published = append(published, article)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if err := renderMissingHTML(articlePointers(published)...); err != nil {
+		return nil, err
+	}
+	return published, nil
+}
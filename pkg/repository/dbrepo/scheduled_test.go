@@ -0,0 +1,116 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledArticles(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	rows := sqlmock.NewRows(articleRowColumns("total")).
+		AddRow(3, "Soon", "", "Ada", "in_review", stamp, stamp, nil, stamp, "Ada", "Ada", 1, 1, "soon", "", nil, 3).
+		AddRow(1, "Later", "", "Ada", "in_review", stamp, stamp, nil, stamp, "Ada", "Ada", 1, 1, "later", "", nil, 3)
+
+	mock.ExpectQuery("FROM articles WHERE status = 'in_review' AND publish_at > now\\(\\) ORDER BY publish_at, id LIMIT \\$1 OFFSET \\$2").
+		WithArgs(2, 1).
+		WillReturnRows(rows)
+	expectTaxonomy(mock, "{3}")
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.ScheduledArticles(models.ListParams{Limit: 1, Offset: 1, AllStatuses: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Articles, 1)
+	assert.Equal(t, &stamp, page.Articles[0].PublishAt)
+	assert.Equal(t, 3, page.Total)
+	assert.True(t, page.HasNext)
+	assert.True(t, page.HasPrev)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduledArticles_OwnOnly(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// viewers who may not see every article only see their own
+	mock.ExpectQuery("FROM articles WHERE status = 'in_review' AND publish_at > now\\(\\) AND \\(status = \\$1 OR author_id = \\$2\\) ORDER BY publish_at, id LIMIT \\$3 OFFSET \\$4").
+		WithArgs(models.StatusPublished, 7, 21, 0).
+		WillReturnRows(sqlmock.NewRows(articleRowColumns("total")))
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.ScheduledArticles(models.ListParams{Limit: 20, Viewer: models.Principal{UserID: 7, Username: "Ada", Role: models.RoleAuthor}})
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Articles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPublishDueArticles(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedIDs []int
+		expectedErr error
+	}{
+		{
+			name: "Due articles are claimed and published",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("WITH due AS \\( SELECT id AS due_id FROM articles WHERE status = 'in_review' AND publish_at <= now\\(\\) ORDER BY publish_at, id LIMIT \\$1 FOR UPDATE SKIP LOCKED \\), "+
+					"changed AS \\( UPDATE articles SET status = 'published', published_at = now\\(\\), publish_at = NULL, updated_by = \\$2, revision = revision \\+ 1 FROM due WHERE id = due_id RETURNING id, title, .+ \\), "+
+					"history AS \\( INSERT INTO article_revisions .+ \\) SELECT id, title, .+ FROM changed").
+					WithArgs(10, "scheduler").
+					WillReturnRows(sqlmock.NewRows(articleRowColumns()).
+						AddRow(4, "Due", "", "Ada", "published", stamp, stamp, stamp, nil, "Ada", "scheduler", 1, 1, "due", "", nil))
+			},
+			expectedIDs: []int{4},
+		},
+		{
+			name: "Nothing is due",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FOR UPDATE SKIP LOCKED").
+					WithArgs(10, "scheduler").
+					WillReturnRows(sqlmock.NewRows(articleRowColumns()))
+			},
+			expectedIDs: []int{},
+		},
+		{
+			name: "Database unavailable",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FOR UPDATE SKIP LOCKED").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: apperrors.ErrUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			test.setupMock(mock)
+
+			repo := &PostgresDBRepo{DB: db}
+			published, err := repo.PublishDueArticles(10, "scheduler")
+
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				ids := []int{}
+				for _, article := range published {
+					ids = append(ids, article.ID)
+				}
+				assert.Equal(t, test.expectedIDs, ids)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
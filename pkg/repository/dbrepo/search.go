@@ -0,0 +1,124 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"context"
+	"html"
+	"log"
+	"strings"
+	"unicode"
+)
+
+// Matches are marked in snippets with characters from the private use
+// area, so the content around them can be escaped before they are turned
+// into <mark> tags: the content is raw Markdown that may hold any HTML.
+const (
+	headlineStart   = "\uE000"
+	headlineStop    = "\uE001"
+	headlineOptions = `MaxFragments=2, MaxWords=30, MinWords=10, StartSel="` + headlineStart + `", StopSel="` + headlineStop + `"`
+)
+
+// highlights turns the marked matches of an escaped snippet into <mark> tags
+var highlights = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// Search articles by relevance to a full-text query
+func (m *PostgresDBRepo) SearchArticles(query string, params models.ListParams) (*models.SearchPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	websearch, prefix := splitPrefixTerms(query)
+
+	// websearch_to_tsquery handles "phrases", OR and -exclusions while the
+	// terms ending in * are matched as prefixes with to_tsquery
+	sqlQuery := `
+        WITH query AS (
+            SELECT websearch_to_tsquery('english', $1) && to_tsquery('english', $2) AS q
+        )
+        SELECT
+            ` + articleColumns + `,
+            ts_rank_cd(search_vector, query.q) AS rank,
+            ts_headline('english', content, query.q, $3) AS snippet,
+            COUNT(*) OVER() AS total
+        FROM
+            articles, query
+        WHERE
+            search_vector @@ query.q
+            AND ($7 OR status = 'published' OR author_id = $6)
+        ORDER BY
+            rank DESC, id
+        LIMIT $4 OFFSET $5
+    `
+
+	// Anonymous viewers are passed as NULL, which matches no author
+	var viewer interface{}
+	if !params.Viewer.Anonymous() {
+		viewer = params.Viewer.UserID
+	}
+
+	rows, err := m.DB.QueryContext(ctx, sqlQuery, websearch, prefix, headlineOptions, params.Limit+1, params.Offset, viewer, params.AllStatuses)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.SearchPage{Results: []models.SearchResult{}}
+	for rows.Next() {
+		var result models.SearchResult
+		err := rows.Scan(articleFields(&result.Article, &result.Rank, &result.Snippet, &page.Total)...)
+		if err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		result.Snippet = highlights.Replace(html.EscapeString(result.Snippet))
+		page.Results = append(page.Results, result)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if len(page.Results) > params.Limit {
+		page.Results = page.Results[:params.Limit]
+		page.HasNext = true
+	}
+	page.HasPrev = params.Offset > 0
+
+	articles := make([]*models.Article, len(page.Results))
+	for i := range page.Results {
+		articles[i] = &page.Results[i].Article
+	}
+	if err := m.attachRelations(ctx, articles...); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// splitPrefixTerms separates the words ending in * from the rest of a search
+// query and returns them as a to_tsquery expression such as "dock:* & kube:*".
+// Only letters and digits are kept in prefix terms so the expression is
+// always valid.
+func splitPrefixTerms(query string) (string, string) {
+	var words, prefixes []string
+
+	for _, word := range strings.Fields(query) {
+		if !strings.HasSuffix(word, "*") || strings.HasPrefix(word, "\"") || strings.HasPrefix(word, "-") {
+			words = append(words, word)
+			continue
+		}
+
+		term := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return -1
+		}, word)
+		if term != "" {
+			prefixes = append(prefixes, term+":*")
+		}
+	}
+
+	return strings.Join(words, " "), strings.Join(prefixes, " & ")
+}
@@ -0,0 +1,93 @@
+package dbrepo
+
+import (
+	"backend/pkg/models"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchArticles(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	rows := sqlmock.NewRows(articleRowColumns("rank", "snippet", "total")).
+		AddRow(2, "Docker basics", "Content", "John", "published", stamp, stamp, stamp, nil, "John", "John", 1, 1, "docker-basics", "<p>Content</p>\n", nil, 0.9, "\uE000Docker\uE001 basics", 3).
+		AddRow(1, "Kubernetes", "Docker content", "Jane", "published", stamp, stamp, stamp, nil, "Jane", "Jane", 1, 1, "kubernetes", "<p>Docker content</p>\n", nil, 0.4, "\uE000Docker\uE001 <script>alert(1)</script>", 3).
+		AddRow(3, "More", "Docker", "Jane", "published", stamp, stamp, stamp, nil, "Jane", "Jane", 1, 1, "more", "<p>Docker</p>\n", nil, 0.1, "\uE000Docker\uE001", 3)
+
+	mock.ExpectQuery("websearch_to_tsquery\\('english', \\$1\\) && to_tsquery\\('english', \\$2\\)").
+		WithArgs(`"docker basics"`, "kube:*", headlineOptions, 3, 0, nil, false).
+		WillReturnRows(rows)
+	expectTaxonomy(mock, "{2,1}")
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.SearchArticles(`"docker basics" kube*`, models.ListParams{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Results, 2)
+	assert.Equal(t, 2, page.Results[0].ID)
+	assert.Equal(t, 0.9, page.Results[0].Rank)
+	assert.Equal(t, "<mark>Docker</mark> basics", page.Results[0].Snippet)
+	// The content around the matches is escaped
+	assert.Equal(t, "<mark>Docker</mark> &lt;script&gt;alert(1)&lt;/script&gt;", page.Results[1].Snippet)
+	assert.Equal(t, 3, page.Total)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchArticles_OwnArticles(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// Signed in viewers also find the articles they wrote, by their ID
+	mock.ExpectQuery("AND \\(\\$7 OR status = 'published' OR author_id = \\$6\\)").
+		WithArgs("docker", "", headlineOptions, 3, 0, 7, false).
+		WillReturnRows(sqlmock.NewRows(articleRowColumns("rank", "snippet", "total")))
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.SearchArticles("docker", models.ListParams{Limit: 2, Viewer: models.Principal{UserID: 7, Username: "Ada", Role: models.RoleAuthor}})
+
+	assert.NoError(t, err)
+	assert.Empty(t, page.Results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchArticlesError(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("websearch_to_tsquery").WillReturnError(fmt.Errorf("Test query error"))
+
+	repo := &PostgresDBRepo{DB: db}
+	page, err := repo.SearchArticles("docker", models.ListParams{Limit: 2})
+
+	assert.ErrorContains(t, err, "Test query error")
+	assert.Nil(t, page)
+}
+
+func TestSplitPrefixTerms(t *testing.T) {
+	tests := []struct {
+		query             string
+		expectedWebsearch string
+		expectedPrefix    string
+	}{
+		{query: "docker", expectedWebsearch: "docker", expectedPrefix: ""},
+		{query: "kube*", expectedWebsearch: "", expectedPrefix: "kube:*"},
+		{query: `"exact phrase" dock* or kube*`, expectedWebsearch: `"exact phrase" or`, expectedPrefix: "dock:* & kube:*"},
+		{query: "-windows Post'gr*", expectedWebsearch: "-windows", expectedPrefix: "postgr:*"},
+		{query: "*", expectedWebsearch: "", expectedPrefix: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			websearch, prefix := splitPrefixTerms(test.query)
+
+			assert.Equal(t, test.expectedWebsearch, websearch)
+			assert.Equal(t, test.expectedPrefix, prefix)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// Return the pages of size published articles each that sitemaps list, in
+// order, with when the last article on each changed
+func (m *PostgresDBRepo) SitemapPages(size int) ([]models.SitemapPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var b queryBuilder
+	b.visibleTo(models.Principal{}, false)
+
+	query := fmt.Sprintf(`
+        SELECT
+            page + 1, max(modified)
+        FROM (
+            SELECT
+                (row_number() OVER (ORDER BY id) - 1) / %s AS page,
+                greatest(updated_at, published_at) AS modified
+            FROM
+                articles
+            %s
+        ) pages
+        GROUP BY
+            page
+        ORDER BY
+            page
+    `, b.arg(size), b.whereClause())
+
+	rows, err := m.DB.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	pages := []models.SitemapPage{}
+	for rows.Next() {
+		var page models.SitemapPage
+		if err := rows.Scan(&page.Number, &page.Modified); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		pages = append(pages, page)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return pages, nil
+}
+
+// Return the published articles on a page of size of them, in the order of
+// SitemapPages, with the URL of their cover
+func (m *PostgresDBRepo) SitemapEntries(page, size int) ([]models.SitemapEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	var b queryBuilder
+	b.visibleTo(models.Principal{}, false)
+
+	query := fmt.Sprintf(`
+        SELECT
+            id, slug, greatest(updated_at, published_at), cover_id,
+            (SELECT max(width) FROM media_variants WHERE media_id = articles.cover_id)
+        FROM
+            articles
+        %s
+        ORDER BY
+            id
+        LIMIT %s OFFSET %s
+    `, b.whereClause(), b.arg(size), b.arg((page-1)*size))
+
+	rows, err := m.DB.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	entries := []models.SitemapEntry{}
+	for rows.Next() {
+		var entry models.SitemapEntry
+		var coverID, width sql.NullInt64
+		if err := rows.Scan(&entry.ArticleID, &entry.Slug, &entry.Modified, &coverID, &width); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		switch {
+		case width.Valid:
+			entry.CoverURL = models.VariantURL(int(coverID.Int64), int(width.Int64))
+		case coverID.Valid:
+			entry.CoverURL = models.MediaURL(int(coverID.Int64))
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return entries, nil
+}
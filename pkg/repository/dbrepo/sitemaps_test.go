@@ -0,0 +1,54 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSitemapPages(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT page \\+ 1, max\\(modified\\) FROM \\( SELECT \\(row_number\\(\\) OVER \\(ORDER BY id\\) - 1\\) / \\$2 AS page, greatest\\(updated_at, published_at\\) AS modified FROM articles WHERE status = \\$1 \\) pages GROUP BY page ORDER BY page").
+		WithArgs(models.StatusPublished, 50000).
+		WillReturnRows(sqlmock.NewRows([]string{"page", "modified"}).AddRow(1, stamp).AddRow(2, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+	pages, err := repo.SitemapPages(50000)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SitemapPage{{Number: 1, Modified: stamp}, {Number: 2, Modified: stamp}}, pages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectQuery("FROM articles").WillReturnError(sql.ErrConnDone)
+	_, err = repo.SitemapPages(50000)
+	assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSitemapEntries(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"id", "slug", "modified", "cover_id", "width"}).
+		AddRow(4, "resized", stamp, 3, 1600).
+		AddRow(5, "processing", stamp, 6, nil).
+		AddRow(7, "no-cover", stamp, nil, nil)
+	mock.ExpectQuery("SELECT id, slug, greatest\\(updated_at, published_at\\), cover_id, \\(SELECT max\\(width\\) FROM media_variants WHERE media_id = articles.cover_id\\) FROM articles WHERE status = \\$1 ORDER BY id LIMIT \\$2 OFFSET \\$3").
+		WithArgs(models.StatusPublished, 3, 3).
+		WillReturnRows(rows)
+
+	repo := &PostgresDBRepo{DB: db}
+	entries, err := repo.SitemapEntries(2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SitemapEntry{
+		{ArticleID: 4, Slug: "resized", Modified: stamp, CoverURL: "/media/3/variants/1600"},
+		{ArticleID: 5, Slug: "processing", Modified: stamp, CoverURL: "/media/6"},
+		{ArticleID: 7, Slug: "no-cover", Modified: stamp},
+	}, entries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,102 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+)
+
+// Retrieve the article with a slug, current or old. The article is returned
+// with its current slug, which differs from slug when slug is an old one.
+func (m *PostgresDBRepo) ArticleBySlug(slug string) (*models.Article, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + articleColumns + `
+        FROM
+            articles
+        WHERE
+            slug = $1
+            OR id = (SELECT article_id FROM article_slugs WHERE slug = $1)
+    `
+
+	var article models.Article
+	err := m.DB.QueryRowContext(ctx, query, slug).Scan(articleFields(&article)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.NoArticleforslug, err)
+			return nil, apperrors.NotFound(appconst.NoArticleforslug, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	if err := m.attachRelations(ctx, &article); err != nil {
+		return nil, err
+	}
+
+	return &article, nil
+}
+
+// uniqueSlug returns base, or base with the lowest number suffix that no
+// other article uses as its slug or had as an old one. The slugs of the
+// article with ID id, 0 for a new article, are free for it to take.
+//
+// Articles saved at the same time would both find the same slug free, so
+// the slugs of a stem are taken one transaction at a time: tx holds a lock
+// on the stem until it ends, after which the slug it took is seen as taken.
+func uniqueSlug(ctx context.Context, tx *sql.Tx, base string, id int) (string, error) {
+	_, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, slugStem(base))
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return "", translateError(err)
+	}
+
+	query := `
+        SELECT slug FROM articles
+        WHERE (slug = $1 OR slug LIKE $2) AND id <> $3
+        UNION ALL
+        SELECT slug FROM article_slugs
+        WHERE (slug = $1 OR slug LIKE $2) AND article_id <> $3
+    `
+
+	taken, err := queryStrings(ctx, tx, query, base, base+"-%", id)
+	if err != nil {
+		return "", err
+	}
+	return nextSlug(base, taken), nil
+}
+
+// numberSuffixes matches the numbers a slug ends with, nextSlug adding one
+// to a base that may already end with some
+var numberSuffixes = regexp.MustCompile(`(-[0-9]+)+$`)
+
+// slugStem returns base without the numbers it ends with. Every slug
+// uniqueSlug may pick for base has the same stem as base, so bases that
+// could pick the same slug are locked together: "web-2" and "web-2-2",
+// which can both become "web-2-2", share the stem "web" with "web".
+func slugStem(base string) string {
+	return numberSuffixes.ReplaceAllString(base, "")
+}
+
+// nextSlug returns base if it is not taken, and otherwise base-2, base-3
+// and so on, whichever is free first.
+func nextSlug(base string, taken []string) string {
+	used := make(map[string]bool, len(taken))
+	for _, slug := range taken {
+		used[slug] = true
+	}
+
+	slug := base
+	for n := 2; used[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug
+}
@@ -0,0 +1,122 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectUniqueSlug expects the slugs of the stem of base to be locked and
+// the slugs like base that the article with ID id cannot take to be read;
+// taken are the slugs returned
+func expectUniqueSlug(mock sqlmock.Sqlmock, base string, id int, taken ...string) {
+	mock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\(\\$1\\)\\)").
+		WithArgs(slugStem(base)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"slug"})
+	for _, slug := range taken {
+		rows.AddRow(slug)
+	}
+	mock.ExpectQuery("SELECT slug FROM articles WHERE \\(slug = \\$1 OR slug LIKE \\$2\\) AND id <> \\$3 UNION ALL SELECT slug FROM article_slugs WHERE \\(slug = \\$1 OR slug LIKE \\$2\\) AND article_id <> \\$3").
+		WithArgs(base, base+"-%", id).
+		WillReturnRows(rows)
+}
+
+func TestArticleBySlug(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	repo := &PostgresDBRepo{DB: db}
+
+	// An old slug finds the article under its current one
+	mock.ExpectQuery("SELECT id, title, .+, slug, content_html, cover_id FROM articles WHERE slug = \\$1 OR id = \\(SELECT article_id FROM article_slugs WHERE slug = \\$1\\)").
+		WithArgs("old-title").
+		WillReturnRows(sqlmock.NewRows(articleRowColumns()).
+			AddRow(1, "New title", "Content", "Ada", "published", stamp, stamp, stamp, nil, "Ada", "Ada", 2, 1, "new-title", "<p>Content</p>\n", nil))
+	expectTaxonomy(mock, "{1}")
+
+	article, err := repo.ArticleBySlug("old-title")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, article.ID)
+	assert.Equal(t, "new-title", article.Slug)
+
+	mock.ExpectQuery("SELECT .+ FROM articles WHERE slug = \\$1").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = repo.ArticleBySlug("missing")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.EqualError(t, err, appconst.NoArticleforslug+": "+sql.ErrNoRows.Error())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUniqueSlug(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectBegin()
+	expectUniqueSlug(mock, "hello", 4, "hello", "hello-2", "hello-world")
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	slug, err := uniqueSlug(context.Background(), tx, "hello", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello-3", slug)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUniqueSlug_OverlappingStems(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// "web-2" with "web-2" taken and "web-2-2" free picks "web-2-2", like a
+	// new "web-2-2" would; both wait for the lock on "web" first
+	for _, base := range []string{"web-2", "web-2-2"} {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\(\\$1\\)\\)").
+			WithArgs("web").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("SELECT slug FROM articles").
+			WithArgs(base, base+"-%", 0).
+			WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("web-2"))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+		slug, err := uniqueSlug(context.Background(), tx, base, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "web-2-2", slug)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSlugStem(t *testing.T) {
+	assert.Equal(t, "hello", slugStem("hello"))
+	assert.Equal(t, "hello", slugStem("hello-2"))
+	assert.Equal(t, "hello-world", slugStem("hello-world"))
+	assert.Equal(t, "web", slugStem("web-2-0-10"))
+	// Bases that can pick the same slug share a stem
+	assert.Equal(t, "web", slugStem("web-2"))
+	assert.Equal(t, "web", slugStem("web-2-2"))
+	assert.Equal(t, "2024", slugStem("2024-10"))
+}
+
+func TestNextSlug(t *testing.T) {
+	testCases := []struct {
+		base     string
+		taken    []string
+		expected string
+	}{
+		{base: "go", taken: nil, expected: "go"},
+		{base: "go", taken: []string{"go-2", "go-tips"}, expected: "go"},
+		{base: "go", taken: []string{"go"}, expected: "go-2"},
+		{base: "go", taken: []string{"go", "go-2", "go-4"}, expected: "go-3"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, nextSlug(tc.base, tc.taken), tc.taken)
+	}
+}
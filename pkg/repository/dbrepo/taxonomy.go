@@ -0,0 +1,469 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/utility"
+	"context"
+	"database/sql"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+// TaxonomyRepo stores the tags and categories articles are organized by.
+// The tags and categories of an article are written with the article and
+// read back with it.
+type TaxonomyRepo interface {
+	Tags(params models.ListParams) (*models.TagPage, error)
+	OneTag(slug string) (*models.Tag, error)
+	RenameTag(tag *models.Tag) error
+	MergeTags(from, into int) error
+	Categories() ([]models.Category, error)
+	OneCategory(slug string) (*models.Category, error)
+	CreateCategory(category *models.Category) error
+	UpdateCategory(category *models.Category) error
+	DeleteCategory(id int) error
+}
+
+// tagColumns are the columns of a tag with its number of published
+// articles, in the order of tagFields. They need tagsCounted.
+const tagColumns = `tags.id, tags.name, tags.slug, COUNT(articles.id), tags.created_at`
+
+// tagsCounted joins tags with their published articles, grouped by tag
+const tagsCounted = `
+            tags
+            LEFT JOIN article_tags ON article_tags.tag_id = tags.id
+            LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.status = 'published'`
+
+// tagFields returns the scan destinations for tagColumns followed by extra
+func tagFields(tag *models.Tag, extra ...interface{}) []interface{} {
+	return append([]interface{}{
+		&tag.ID,
+		&tag.Name,
+		&tag.Slug,
+		&tag.Articles,
+		&tag.CreatedAt,
+	}, extra...)
+}
+
+// categoryColumns are the columns of a category with its number of
+// published articles, in the order of categoryFields. They need
+// categoriesCounted.
+const categoryColumns = `categories.id, categories.parent_id, categories.name, categories.slug, COUNT(articles.id), categories.created_at`
+
+// categoriesCounted joins categories with their published articles,
+// grouped by category
+const categoriesCounted = `
+            categories
+            LEFT JOIN article_categories ON article_categories.category_id = categories.id
+            LEFT JOIN articles ON articles.id = article_categories.article_id AND articles.status = 'published'`
+
+// categoryFields returns the scan destinations for categoryColumns
+func categoryFields(category *models.Category) []interface{} {
+	return []interface{}{
+		&category.ID,
+		&category.ParentID,
+		&category.Name,
+		&category.Slug,
+		&category.Articles,
+		&category.CreatedAt,
+	}
+}
+
+// Return one page of tags ordered by name
+func (m *PostgresDBRepo) Tags(params models.ListParams) (*models.TagPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + tagColumns + `,
+            COUNT(*) OVER() AS total
+        FROM` + tagsCounted + `
+        GROUP BY
+            tags.id
+        ORDER BY
+            tags.name, tags.id
+        LIMIT $1 OFFSET $2
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, params.Limit+1, params.Offset)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.TagPage{Tags: []models.Tag{}}
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(tagFields(&tag, &page.Total)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		page.Tags = append(page.Tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if len(page.Tags) > params.Limit {
+		page.Tags = page.Tags[:params.Limit]
+		page.HasNext = true
+	}
+	page.HasPrev = params.Offset > 0
+
+	return page, nil
+}
+
+// Retrieve the tag with a slug
+func (m *PostgresDBRepo) OneTag(slug string) (*models.Tag, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + tagColumns + `
+        FROM` + tagsCounted + `
+        WHERE
+            tags.slug = $1
+        GROUP BY
+            tags.id
+    `
+
+	var tag models.Tag
+	err := m.DB.QueryRowContext(ctx, query, slug).Scan(tagFields(&tag)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Notag, err)
+			return nil, apperrors.NotFound(appconst.Notag, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &tag, nil
+}
+
+// Give a tag a new name and slug
+func (m *PostgresDBRepo) RenameTag(tag *models.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE tags
+        SET name = $2, slug = $3
+        WHERE id = $1
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, tag.ID, tag.Name, tag.Slug)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return checkAffected(result, appconst.Notag)
+}
+
+// Move the articles of one tag to another and delete the first tag
+func (m *PostgresDBRepo) MergeTags(from, into int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	// Deleting the tag deletes what is left of its article_tags
+	query := `
+        WITH moved AS (
+            INSERT INTO article_tags (article_id, tag_id)
+            SELECT article_id, $2 FROM article_tags WHERE tag_id = $1
+            ON CONFLICT DO NOTHING
+        )
+        DELETE FROM tags
+        WHERE id = $1
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, from, into)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return checkAffected(result, appconst.Notag)
+}
+
+// Return every category ordered by name; callers build the tree from the
+// parent IDs
+func (m *PostgresDBRepo) Categories() ([]models.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + categoryColumns + `
+        FROM` + categoriesCounted + `
+        GROUP BY
+            categories.id
+        ORDER BY
+            categories.name, categories.id
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	categories := []models.Category{}
+	for rows.Next() {
+		var category models.Category
+		if err := rows.Scan(categoryFields(&category)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return categories, nil
+}
+
+// Retrieve the category with a slug
+func (m *PostgresDBRepo) OneCategory(slug string) (*models.Category, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + categoryColumns + `
+        FROM` + categoriesCounted + `
+        WHERE
+            categories.slug = $1
+        GROUP BY
+            categories.id
+    `
+
+	var category models.Category
+	err := m.DB.QueryRowContext(ctx, query, slug).Scan(categoryFields(&category)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Nocategory, err)
+			return nil, apperrors.NotFound(appconst.Nocategory, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &category, nil
+}
+
+// Create a category
+func (m *PostgresDBRepo) CreateCategory(category *models.Category) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        INSERT INTO categories (parent_id, name, slug)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, category.ParentID, category.Name, category.Slug).Scan(&category.ID, &category.CreatedAt)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// Rename a category or move it under another parent
+func (m *PostgresDBRepo) UpdateCategory(category *models.Category) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE categories
+        SET parent_id = $2, name = $3, slug = $4
+        WHERE id = $1
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, category.ID, category.ParentID, category.Name, category.Slug)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return checkAffected(result, appconst.Nocategory)
+}
+
+// Delete a category without children; its articles are taken out of it
+func (m *PostgresDBRepo) DeleteCategory(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        DELETE FROM categories
+        WHERE id = $1
+    `
+
+	result, err := m.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return checkAffected(result, appconst.Nocategory)
+}
+
+// saveTaxonomy replaces the tags and categories of an article within tx.
+// Tags are created the first time they are used and an existing tag keeps
+// its name, so article.Tags is read back with the stored names. Unknown
+// categories are rejected.
+func saveTaxonomy(ctx context.Context, tx *sql.Tx, article *models.Article) error {
+	slugs := make([]string, len(article.Tags))
+	for i, name := range article.Tags {
+		slugs[i] = utility.Slugify(name)
+	}
+
+	tagQuery := `
+        WITH wanted AS (
+            SELECT name, slug FROM unnest(CAST($2 AS TEXT[]), CAST($3 AS TEXT[])) AS wanted (name, slug)
+        ), created AS (
+            INSERT INTO tags (name, slug)
+            SELECT name, slug FROM wanted
+            ON CONFLICT (slug) DO NOTHING
+            RETURNING id, name
+        ), chosen AS (
+            SELECT id, name FROM created
+            UNION
+            SELECT tags.id, tags.name FROM tags JOIN wanted ON wanted.slug = tags.slug
+        ), removed AS (
+            DELETE FROM article_tags
+            WHERE article_id = $1 AND tag_id NOT IN (SELECT id FROM chosen)
+        ), added AS (
+            INSERT INTO article_tags (article_id, tag_id)
+            SELECT $1, id FROM chosen
+            ON CONFLICT DO NOTHING
+        )
+        SELECT name FROM chosen ORDER BY name
+    `
+
+	tags, err := queryStrings(ctx, tx, tagQuery, article.ID, pq.Array(article.Tags), pq.Array(slugs))
+	if err != nil {
+		return err
+	}
+
+	categoryQuery := `
+        WITH chosen AS (
+            SELECT id, slug FROM categories WHERE slug = ANY(CAST($2 AS TEXT[]))
+        ), removed AS (
+            DELETE FROM article_categories
+            WHERE article_id = $1 AND category_id NOT IN (SELECT id FROM chosen)
+        ), added AS (
+            INSERT INTO article_categories (article_id, category_id)
+            SELECT $1, id FROM chosen
+            ON CONFLICT DO NOTHING
+        )
+        SELECT slug FROM chosen ORDER BY slug
+    `
+
+	categories, err := queryStrings(ctx, tx, categoryQuery, article.ID, pq.Array(article.Categories))
+	if err != nil {
+		return err
+	}
+	if len(categories) != len(article.Categories) {
+		return apperrors.Validation(appconst.Unknowncategory, nil)
+	}
+
+	article.Tags, article.Categories = tags, categories
+	return nil
+}
+
+// queryStrings runs a query within tx returning one text column
+func queryStrings(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return values, nil
+}
+
+// attachTaxonomy reads the tag names and category slugs of articles, both
+// sorted, with one query for all of them.
+func (m *PostgresDBRepo) attachTaxonomy(ctx context.Context, articles ...*models.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*models.Article, len(articles))
+	ids := make([]int, len(articles))
+	for i, article := range articles {
+		byID[article.ID] = article
+		ids[i] = article.ID
+	}
+
+	query := `
+        SELECT article_tags.article_id, 'tag', tags.name
+        FROM article_tags JOIN tags ON tags.id = article_tags.tag_id
+        WHERE article_tags.article_id = ANY($1)
+        UNION ALL
+        SELECT article_categories.article_id, 'category', categories.slug
+        FROM article_categories JOIN categories ON categories.id = article_categories.category_id
+        WHERE article_categories.article_id = ANY($1)
+        ORDER BY 3
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var kind, value string
+		if err := rows.Scan(&id, &kind, &value); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return translateError(err)
+		}
+		article, ok := byID[id]
+		if !ok {
+			continue
+		}
+		if kind == "tag" {
+			article.Tags = append(article.Tags, value)
+		} else {
+			article.Categories = append(article.Categories, value)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return translateError(err)
+	}
+
+	return nil
+}
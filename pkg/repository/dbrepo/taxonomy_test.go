@@ -0,0 +1,272 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tagRowColumns      = []string{"id", "name", "slug", "articles", "created_at"}
+	categoryRowColumns = []string{"id", "parent_id", "name", "slug", "articles", "created_at"}
+)
+
+// taxonomyQuery matches the query reading the tags and categories of articles
+const taxonomyQuery = "SELECT article_tags.article_id, 'tag', tags.name FROM article_tags JOIN tags ON tags.id = article_tags.tag_id WHERE article_tags.article_id = ANY\\(\\$1\\) UNION ALL .+ ORDER BY 3"
+
+// expectTaxonomy expects the tags and categories of the articles with ids,
+// such as "{1,2}", to be read; the articles have none
+func expectTaxonomy(mock sqlmock.Sqlmock, ids string) {
+	mock.ExpectQuery(taxonomyQuery).
+		WithArgs(ids).
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "kind", "value"}))
+}
+
+// expectSaveTaxonomy expects the tags and categories of article id to be
+// cleared
+func expectSaveTaxonomy(mock sqlmock.Sqlmock, id int) {
+	mock.ExpectQuery("WITH wanted AS .+ SELECT name FROM chosen ORDER BY name").
+		WithArgs(id, nil, "{}").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectQuery("WITH chosen AS .+ SELECT slug FROM chosen ORDER BY slug").
+		WithArgs(id, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}))
+}
+
+func TestSaveTaxonomy(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// New tags are created and existing ones keep their stored name
+	mock.ExpectBegin()
+	mock.ExpectQuery("WITH wanted AS \\( SELECT name, slug FROM unnest\\(CAST\\(\\$2 AS TEXT\\[\\]\\), CAST\\(\\$3 AS TEXT\\[\\]\\)\\) AS wanted \\(name, slug\\) \\), "+
+		"created AS \\( INSERT INTO tags \\(name, slug\\) SELECT name, slug FROM wanted ON CONFLICT \\(slug\\) DO NOTHING RETURNING id, name \\), .+ SELECT name FROM chosen ORDER BY name").
+		WithArgs(1, `{"go","Web Development"}`, `{"go","web-development"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Go").AddRow("Web Development"))
+	mock.ExpectQuery("WITH chosen AS \\( SELECT id, slug FROM categories WHERE slug = ANY\\(CAST\\(\\$2 AS TEXT\\[\\]\\)\\) \\), .+ SELECT slug FROM chosen ORDER BY slug").
+		WithArgs(1, `{"backend"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("backend"))
+	// An unknown category rolls the change back
+	mock.ExpectQuery("WITH wanted AS").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectQuery("WITH chosen AS").
+		WithArgs(1, `{"backend","missing"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"slug"}).AddRow("backend"))
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	article := &models.Article{ID: 1, Tags: []string{"go", "Web Development"}, Categories: []string{"backend"}}
+	assert.NoError(t, saveTaxonomy(context.Background(), tx, article))
+	assert.Equal(t, []string{"Go", "Web Development"}, article.Tags)
+	assert.Equal(t, []string{"backend"}, article.Categories)
+
+	err = saveTaxonomy(context.Background(), tx, &models.Article{ID: 1, Categories: []string{"backend", "missing"}})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachTaxonomy(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery(taxonomyQuery).
+		WithArgs("{1,2}").
+		WillReturnRows(sqlmock.NewRows([]string{"article_id", "kind", "value"}).
+			AddRow(2, "category", "backend").
+			AddRow(1, "tag", "Go").
+			AddRow(2, "tag", "Go").
+			AddRow(2, "tag", "Postgres"))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	articles := []models.Article{{ID: 1}, {ID: 2}}
+	assert.NoError(t, repo.attachTaxonomy(context.Background(), articlePointers(articles)...))
+	assert.Equal(t, []string{"Go"}, articles[0].Tags)
+	assert.Nil(t, articles[0].Categories)
+	assert.Equal(t, []string{"Go", "Postgres"}, articles[1].Tags)
+	assert.Equal(t, []string{"backend"}, articles[1].Categories)
+
+	// No articles need no query
+	assert.NoError(t, repo.attachTaxonomy(context.Background()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTags(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT tags.id, tags.name, tags.slug, COUNT\\(articles.id\\), tags.created_at, COUNT\\(\\*\\) OVER\\(\\) AS total FROM tags "+
+		"LEFT JOIN article_tags ON article_tags.tag_id = tags.id LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.status = 'published' "+
+		"GROUP BY tags.id ORDER BY tags.name, tags.id LIMIT \\$1 OFFSET \\$2").
+		WithArgs(2, 0).
+		WillReturnRows(sqlmock.NewRows(append(tagRowColumns, "total")).
+			AddRow(1, "Go", "go", 3, stamp, 2).
+			AddRow(2, "Postgres", "postgres", 0, stamp, 2))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	page, err := repo.Tags(models.ListParams{Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Tag{{ID: 1, Name: "Go", Slug: "go", Articles: 3, CreatedAt: &stamp}}, page.Tags)
+	assert.Equal(t, models.PageInfo{Total: 2, HasNext: true}, page.PageInfo)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOneTag(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "SELECT tags.id, .+ FROM tags .+ WHERE tags.slug = \\$1 GROUP BY tags.id"
+	mock.ExpectQuery(query).
+		WithArgs("go").
+		WillReturnRows(sqlmock.NewRows(tagRowColumns).AddRow(1, "Go", "go", 3, stamp))
+	mock.ExpectQuery(query).
+		WithArgs("rust").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	tag, err := repo.OneTag("go")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Tag{ID: 1, Name: "Go", Slug: "go", Articles: 3, CreatedAt: &stamp}, tag)
+
+	_, err = repo.OneTag("rust")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRenameTag(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE tags SET name = \\$2, slug = \\$3 WHERE id = \\$1"
+	mock.ExpectExec(query).
+		WithArgs(1, "Golang", "golang").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs(9, "Golang", "golang").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.RenameTag(&models.Tag{ID: 1, Name: "Golang", Slug: "golang"}))
+	assert.ErrorIs(t, repo.RenameTag(&models.Tag{ID: 9, Name: "Golang", Slug: "golang"}), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeTags(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectExec("WITH moved AS \\( INSERT INTO article_tags \\(article_id, tag_id\\) SELECT article_id, \\$2 FROM article_tags WHERE tag_id = \\$1 ON CONFLICT DO NOTHING \\) DELETE FROM tags WHERE id = \\$1").
+		WithArgs(2, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.MergeTags(2, 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCategories(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT categories.id, categories.parent_id, categories.name, categories.slug, COUNT\\(articles.id\\), categories.created_at FROM categories " +
+		"LEFT JOIN article_categories ON article_categories.category_id = categories.id LEFT JOIN articles ON articles.id = article_categories.article_id AND articles.status = 'published' " +
+		"GROUP BY categories.id ORDER BY categories.name, categories.id").
+		WillReturnRows(sqlmock.NewRows(categoryRowColumns).
+			AddRow(1, nil, "Backend", "backend", 2, stamp).
+			AddRow(2, 1, "Databases", "databases", 1, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	parent := 1
+	categories, err := repo.Categories()
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Category{
+		{ID: 1, Name: "Backend", Slug: "backend", Articles: 2, CreatedAt: &stamp},
+		{ID: 2, ParentID: &parent, Name: "Databases", Slug: "databases", Articles: 1, CreatedAt: &stamp},
+	}, categories)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOneCategory(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "SELECT categories.id, .+ FROM categories .+ WHERE categories.slug = \\$1 GROUP BY categories.id"
+	mock.ExpectQuery(query).
+		WithArgs("backend").
+		WillReturnRows(sqlmock.NewRows(categoryRowColumns).AddRow(1, nil, "Backend", "backend", 2, stamp))
+	mock.ExpectQuery(query).
+		WithArgs("frontend").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	category, err := repo.OneCategory("backend")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Category{ID: 1, Name: "Backend", Slug: "backend", Articles: 2, CreatedAt: &stamp}, category)
+
+	_, err = repo.OneCategory("frontend")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateCategory(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	parent := 1
+	mock.ExpectQuery("INSERT INTO categories \\(parent_id, name, slug\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id, created_at").
+		WithArgs(&parent, "Databases", "databases").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	category := &models.Category{ParentID: &parent, Name: "Databases", Slug: "databases"}
+	assert.NoError(t, repo.CreateCategory(category))
+	assert.Equal(t, 2, category.ID)
+	assert.Equal(t, &stamp, category.CreatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateCategory(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE categories SET parent_id = \\$2, name = \\$3, slug = \\$4 WHERE id = \\$1"
+	mock.ExpectExec(query).
+		WithArgs(2, nil, "Data", "data").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs(9, nil, "Data", "data").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.UpdateCategory(&models.Category{ID: 2, Name: "Data", Slug: "data"}))
+	assert.ErrorIs(t, repo.UpdateCategory(&models.Category{ID: 9, Name: "Data", Slug: "data"}), apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteCategory(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM categories WHERE id = \\$1").
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.DeleteCategory(2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
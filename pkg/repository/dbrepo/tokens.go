@@ -0,0 +1,83 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"log"
+)
+
+// Store a new refresh token
+func (m *PostgresDBRepo) CreateRefreshToken(token *models.RefreshToken) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        INSERT INTO refresh_tokens (user_id, token_hash, family, expires_at)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, token.UserID, token.TokenHash, token.Family, token.ExpiresAt).Scan(&token.ID)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
+
+// UseRefreshToken marks a refresh token as used and returns it. Each token
+// can be used once: tokens that were used before, revoked, expired or never
+// issued are not found.
+func (m *PostgresDBRepo) UseRefreshToken(hash string) (*models.RefreshToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE refresh_tokens
+        SET used_at = now()
+        WHERE token_hash = $1
+            AND used_at IS NULL
+            AND revoked_at IS NULL
+            AND expires_at > now()
+        RETURNING id, user_id, token_hash, family, expires_at
+    `
+
+	var token models.RefreshToken
+	err := m.DB.QueryRowContext(ctx, query, hash).
+		Scan(&token.ID, &token.UserID, &token.TokenHash, &token.Family, &token.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperrors.NotFound(appconst.Invalidrefresh, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &token, nil
+}
+
+// RevokeRefreshTokens revokes every token of the family the token with
+// the given hash belongs to. Unknown hashes revoke nothing.
+func (m *PostgresDBRepo) RevokeRefreshTokens(hash string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE refresh_tokens
+        SET revoked_at = now()
+        WHERE family = (SELECT family FROM refresh_tokens WHERE token_hash = $1)
+            AND revoked_at IS NULL
+    `
+
+	_, err := m.DB.ExecContext(ctx, query, hash)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return translateError(err)
+	}
+
+	return nil
+}
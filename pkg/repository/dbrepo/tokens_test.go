@@ -0,0 +1,65 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRefreshToken(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO refresh_tokens \\(user_id, token_hash, family, expires_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING id").
+		WithArgs(4, "hash", "family", stamp).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+
+	repo := &PostgresDBRepo{DB: db}
+	token := &models.RefreshToken{UserID: 4, TokenHash: "hash", Family: "family", ExpiresAt: stamp}
+
+	assert.NoError(t, repo.CreateRefreshToken(token))
+	assert.Equal(t, 9, token.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUseRefreshToken(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	// Only tokens that are unused, not revoked and not expired can be used
+	query := "UPDATE refresh_tokens SET used_at = now\\(\\) WHERE token_hash = \\$1 AND used_at IS NULL AND revoked_at IS NULL AND expires_at > now\\(\\) RETURNING id, user_id, token_hash, family, expires_at"
+	mock.ExpectQuery(query).
+		WithArgs("hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "family", "expires_at"}).AddRow(9, 4, "hash", "family", stamp))
+	mock.ExpectQuery(query).
+		WithArgs("hash").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	token, err := repo.UseRefreshToken("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.RefreshToken{ID: 9, UserID: 4, TokenHash: "hash", Family: "family", ExpiresAt: stamp}, token)
+
+	_, err = repo.UseRefreshToken("hash")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeRefreshTokens(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked_at = now\\(\\) WHERE family = \\(SELECT family FROM refresh_tokens WHERE token_hash = \\$1\\) AND revoked_at IS NULL").
+		WithArgs("hash").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	assert.NoError(t, repo.RevokeRefreshTokens("hash"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,222 @@
+package dbrepo
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/lib/pq"
+)
+
+// UserRepo stores user accounts, their refresh tokens and their API keys.
+// PostgresDBRepo implements it next to the article queries.
+type UserRepo interface {
+	CreateUser(user *models.User) (int, error)
+	OneUser(id int) (*models.User, error)
+	UserByUsername(username string) (*models.User, error)
+	UsersByIDs(ids []int) ([]models.User, error)
+	AllUsers(params models.ListParams) (*models.UserPage, error)
+	SetUserRole(id int, role string) (*models.User, error)
+	CreateRefreshToken(token *models.RefreshToken) error
+	UseRefreshToken(hash string) (*models.RefreshToken, error)
+	RevokeRefreshTokens(hash string) error
+	CreateAPIKey(key *models.APIKey) error
+	APIKeys(userID int) ([]models.APIKey, error)
+	OneAPIKey(id int) (*models.APIKey, error)
+	RevokeAPIKey(id int) error
+	UseAPIKey(hash string) (*models.APIKey, error)
+}
+
+// userColumns are the columns of a user, in the order of userFields
+const userColumns = `id, username, COALESCE(email, ''), role, password_hash, created_at, updated_at`
+
+// userFields returns the scan destinations for userColumns
+func userFields(user *models.User) []interface{} {
+	return []interface{}{
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.Role,
+		&user.PasswordHash,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	}
+}
+
+// Create a new user with the default role. A username or email that is
+// already registered, in any case, is a conflict.
+func (m *PostgresDBRepo) CreateUser(user *models.User) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        INSERT INTO users (username, email, password_hash)
+        VALUES ($1, $2, $3)
+        RETURNING id, role, created_at, updated_at
+    `
+
+	err := m.DB.QueryRowContext(ctx, query, user.Username, user.Email, user.PasswordHash).
+		Scan(&user.ID, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		err = translateError(err)
+		if errors.Is(err, apperrors.ErrConflict) {
+			return 0, apperrors.Conflict(appconst.Usertaken, err)
+		}
+		return 0, err
+	}
+
+	return user.ID, nil
+}
+
+// Retrieve one user
+func (m *PostgresDBRepo) OneUser(id int) (*models.User, error) {
+	return m.oneUser(`id = $1`, id)
+}
+
+// Retrieve the user with a username, ignoring case
+func (m *PostgresDBRepo) UserByUsername(username string) (*models.User, error) {
+	return m.oneUser(`lower(username) = lower($1)`, username)
+}
+
+// Retrieve the users with any of ids in one query, in no particular order.
+// IDs without a user are left out.
+func (m *PostgresDBRepo) UsersByIDs(ids []int) ([]models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + userColumns + `
+        FROM
+            users
+        WHERE
+            id = ANY($1)
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(userFields(&user)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		users = append(users, user)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	return users, nil
+}
+
+// Retrieve a page of users ordered by username
+func (m *PostgresDBRepo) AllUsers(params models.ListParams) (*models.UserPage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + userColumns + `,
+            COUNT(*) OVER() AS total
+        FROM
+            users
+        ORDER BY
+            lower(username), id
+        LIMIT $1 OFFSET $2
+    `
+
+	rows, err := m.DB.QueryContext(ctx, query, params.Limit+1, params.Offset)
+	if err != nil {
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+	defer rows.Close()
+
+	page := &models.UserPage{Users: []models.User{}}
+	for rows.Next() {
+		var user models.User
+		if err := rows.Scan(append(userFields(&user), &page.Total)...); err != nil {
+			log.Println(appconst.Nextrow, err)
+			return nil, translateError(err)
+		}
+		page.Users = append(page.Users, user)
+	}
+	if err := rows.Err(); err != nil {
+		log.Println(appconst.Nextrow, err)
+		return nil, translateError(err)
+	}
+
+	if len(page.Users) > params.Limit {
+		page.Users = page.Users[:params.Limit]
+		page.HasNext = true
+	}
+	page.HasPrev = params.Offset > 0
+
+	return page, nil
+}
+
+// Change the role of a user and return the updated user
+func (m *PostgresDBRepo) SetUserRole(id int, role string) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        UPDATE users
+        SET role = $2
+        WHERE id = $1
+        RETURNING ` + userColumns + `
+    `
+
+	var user models.User
+	err := m.DB.QueryRowContext(ctx, query, id, role).Scan(userFields(&user)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Nouser, err)
+			return nil, apperrors.NotFound(appconst.Nouser, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &user, nil
+}
+
+func (m *PostgresDBRepo) oneUser(condition string, arg interface{}) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	query := `
+        SELECT
+            ` + userColumns + `
+        FROM
+            users
+        WHERE
+            ` + condition + `
+    `
+
+	var user models.User
+	err := m.DB.QueryRowContext(ctx, query, arg).Scan(userFields(&user)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			log.Println(appconst.Nouser, err)
+			return nil, apperrors.NotFound(appconst.Nouser, err)
+		}
+		log.Println(appconst.Queryerror, err)
+		return nil, translateError(err)
+	}
+
+	return &user, nil
+}
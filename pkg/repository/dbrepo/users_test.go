@@ -0,0 +1,149 @@
+package dbrepo
+
+import (
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+var userRowColumns = []string{"id", "username", "email", "role", "password_hash", "created_at", "updated_at"}
+
+func TestCreateUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "User created",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO users \\(username, email, password_hash\\) VALUES \\(\\$1, \\$2, \\$3\\) RETURNING id, role, created_at, updated_at").
+					WithArgs("ada", "ada@example.com", "hash").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "role", "created_at", "updated_at"}).AddRow(4, "author", stamp, stamp))
+			},
+		},
+		{
+			name: "Username taken",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO users").
+					WillReturnError(&pgconn.PgError{Code: pgUniqueViolation})
+			},
+			expectedErr: apperrors.ErrConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			repo := &PostgresDBRepo{DB: db}
+			test.setupMock(mock)
+
+			user := &models.User{Username: "ada", Email: "ada@example.com", PasswordHash: "hash"}
+			id, err := repo.CreateUser(user)
+
+			if test.expectedErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, 4, id)
+				assert.Equal(t, models.RoleAuthor, user.Role)
+				assert.Equal(t, &stamp, user.CreatedAt)
+			} else {
+				assert.ErrorIs(t, err, test.expectedErr)
+				message, _ := apperrors.Message(err)
+				assert.Equal(t, "username or email is already registered", message)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUserByUsername(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, username, COALESCE\\(email, ''\\), role, password_hash, created_at, updated_at FROM users WHERE lower\\(username\\) = lower\\(\\$1\\)").
+		WithArgs("Ada").
+		WillReturnRows(sqlmock.NewRows(userRowColumns).AddRow(4, "ada", "", "editor", "hash", stamp, stamp))
+	mock.ExpectQuery("FROM users WHERE id = \\$1").
+		WithArgs(5).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	user, err := repo.UserByUsername("Ada")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.User{ID: 4, Username: "ada", Role: "editor", PasswordHash: "hash", CreatedAt: &stamp, UpdatedAt: &stamp}, user)
+
+	_, err = repo.OneUser(5)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUsersByIDs(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, username, .* FROM users WHERE id = ANY\\(\\$1\\)").
+		WithArgs("{4,5,9}").
+		WillReturnRows(sqlmock.NewRows(userRowColumns).
+			AddRow(4, "ada", "ada@example.com", "admin", "hash", stamp, stamp).
+			AddRow(5, "grace", "", "author", "hash", stamp, stamp))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	users, err := repo.UsersByIDs([]int{4, 5, 9})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "grace", users[1].Username)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAllUsers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, username, .*, COUNT\\(\\*\\) OVER\\(\\) AS total FROM users ORDER BY lower\\(username\\), id LIMIT \\$1 OFFSET \\$2").
+		WithArgs(2, 0).
+		WillReturnRows(sqlmock.NewRows(append(userRowColumns, "total")).
+			AddRow(4, "ada", "ada@example.com", "admin", "hash", stamp, stamp, 3).
+			AddRow(5, "grace", "", "author", "hash", stamp, stamp, 3))
+
+	repo := &PostgresDBRepo{DB: db}
+
+	page, err := repo.AllUsers(models.ListParams{Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, page.Users, 1)
+	assert.Equal(t, "admin", page.Users[0].Role)
+	assert.Equal(t, 3, page.Total)
+	assert.True(t, page.HasNext)
+	assert.False(t, page.HasPrev)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetUserRole(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE users SET role = \\$2 WHERE id = \\$1 RETURNING id, username").
+		WithArgs(5, "editor").
+		WillReturnRows(sqlmock.NewRows(userRowColumns).AddRow(5, "grace", "", "editor", "hash", stamp, stamp))
+	mock.ExpectQuery("UPDATE users SET role").
+		WithArgs(6, "editor").
+		WillReturnError(sql.ErrNoRows)
+
+	repo := &PostgresDBRepo{DB: db}
+
+	user, err := repo.SetUserRole(5, "editor")
+	assert.NoError(t, err)
+	assert.Equal(t, "editor", user.Role)
+
+	_, err = repo.SetUserRole(6, "editor")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,119 @@
+// Package sitemap writes the sitemaps search engines discover pages with,
+// and the robots.txt that points them there.
+// See https://www.sitemaps.org/protocol.html
+package sitemap
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// MaxURLs is the most URLs a sitemap may list. Larger sites are split into
+// several sitemaps listed by a sitemap index.
+const MaxURLs = 50000
+
+// ContentType is the content type of sitemaps and sitemap indexes
+const ContentType = "application/xml; charset=utf-8"
+
+const (
+	namespace      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	imageNamespace = "http://www.google.com/schemas/sitemap-image/1.1"
+)
+
+// URL is a page in a sitemap. Every URL in it is absolute.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+	// Images are the images on the page
+	Images []string
+}
+
+type urlset struct {
+	XMLName xml.Name  `xml:"urlset"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Image   string    `xml:"xmlns:image,attr"`
+	URLs    []siteURL `xml:"url"`
+}
+
+type siteURL struct {
+	Loc     string  `xml:"loc"`
+	LastMod string  `xml:"lastmod,omitempty"`
+	Images  []image `xml:"image:image"`
+}
+
+type image struct {
+	Loc string `xml:"image:loc"`
+}
+
+// Write writes a sitemap of at most MaxURLs urls, with the image extension
+// for the images on them.
+func Write(urls []URL) ([]byte, error) {
+	set := urlset{XMLNS: namespace, Image: imageNamespace, URLs: []siteURL{}}
+	for _, u := range urls {
+		entry := siteURL{Loc: u.Loc, LastMod: lastMod(u.LastMod)}
+		for _, loc := range u.Images {
+			entry.Images = append(entry.Images, image{Loc: loc})
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+	return marshal(set)
+}
+
+// Sitemap is a sitemap listed in a sitemap index.
+type Sitemap struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name      `xml:"sitemapindex"`
+	XMLNS    string        `xml:"xmlns,attr"`
+	Sitemaps []siteSitemap `xml:"sitemap"`
+}
+
+type siteSitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// WriteIndex writes a sitemap index listing sitemaps.
+func WriteIndex(sitemaps []Sitemap) ([]byte, error) {
+	index := sitemapIndex{XMLNS: namespace}
+	for _, s := range sitemaps {
+		index.Sitemaps = append(index.Sitemaps, siteSitemap{Loc: s.Loc, LastMod: lastMod(s.LastMod)})
+	}
+	return marshal(index)
+}
+
+// Robots returns a robots.txt letting every crawler in, except to the
+// disallowed paths, and pointing them to the sitemap.
+func Robots(disallow []string, sitemapURL string) string {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+	if len(disallow) == 0 {
+		b.WriteString("Disallow:\n")
+	}
+	for _, path := range disallow {
+		b.WriteString("Disallow: " + path + "\n")
+	}
+	if sitemapURL != "" {
+		b.WriteString("\nSitemap: " + sitemapURL + "\n")
+	}
+	return b.String()
+}
+
+func lastMod(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func marshal(v interface{}) ([]byte, error) {
+	out, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
@@ -0,0 +1,52 @@
+package sitemap
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+	out, err := Write([]URL{
+		{Loc: "https://example.com/articles/by-slug/fish-chips", LastMod: modified, Images: []string{"https://example.com/media/3/variants/1600"}},
+		{Loc: "https://example.com/articles/by-slug/a&b"},
+	})
+	assert.NoError(t, err)
+	body := string(out)
+
+	assert.Contains(t, body, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">`)
+	assert.Contains(t, body, "<loc>https://example.com/articles/by-slug/fish-chips</loc>")
+	assert.Contains(t, body, "<lastmod>2024-03-01T09:30:00Z</lastmod>")
+	assert.Contains(t, body, "<image:image>\n      <image:loc>https://example.com/media/3/variants/1600</image:loc>\n    </image:image>")
+	assert.Contains(t, body, "<loc>https://example.com/articles/by-slug/a&amp;b</loc>")
+	assert.NoError(t, xml.Unmarshal(out, new(interface{})))
+
+	// An empty sitemap is still a valid one
+	out, err = Write(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"></urlset>`)
+}
+
+func TestWriteIndex(t *testing.T) {
+	out, err := WriteIndex([]Sitemap{
+		{Loc: "https://example.com/sitemaps/1.xml", LastMod: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/sitemaps/2.xml"},
+	})
+	assert.NoError(t, err)
+	body := string(out)
+
+	assert.Contains(t, body, `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, body, "<sitemap>\n    <loc>https://example.com/sitemaps/1.xml</loc>\n    <lastmod>2024-03-01T00:00:00Z</lastmod>\n  </sitemap>")
+	assert.Contains(t, body, "<sitemap>\n    <loc>https://example.com/sitemaps/2.xml</loc>\n  </sitemap>")
+}
+
+func TestRobots(t *testing.T) {
+	assert.Equal(t, "User-agent: *\nDisallow: /auth/\nDisallow: /users\n\nSitemap: https://example.com/sitemap.xml\n",
+		Robots([]string{"/auth/", "/users"}, "https://example.com/sitemap.xml"))
+
+	// Nothing disallowed lets crawlers everywhere
+	assert.Equal(t, "User-agent: *\nDisallow:\n", Robots(nil, ""))
+}
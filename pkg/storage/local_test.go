@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(filepath.Join(t.TempDir(), "media"))
+	assert.NoError(t, err)
+
+	testStore(t, store)
+}
+
+func TestLocalStoreShortPut(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	assert.NoError(t, err)
+
+	// A blob shorter than announced is not kept, nor is its temporary file
+	err = store.Put(context.Background(), "ab/abcdef", bytes.NewReader([]byte("abc")), 10, "text/plain")
+	assert.Error(t, err)
+	entries, err := os.ReadDir(filepath.Join(root, "ab"))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
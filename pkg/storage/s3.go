@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Payload hashes of signed requests. Uploads are streamed, so their bodies
+// are not part of the signature.
+const (
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+)
+
+// S3Config says where an S3Store keeps its blobs.
+type S3Config struct {
+	// Endpoint is the URL of the service, e.g. https://s3.eu-west-1.amazonaws.com
+	// or http://minio:9000
+	Endpoint string
+	// Region the requests are signed for, us-east-1 when empty
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store keeps blobs in a bucket of Amazon S3 or a service compatible with
+// it, like MinIO. Objects are addressed path style, as
+// <endpoint>/<bucket>/<key>, and requests are signed with AWS Signature
+// Version 4.
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Store returns a store keeping blobs in the bucket of config.
+func NewS3Store(config S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("s3 endpoint: %w", err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3 endpoint %q: must be an http or https URL", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, errors.New("s3 bucket must be set")
+	}
+	region := config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	// Blobs are streamed for as long as a download takes, so only waiting
+	// for the service to answer is bounded
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = 30 * time.Second
+
+	return &S3Store{
+		endpoint:  endpoint,
+		region:    region,
+		bucket:    config.Bucket,
+		accessKey: config.AccessKey,
+		secretKey: config.SecretKey,
+		client:    &http.Client{Transport: transport},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	if size == 0 {
+		r = http.NoBody
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, r, size, header)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp, key)
+	}
+	return nil
+}
+
+// Open asks for the size of the object and leaves reading it to the
+// returned object, which gets the bytes from where it was seeked to.
+func (s *S3Store) Open(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	resp, err := s.do(ctx, http.MethodHead, key, nil, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp, key)
+	}
+	return &s3Object{ctx: ctx, store: s, key: key, size: resp.ContentLength}, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return responseError(resp, key)
+	}
+	return nil
+}
+
+// do sends a signed request for the object under key.
+func (s *S3Store) do(ctx context.Context, method, key string, body io.Reader, size int64, header http.Header) (*http.Response, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = uriEncode(u.Path, false)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := emptyPayloadHash
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = unsignedPayload
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	s.sign(req, payloadHash, s.now())
+
+	return s.client.Do(req)
+}
+
+// sign adds the headers of AWS Signature Version 4 to req, signing the host
+// and every header already set.
+func (s *S3Store) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.Host}
+	if req.Host == "" {
+		headers["host"] = req.URL.Host
+	}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uriEncode(req.URL.Path, false),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), day)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(query url.Values) string {
+	params := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// uriEncode percent-encodes every byte of s but the unreserved characters,
+// and slashes unless encodeSlash is set, the way AWS signatures expect.
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hexSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func responseError(resp *http.Response, key string) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return notExist(key)
+	}
+	return fmt.Errorf("s3 %s %s: %s", resp.Request.Method, key, resp.Status)
+}
+
+// s3Object reads an object from its offset on. The first read after a seek
+// asks for the bytes from the new offset to the end.
+type s3Object struct {
+	ctx    context.Context
+	store  *S3Store
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func (o *s3Object) Read(p []byte) (int, error) {
+	if o.offset >= o.size {
+		return 0, io.EOF
+	}
+	if o.body == nil {
+		header := http.Header{"Range": {fmt.Sprintf("bytes=%d-", o.offset)}}
+		resp, err := o.store.do(o.ctx, http.MethodGet, o.key, nil, 0, header)
+		if err != nil {
+			return 0, err
+		}
+		if resp.StatusCode != http.StatusPartialContent && !(resp.StatusCode == http.StatusOK && o.offset == 0) {
+			resp.Body.Close()
+			return 0, responseError(resp, o.key)
+		}
+		o.body = resp.Body
+	}
+
+	n, err := o.body.Read(p)
+	o.offset += int64(n)
+	if err == io.EOF && o.offset < o.size {
+		err = io.ErrUnexpectedEOF
+	}
+	return n, err
+}
+
+func (o *s3Object) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += o.offset
+	case io.SeekEnd:
+		offset += o.size
+	}
+	if offset < 0 {
+		return 0, errors.New("s3: seek before the start of the object")
+	}
+	if offset != o.offset {
+		o.Close()
+		o.offset = offset
+	}
+	return offset, nil
+}
+
+func (o *s3Object) Close() error {
+	if o.body == nil {
+		return nil
+	}
+	err := o.body.Close()
+	o.body = nil
+	return err
+}
@@ -0,0 +1,45 @@
+// Package storage keeps the bytes of uploaded media. Media metadata lives in
+// the database; the blobs live in a BlobStore, either a directory on the
+// local disk or a bucket of an S3 compatible service.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// BlobStore stores blobs under keys. Keys are slash separated paths like
+// "ab/abcdef". A missing blob is reported with an error matching
+// fs.ErrNotExist.
+type BlobStore interface {
+	// Put stores the size bytes read from r under key, replacing any blob
+	// already there.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Open returns the blob under key. It can seek, so ranges of a blob can
+	// be served without reading all of it.
+	Open(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete removes the blob under key. Deleting a missing blob is not an
+	// error.
+	Delete(ctx context.Context, key string) error
+}
+
+var errInvalidKey = errors.New("invalid blob key")
+
+// checkKey rejects keys that are not clean relative paths, so a key can
+// never point outside of a store.
+func checkKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return fmt.Errorf("%w: %q", errInvalidKey, key)
+	}
+	return nil
+}
+
+// notExist marks err as the error of a missing blob.
+func notExist(key string) error {
+	return fmt.Errorf("blob %s: %w", key, fs.ErrNotExist)
+}
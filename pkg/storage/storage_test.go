@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testStore puts, reads ranges of and deletes blobs in store, the way the
+// media service and handlers use it.
+func testStore(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+	data := []byte("0123456789abcdefghij")
+
+	assert.NoError(t, store.Put(ctx, "ab/abcdef", bytes.NewReader(data), int64(len(data)), "text/plain"))
+
+	blob, err := store.Open(ctx, "ab/abcdef")
+	assert.NoError(t, err)
+	size, err := blob.Seek(0, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+
+	_, err = blob.Seek(10, io.SeekStart)
+	assert.NoError(t, err)
+	part := make([]byte, 5)
+	_, err = io.ReadFull(blob, part)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcde", string(part))
+
+	_, err = blob.Seek(0, io.SeekStart)
+	assert.NoError(t, err)
+	all, err := io.ReadAll(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, data, all)
+	assert.NoError(t, blob.Close())
+
+	// Putting again replaces the blob
+	assert.NoError(t, store.Put(ctx, "ab/abcdef", bytes.NewReader([]byte("new")), 3, "text/plain"))
+	blob, err = store.Open(ctx, "ab/abcdef")
+	assert.NoError(t, err)
+	all, err = io.ReadAll(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(all))
+	blob.Close()
+
+	assert.NoError(t, store.Delete(ctx, "ab/abcdef"))
+	assert.NoError(t, store.Delete(ctx, "ab/abcdef"))
+	_, err = store.Open(ctx, "ab/abcdef")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	_, err = store.Open(ctx, "../outside")
+	assert.ErrorIs(t, err, errInvalidKey)
+}
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"abc", "ab/abcdef", "sha256/ab/abcdef.png"} {
+		assert.NoError(t, checkKey(key), key)
+	}
+	for _, key := range []string{"", "/abs", "..", "../up", "ab/../../up", "ab//cd", "ab/./cd", "ab/"} {
+		assert.ErrorIs(t, checkKey(key), errInvalidKey, key)
+	}
+}
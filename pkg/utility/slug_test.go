@@ -0,0 +1,42 @@
+package utility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	testCases := map[string]string{
+		"Go":                   "go",
+		"Web Development":      "web-development",
+		"  C++ & Rust!  ":      "c-rust",
+		"HTTP/2":               "http-2",
+		"already-a-slug":       "already-a-slug",
+		"Crème brûlée":         "crème-brûlée",
+		"---":                  "",
+		"Machine   Learning--": "machine-learning",
+	}
+
+	for name, slug := range testCases {
+		assert.Equal(t, slug, Slugify(name), name)
+	}
+}
+
+func TestTransliterate(t *testing.T) {
+	testCases := map[string]string{
+		"Crème brûlée":    "Creme brulee",
+		"Straße":          "Strasse",
+		"Œuvre":           "Oeuvre",
+		"Łódź":            "Lodz",
+		"Ελληνικά":        "Ellinika",
+		"Привет, мир":     "Privet, mir",
+		"Объявление":      "Obyavlenie",
+		"日本語 and ASCII":   "日本語 and ASCII",
+		"Smørrebrød 2024": "Smorrebrod 2024",
+	}
+
+	for s, spelled := range testCases {
+		assert.Equal(t, spelled, Transliterate(s), s)
+	}
+}
@@ -69,3 +69,43 @@ func ErrorJSON(w http.ResponseWriter, err error, status ...int) error {
 
 	return WriteJSON(w, statusCode, payload)
 }
+
+// MergePatch applies an RFC 7396 JSON merge patch to the original document
+// and returns the patched document.
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := json.Unmarshal(original, &target); err != nil {
+		return nil, err
+	}
+
+	var changes interface{}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeValue(target, changes))
+}
+
+// mergeValue merges patch into target following the RFC 7396 rules: objects are
+// merged recursively, null removes a member and any other value replaces it.
+func mergeValue(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+
+	return targetObject
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // Test case using the table driven test
@@ -107,3 +109,54 @@ func TestWriteJSONWithHeaders(t *testing.T) {
 }
 
 // Negative test cases
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		expected string
+	}{
+		{
+			name:     "Replace member",
+			original: `{"title":"Old","author":"John"}`,
+			patch:    `{"title":"New"}`,
+			expected: `{"title":"New","author":"John"}`,
+		},
+		{
+			name:     "Null removes member",
+			original: `{"title":"Old","author":"John"}`,
+			patch:    `{"author":null}`,
+			expected: `{"title":"Old"}`,
+		},
+		{
+			name:     "Nested objects are merged",
+			original: `{"meta":{"a":1,"b":2}}`,
+			patch:    `{"meta":{"b":null,"c":3}}`,
+			expected: `{"meta":{"a":1,"c":3}}`,
+		},
+		{
+			name:     "Non-object patch replaces document",
+			original: `{"title":"Old"}`,
+			patch:    `["a"]`,
+			expected: `["a"]`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			patched, err := MergePatch([]byte(test.original), []byte(test.patch))
+			if err != nil {
+				t.Fatalf("MergePatch returned an error: %v", err)
+			}
+			assert.JSONEq(t, test.expected, string(patched))
+		})
+	}
+}
+
+func TestMergePatchInvalidJSON(t *testing.T) {
+	_, err := MergePatch([]byte(`{}`), []byte(`{invalid`))
+	if err == nil {
+		t.Fatal("MergePatch did not return an expected error")
+	}
+}
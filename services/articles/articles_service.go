@@ -1,18 +1,49 @@
 package services
 
 import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/markdown"
 	"backend/pkg/models"
+	"backend/pkg/policy"
 	"backend/pkg/repository/dbrepo"
+	"backend/pkg/utility"
+	"backend/services/taxonomy"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
 )
 
 type ArticleServices interface {
-	GetAllArticles() ([]models.Article, error)
-	GetArticleByID(id int) (*models.Article, error)
-	CreateArticle(article *models.Article) (int, error)
+	GetAllArticles(params models.ListParams) (*models.ArticlePage, error)
+	GetArticleByID(id int, viewer models.Principal) (*models.Article, error)
+	GetArticleBySlug(slug string, viewer models.Principal) (*models.Article, error)
+	CreateArticle(article *models.Article, actor models.Principal) (int, error)
+	UpdateArticle(id int, article *models.Article, actor models.Principal) (*models.Article, error)
+	PatchArticle(id int, patch []byte, actor models.Principal) (*models.Article, error)
+	DeleteArticle(id int, actor models.Principal) error
+	SearchArticles(query string, params models.ListParams) (*models.SearchPage, error)
+	GetFeed(author, tag string) (*models.Feed, error)
+	GetSitemapPages() ([]models.SitemapPage, error)
+	GetSitemap(page int) ([]models.SitemapEntry, error)
+	SubmitArticle(id int, actor models.Principal) (*models.Article, error)
+	PublishArticle(id int, actor models.Principal) (*models.Article, error)
+	UnpublishArticle(id int, actor models.Principal) (*models.Article, error)
+	ArchiveArticle(id int, actor models.Principal) (*models.Article, error)
+	GetScheduledArticles(params models.ListParams) (*models.ArticlePage, error)
+	PublishDueArticles() ([]models.Article, error)
+	GetRevisions(id int, viewer models.Principal, params models.ListParams) (*models.RevisionPage, error)
+	GetRevision(id, revision int, viewer models.Principal) (*models.Revision, error)
+	DiffRevisions(id, from, to int, viewer models.Principal) (*models.RevisionDiff, error)
+	RestoreRevision(id, revision int, actor models.Principal) (*models.Article, error)
+	WatchArticles() (<-chan models.ArticleEvent, func())
 }
 
 type ArticleService struct {
-	repo dbrepo.DatabaseRepo
+	repo   dbrepo.DatabaseRepo
+	events events
 }
 
 func NewArticleService(repo dbrepo.DatabaseRepo) *ArticleService {
@@ -21,15 +52,295 @@ func NewArticleService(repo dbrepo.DatabaseRepo) *ArticleService {
 	}
 }
 
-func (s *ArticleService) GetAllArticles() ([]models.Article, error) {
-	// Call the GetAllArticles method from the repository
-	return s.repo.AllArticles()
+func (s *ArticleService) GetAllArticles(params models.ListParams) (*models.ArticlePage, error) {
+	// Apply the default and maximum page size before reading the page
+	params.Normalize()
+	params.AllStatuses = policy.Can(params.Viewer, policy.ViewAllArticles, nil)
+	return s.repo.AllArticles(params)
 }
 
-func (s *ArticleService) GetArticleByID(id int) (*models.Article, error) {
-	return s.repo.OneArticle(id)
+// GetArticleByID returns an article if viewer may see it: published
+// articles are public, unpublished ones only visible to their author and
+// editors. Hidden articles are reported as not found so their existence
+// does not leak.
+func (s *ArticleService) GetArticleByID(id int, viewer models.Principal) (*models.Article, error) {
+	article, err := s.repo.OneArticle(id)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Can(viewer, policy.ViewArticle, article) {
+		return nil, apperrors.NotFound(appconst.NoArticleforid, nil)
+	}
+	return article, nil
+}
+
+// GetArticleBySlug returns the article with a current or old slug if viewer
+// may see it, hiding the rest as GetArticleByID does. The returned article
+// carries its current slug.
+func (s *ArticleService) GetArticleBySlug(slug string, viewer models.Principal) (*models.Article, error) {
+	article, err := s.repo.ArticleBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Can(viewer, policy.ViewArticle, article) {
+		return nil, apperrors.NotFound(appconst.NoArticleforslug, nil)
+	}
+	return article, nil
 }
 
-func (s *ArticleService) CreateArticle(article *models.Article) (int, error) {
-	return s.repo.CreateArticle(article)
+// CreateArticle saves a new draft written by actor. Only actors who may
+// publish may schedule it, for a time in the future.
+func (s *ArticleService) CreateArticle(article *models.Article, actor models.Principal) (int, error) {
+	if err := policy.Authorize(actor, policy.CreateArticle, nil); err != nil {
+		return 0, err
+	}
+	if err := checkSchedule(nil, article, actor); err != nil {
+		return 0, err
+	}
+	article.AuthorID = actor.UserID
+	if err := s.setAuthor(article); err != nil {
+		return 0, err
+	}
+	if err := cleanTaxonomy(article); err != nil {
+		return 0, err
+	}
+	if err := s.checkCover(article); err != nil {
+		return 0, err
+	}
+	if err := renderContent(article); err != nil {
+		return 0, err
+	}
+	article.Slug = articleSlug(article.Title)
+	article.CreatedBy = actor.Username
+	id, err := s.repo.CreateArticle(article)
+	if err != nil {
+		return 0, err
+	}
+	s.events.publish(models.EventCreated, *article)
+	return id, nil
+}
+
+// UpdateArticle replaces every field of the article with the given id. The
+// article keeps its author unless article.AuthorID names another one.
+func (s *ArticleService) UpdateArticle(id int, article *models.Article, actor models.Principal) (*models.Article, error) {
+	current, err := s.authorizedArticle(id, policy.EditArticle, actor)
+	if err != nil {
+		return nil, err
+	}
+	return s.replace(current, article, actor)
+}
+
+// replace saves article in place of current on behalf of actor. Only
+// actors the policy allows to may hand the article to another author or
+// schedule it.
+func (s *ArticleService) replace(current, article *models.Article, actor models.Principal) (*models.Article, error) {
+	if article.AuthorID == 0 {
+		article.AuthorID = current.AuthorID
+	}
+	if article.AuthorID != current.AuthorID {
+		if err := policy.Authorize(actor, policy.AssignAuthor, current); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkSchedule(current, article, actor); err != nil {
+		return nil, err
+	}
+	if err := s.setAuthor(article); err != nil {
+		return nil, err
+	}
+	if err := cleanTaxonomy(article); err != nil {
+		return nil, err
+	}
+	if err := s.checkCover(article); err != nil {
+		return nil, err
+	}
+	if err := renderContent(article); err != nil {
+		return nil, err
+	}
+	// The slug only follows a changed title, so links to the article stay
+	// put; the repository keeps the old slug to redirect from
+	article.Slug = current.Slug
+	if article.Title != current.Title {
+		article.Slug = articleSlug(article.Title)
+	}
+	article.ID = current.ID
+	article.UpdatedBy = actor.Username
+	if err := s.repo.UpdateArticle(article); err != nil {
+		return nil, err
+	}
+	s.events.publish(models.EventUpdated, *article)
+	return article, nil
+}
+
+// authorizedArticle loads the article with the given id for actor to take
+// action on. Anonymous actors are turned away before the article is read,
+// and articles actor may not see are reported as not found rather than
+// forbidden, as GetArticleByID does.
+func (s *ArticleService) authorizedArticle(id int, action policy.Action, actor models.Principal) (*models.Article, error) {
+	if actor.Anonymous() {
+		return nil, apperrors.Unauthorized(appconst.Unauthenticated, nil)
+	}
+	article, err := s.repo.OneArticle(id)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.Can(actor, policy.ViewArticle, article) {
+		return nil, apperrors.NotFound(appconst.NoArticleforid, nil)
+	}
+	if err := policy.Authorize(actor, action, article); err != nil {
+		return nil, err
+	}
+	return article, nil
+}
+
+// checkSchedule makes sure only actors who may publish set when article
+// goes live, since the scheduler publishes it then without asking anyone,
+// and that it goes live in the future. current is the article as it is
+// saved, nil for a new one; keeping or clearing its schedule needs no
+// permission.
+func checkSchedule(current, article *models.Article, actor models.Principal) error {
+	if article.PublishAt == nil {
+		return nil
+	}
+	if current != nil && current.PublishAt != nil && current.PublishAt.Equal(*article.PublishAt) {
+		return nil
+	}
+	target := current
+	if target == nil {
+		target = article
+	}
+	if err := policy.Authorize(actor, policy.PublishArticle, target); err != nil {
+		return err
+	}
+	if !article.PublishAt.After(time.Now()) {
+		return apperrors.Validation(appconst.Publishinpast, nil)
+	}
+	return nil
+}
+
+// cleanTaxonomy cleans the tag names and category slugs of article before
+// they are saved with it.
+func cleanTaxonomy(article *models.Article) error {
+	tags, err := taxonomy.CleanTags(article.Tags)
+	if err != nil {
+		return err
+	}
+	article.Tags = tags
+	article.Categories = taxonomy.CleanCategories(article.Categories)
+	return nil
+}
+
+// checkCover makes sure the cover of article, if it has one, is an uploaded
+// image.
+func (s *ArticleService) checkCover(article *models.Article) error {
+	if article.CoverID == nil {
+		return nil
+	}
+	media, err := s.repo.OneMedia(*article.CoverID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return apperrors.Validation(appconst.Nocover, nil)
+	}
+	if err != nil {
+		return err
+	}
+	if !models.IsImage(media.ContentType) {
+		return apperrors.Validation(appconst.Nocover, nil)
+	}
+	return nil
+}
+
+// renderContent keeps the HTML of the Markdown content of article with it,
+// so reads do not render it again.
+func renderContent(article *models.Article) error {
+	html, err := markdown.Render(article.Content)
+	if err != nil {
+		return err
+	}
+	article.ContentHTML = html
+	return nil
+}
+
+// articleSlug derives the slug of an article from its title, spelling
+// letters out in ASCII where it can. The repository makes it unique.
+func articleSlug(title string) string {
+	slug := []rune(utility.Slugify(utility.Transliterate(title)))
+	if len(slug) > models.MaxSlugLength {
+		slug = slug[:models.MaxSlugLength]
+	}
+	if s := strings.Trim(string(slug), "-"); s != "" {
+		return s
+	}
+	return "article"
+}
+
+// setAuthor looks up the user in article.AuthorID and makes their username
+// the author of the article, so the author name a client sends is never
+// trusted.
+func (s *ArticleService) setAuthor(article *models.Article) error {
+	if article.AuthorID <= 0 {
+		return apperrors.Validation(appconst.Authorrequired, nil)
+	}
+
+	user, err := s.repo.OneUser(article.AuthorID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return apperrors.Validation(appconst.Authorrequired, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	article.Author = user.Username
+	return nil
+}
+
+// PatchArticle applies a JSON merge patch to the stored article and saves the result.
+func (s *ArticleService) PatchArticle(id int, patch []byte, actor models.Principal) (*models.Article, error) {
+	current, err := s.authorizedArticle(id, policy.EditArticle, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := utility.MergePatch(original, patch)
+	if err != nil {
+		return nil, apperrors.Validation(appconst.Patchparsing, err)
+	}
+
+	var article models.Article
+	dec := json.NewDecoder(bytes.NewReader(patched))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&article); err != nil {
+		return nil, apperrors.Validation(appconst.Patchparsing, err)
+	}
+
+	return s.replace(current, &article, actor)
+}
+
+// DeleteArticle removes an article on behalf of actor.
+func (s *ArticleService) DeleteArticle(id int, actor models.Principal) error {
+	article, err := s.authorizedArticle(id, policy.DeleteArticle, actor)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteArticle(id); err != nil {
+		return err
+	}
+	s.events.publish(models.EventDeleted, *article)
+	return nil
+}
+
+// SearchArticles returns the articles matching a full-text query, best match first.
+func (s *ArticleService) SearchArticles(query string, params models.ListParams) (*models.SearchPage, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, apperrors.Validation(appconst.Emptysearch, nil)
+	}
+
+	params.Normalize()
+	params.AllStatuses = policy.Can(params.Viewer, policy.ViewAllArticles, nil)
+	return s.repo.SearchArticles(query, params)
 }
@@ -1,11 +1,16 @@
 package services
 
 import (
+	"database/sql"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"backend/mocks" // Import the generated mock package
 	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
 	"backend/pkg/models"
 
 	"github.com/golang/mock/gomock"
@@ -20,27 +25,44 @@ func TestArticleService_GetAllArticles(t *testing.T) {
 
 	// Create a new instance of the ArticleService with the mock repo
 	service := NewArticleService(mockDB)
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil).AnyTimes()
 
 	// Define the test cases
 	testCases := []struct {
-		description  string
-		expectedData []models.Article
-		expectedErr  error
-		mockFunc     func() ([]models.Article, error)
+		description    string
+		params         models.ListParams
+		expectedParams models.ListParams
+		expectedData   *models.ArticlePage
+		expectedErr    error
+		mockFunc       func(params models.ListParams) (*models.ArticlePage, error)
 	}{
 		{
-			description:  "Successful case",
-			expectedData: []models.Article{{ID: 1, Title: "Article 1", Content: "Content 1"}, {ID: 2, Title: "Article 2", Content: "Content 2"}},
-			expectedErr:  nil,
-			mockFunc: func() ([]models.Article, error) {
-				return []models.Article{{ID: 1, Title: "Article 1", Content: "Content 1"}, {ID: 2, Title: "Article 2", Content: "Content 2"}}, nil
+			description:    "Successful case",
+			params:         models.ListParams{Limit: 2},
+			expectedParams: models.ListParams{Limit: 2, Sort: models.Sort{Field: models.SortTitle}},
+			expectedData:   &models.ArticlePage{Articles: []models.Article{{ID: 1, Title: "Article 1", Content: "Content 1"}, {ID: 2, Title: "Article 2", Content: "Content 2"}}, PageInfo: models.PageInfo{Total: 2}},
+			expectedErr:    nil,
+			mockFunc: func(params models.ListParams) (*models.ArticlePage, error) {
+				return &models.ArticlePage{Articles: []models.Article{{ID: 1, Title: "Article 1", Content: "Content 1"}, {ID: 2, Title: "Article 2", Content: "Content 2"}}, PageInfo: models.PageInfo{Total: 2}}, nil
 			},
 		},
 		{
-			description:  "Negative test case",
-			expectedData: nil,
-			expectedErr:  errors.New(appconst.Noarticlefound),
-			mockFunc: func() ([]models.Article, error) {
+			description:    "Page size defaults and is capped",
+			params:         models.ListParams{Limit: 1000, Offset: -5},
+			expectedParams: models.ListParams{Limit: models.MaxPageSize, Sort: models.Sort{Field: models.SortTitle}},
+			expectedData:   &models.ArticlePage{Articles: []models.Article{}},
+			expectedErr:    nil,
+			mockFunc: func(params models.ListParams) (*models.ArticlePage, error) {
+				return &models.ArticlePage{Articles: []models.Article{}}, nil
+			},
+		},
+		{
+			description:    "Negative test case",
+			params:         models.ListParams{},
+			expectedParams: models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}},
+			expectedData:   nil,
+			expectedErr:    errors.New(appconst.Noarticlefound),
+			mockFunc: func(params models.ListParams) (*models.ArticlePage, error) {
 				return nil, errors.New(appconst.Noarticlefound)
 			},
 		},
@@ -49,10 +71,10 @@ func TestArticleService_GetAllArticles(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.description, func(t *testing.T) {
 			// Set up expectations for the mock repo
-			mockDB.EXPECT().AllArticles().DoAndReturn(testCase.mockFunc)
+			mockDB.EXPECT().AllArticles(testCase.expectedParams).DoAndReturn(testCase.mockFunc)
 
 			// Call the GetAllArticles method
-			articles, err := service.GetAllArticles()
+			articles, err := service.GetAllArticles(testCase.params)
 
 			// Check the result
 			assert.Equal(t, testCase.expectedErr, err)
@@ -69,6 +91,7 @@ func TestArticleService_CreateArticle(t *testing.T) {
 
 	// Create a new instance of the ArticleService with the mock repo
 	service := NewArticleService(mockDB)
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil).AnyTimes()
 
 	// Define the test cases
 	testCases := []struct {
@@ -80,16 +103,57 @@ func TestArticleService_CreateArticle(t *testing.T) {
 	}{
 		{
 			description:       "Successful creation",
-			articleToCreate:   &models.Article{Title: "New Article", Content: "New Content"},
+			articleToCreate:   &models.Article{Title: "New Article", Content: "New Content", Author: "Mallory", AuthorID: 9, CreatedBy: "Someone else"},
 			expectedArticleID: 1,
 			expectedErr:       nil,
 			mockFunc: func(article *models.Article) (int, error) {
+				// The client cannot choose the author or who is recorded as
+				// the creator: both are the signed in user
+				if article.AuthorID != 7 || article.Author != "Author" || article.CreatedBy != "Author" {
+					return 0, errors.New("author not set from the user")
+				}
 				return 1, nil
 			},
 		},
+		{
+			description:       "Tags and categories are cleaned",
+			articleToCreate:   &models.Article{Title: "New Article", Content: "New Content", Tags: []string{" Go ", "go", "web  apps"}, Categories: []string{"news", " news"}},
+			expectedArticleID: 2,
+			expectedErr:       nil,
+			mockFunc: func(article *models.Article) (int, error) {
+				if !reflect.DeepEqual(article.Tags, []string{"Go", "web apps"}) || !reflect.DeepEqual(article.Categories, []string{"news"}) {
+					return 0, errors.New("tags or categories not cleaned")
+				}
+				return 2, nil
+			},
+		},
+		{
+			description:       "Slug is made from the title",
+			articleToCreate:   &models.Article{Title: "Crème Brûlée, Step by Step!", Slug: "chosen-by-client"},
+			expectedArticleID: 3,
+			expectedErr:       nil,
+			mockFunc: func(article *models.Article) (int, error) {
+				if article.Slug != "creme-brulee-step-by-step" {
+					return 0, errors.New("slug not made from the title")
+				}
+				return 3, nil
+			},
+		},
+		{
+			description:       "Content is rendered to HTML",
+			articleToCreate:   &models.Article{Title: "Rendered", Content: "# Intro\n\n<script>alert(1)</script>\n\n**Hi**", ContentHTML: "<script>"},
+			expectedArticleID: 4,
+			expectedErr:       nil,
+			mockFunc: func(article *models.Article) (int, error) {
+				if !strings.Contains(article.ContentHTML, `<h1 id="intro">`) || !strings.Contains(article.ContentHTML, "<strong>Hi</strong>") || strings.Contains(article.ContentHTML, "<script>") {
+					return 0, errors.New("content not rendered and sanitized")
+				}
+				return 4, nil
+			},
+		},
 		{
 			description:       "Negative test case",
-			articleToCreate:   &models.Article{Title: "New Article", Content: "New Content"},
+			articleToCreate:   &models.Article{Title: "New Article", Content: "New Content", AuthorID: 7},
 			expectedArticleID: 0,
 			expectedErr:       errors.New(appconst.Articlenotcreated),
 			mockFunc: func(article *models.Article) (int, error) {
@@ -104,7 +168,7 @@ func TestArticleService_CreateArticle(t *testing.T) {
 			mockDB.EXPECT().CreateArticle(testCase.articleToCreate).DoAndReturn(testCase.mockFunc)
 
 			// Call the CreateArticle method
-			createdArticleID, err := service.CreateArticle(testCase.articleToCreate)
+			createdArticleID, err := service.CreateArticle(testCase.articleToCreate, models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor})
 
 			// Check the result
 			assert.Equal(t, testCase.expectedErr, err)
@@ -113,6 +177,76 @@ func TestArticleService_CreateArticle(t *testing.T) {
 	}
 }
 
+func TestArticleService_CreateArticle_UnknownAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	// Without a signed in user nothing is looked up
+	_, err := service.CreateArticle(&models.Article{Title: "New Article", Author: "Author", AuthorID: 7}, models.Principal{})
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+
+	// A token of a user that was removed since
+	mockDB.EXPECT().OneUser(9).Return(nil, apperrors.NotFound(appconst.Nouser, sql.ErrNoRows))
+
+	_, err = service.CreateArticle(&models.Article{Title: "New Article"}, models.Principal{UserID: 9, Username: "Gone", Role: models.RoleAuthor})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	assert.NotErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestArticleService_CreateArticle_Scheduled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+	tomorrow := time.Now().Add(24 * time.Hour)
+
+	// Authors cannot have their drafts published without an editor
+	_, err := service.CreateArticle(&models.Article{Title: "Soon", PublishAt: &tomorrow}, models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor})
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+
+	// Nor can editors schedule articles for a time that has passed, which
+	// would publish them backdated
+	editor := models.Principal{UserID: 8, Username: "Editor", Role: models.RoleEditor}
+	yesterday := time.Now().Add(-24 * time.Hour)
+	_, err = service.CreateArticle(&models.Article{Title: "Soon", PublishAt: &yesterday}, editor)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	mockDB.EXPECT().OneUser(8).Return(&models.User{ID: 8, Username: "Editor"}, nil)
+	mockDB.EXPECT().CreateArticle(gomock.Any()).Return(1, nil)
+
+	id, err := service.CreateArticle(&models.Article{Title: "Soon", PublishAt: &tomorrow}, editor)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestArticleService_CreateArticle_Cover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+	author := models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor}
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil).AnyTimes()
+
+	image, pdf, missing := 3, 4, 5
+	mockDB.EXPECT().OneMedia(image).Return(&models.Media{ID: image, ContentType: "image/webp"}, nil)
+	mockDB.EXPECT().OneMedia(pdf).Return(&models.Media{ID: pdf, ContentType: "application/pdf"}, nil)
+	mockDB.EXPECT().OneMedia(missing).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows))
+	mockDB.EXPECT().CreateArticle(gomock.Any()).Return(1, nil)
+
+	id, err := service.CreateArticle(&models.Article{Title: "Covered", CoverID: &image}, author)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+
+	// Only images can be covers
+	_, err = service.CreateArticle(&models.Article{Title: "Covered", CoverID: &pdf}, author)
+	assert.Equal(t, apperrors.Validation(appconst.Nocover, nil), err)
+
+	_, err = service.CreateArticle(&models.Article{Title: "Covered", CoverID: &missing}, author)
+	assert.Equal(t, apperrors.Validation(appconst.Nocover, nil), err)
+}
+
 func TestArticleService_GetArticleByID(t *testing.T) {
 	// Create a new instance of the mock controller
 	ctrl := gomock.NewController(t)
@@ -121,11 +255,13 @@ func TestArticleService_GetArticleByID(t *testing.T) {
 
 	// Create a new instance of the ArticleService with the mock repo
 	service := NewArticleService(mockDB)
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil).AnyTimes()
 
 	// Define the test cases
 	testCases := []struct {
 		description     string
 		articleID       int
+		viewer          models.Principal
 		expectedArticle *models.Article
 		expectedErr     error
 		mockFunc        func(id int) (*models.Article, error)
@@ -133,10 +269,49 @@ func TestArticleService_GetArticleByID(t *testing.T) {
 		{
 			description:     "Successful case",
 			articleID:       1,
-			expectedArticle: &models.Article{ID: 1, Title: "Article 1", Content: "Content 1"},
+			expectedArticle: &models.Article{ID: 1, Title: "Article 1", Content: "Content 1", Status: models.StatusPublished},
 			expectedErr:     nil,
 			mockFunc: func(id int) (*models.Article, error) {
-				return &models.Article{ID: 1, Title: "Article 1", Content: "Content 1"}, nil
+				return &models.Article{ID: 1, Title: "Article 1", Content: "Content 1", Status: models.StatusPublished}, nil
+			},
+		},
+		{
+			description:     "Draft is hidden from anonymous viewers",
+			articleID:       4,
+			expectedArticle: nil,
+			expectedErr:     apperrors.NotFound(appconst.NoArticleforid, nil),
+			mockFunc: func(id int) (*models.Article, error) {
+				return &models.Article{ID: 4, Author: "Ada", Status: models.StatusDraft}, nil
+			},
+		},
+		{
+			description:     "Draft is hidden from other authors",
+			articleID:       4,
+			viewer:          models.Principal{UserID: 6, Username: "Grace", Role: models.RoleAuthor},
+			expectedArticle: nil,
+			expectedErr:     apperrors.NotFound(appconst.NoArticleforid, nil),
+			mockFunc: func(id int) (*models.Article, error) {
+				return &models.Article{ID: 4, Author: "Ada", Status: models.StatusInReview}, nil
+			},
+		},
+		{
+			description:     "Draft is visible to its author",
+			articleID:       4,
+			viewer:          models.Principal{UserID: 5, Username: "Ada", Role: models.RoleAuthor},
+			expectedArticle: &models.Article{ID: 4, Author: "Ada", AuthorID: 5, Status: models.StatusDraft},
+			expectedErr:     nil,
+			mockFunc: func(id int) (*models.Article, error) {
+				return &models.Article{ID: 4, Author: "Ada", AuthorID: 5, Status: models.StatusDraft}, nil
+			},
+		},
+		{
+			description:     "Review is visible to editors",
+			articleID:       4,
+			viewer:          models.Principal{UserID: 8, Username: "Editor", Role: models.RoleEditor},
+			expectedArticle: &models.Article{ID: 4, Author: "Ada", AuthorID: 5, Status: models.StatusInReview},
+			expectedErr:     nil,
+			mockFunc: func(id int) (*models.Article, error) {
+				return &models.Article{ID: 4, Author: "Ada", AuthorID: 5, Status: models.StatusInReview}, nil
 			},
 		},
 		{
@@ -165,7 +340,7 @@ func TestArticleService_GetArticleByID(t *testing.T) {
 			mockDB.EXPECT().OneArticle(testCase.articleID).DoAndReturn(testCase.mockFunc)
 
 			// Call the GetArticleByID method
-			article, err := service.GetArticleByID(testCase.articleID)
+			article, err := service.GetArticleByID(testCase.articleID, testCase.viewer)
 
 			// Check the result
 			assert.Equal(t, testCase.expectedErr, err)
@@ -173,3 +348,410 @@ func TestArticleService_GetArticleByID(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleService_GetArticleBySlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	published := &models.Article{ID: 1, Slug: "docker-basics", Status: models.StatusPublished}
+	mockDB.EXPECT().ArticleBySlug("docker-101").Return(published, nil)
+	article, err := service.GetArticleBySlug("docker-101", models.Principal{})
+	assert.NoError(t, err)
+	assert.Equal(t, published, article)
+
+	// Drafts are hidden by slug just as by ID
+	mockDB.EXPECT().ArticleBySlug("draft").Return(&models.Article{ID: 2, Slug: "draft", AuthorID: 5, Status: models.StatusDraft}, nil)
+	_, err = service.GetArticleBySlug("draft", models.Principal{})
+	assert.Equal(t, apperrors.NotFound(appconst.NoArticleforslug, nil), err)
+
+	mockDB.EXPECT().ArticleBySlug("missing").Return(nil, apperrors.NotFound(appconst.NoArticleforslug, sql.ErrNoRows))
+	_, err = service.GetArticleBySlug("missing", models.Principal{})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestArticleSlug(t *testing.T) {
+	testCases := []struct {
+		title    string
+		expected string
+	}{
+		{title: "Docker Basics", expected: "docker-basics"},
+		{title: "  Go 1.19: what's new?  ", expected: "go-1-19-what-s-new"},
+		{title: "Привет, мир", expected: "privet-mir"},
+		{title: "日本語の記事", expected: "日本語の記事"},
+		{title: "!!!", expected: "article"},
+		{title: strings.Repeat("ab ", 40), expected: strings.TrimSuffix(strings.Repeat("ab-", 27), "-")},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, articleSlug(tc.title), tc.title)
+	}
+}
+
+func TestArticleService_UpdateArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+
+	service := NewArticleService(mockDB)
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil).AnyTimes()
+	mockDB.EXPECT().OneUser(8).Return(&models.User{ID: 8, Username: "Editor"}, nil).AnyTimes()
+	editor := models.Principal{UserID: 8, Username: "Editor", Role: models.RoleEditor}
+
+	testCases := []struct {
+		description     string
+		articleID       int
+		current         *models.Article
+		articleToUpdate *models.Article
+		expectedArticle *models.Article
+		expectedErr     error
+	}{
+		{
+			description:     "Article keeps its author",
+			articleID:       1,
+			current:         &models.Article{ID: 1, Title: "Title", Slug: "title", Author: "Author", AuthorID: 7},
+			articleToUpdate: &models.Article{Title: "Updated", Content: "Updated Content"},
+			expectedArticle: &models.Article{ID: 1, Title: "Updated", Slug: "updated", Content: "Updated Content", ContentHTML: "<p>Updated Content</p>\n", Author: "Author", AuthorID: 7, UpdatedBy: "Editor"},
+		},
+		{
+			description:     "Article keeps its slug when the title stays",
+			articleID:       1,
+			current:         &models.Article{ID: 1, Title: "Title", Slug: "title-2", Author: "Author", AuthorID: 7},
+			articleToUpdate: &models.Article{Title: "Title", Content: "Updated Content", Slug: "ignored"},
+			expectedArticle: &models.Article{ID: 1, Title: "Title", Slug: "title-2", Content: "Updated Content", ContentHTML: "<p>Updated Content</p>\n", Author: "Author", AuthorID: 7, UpdatedBy: "Editor"},
+		},
+		{
+			description:     "Article gets another author",
+			articleID:       1,
+			current:         &models.Article{ID: 1, Title: "Title", Author: "Author", AuthorID: 7},
+			articleToUpdate: &models.Article{Title: "Updated", AuthorID: 8},
+			expectedArticle: &models.Article{ID: 1, Title: "Updated", Slug: "updated", Author: "Editor", AuthorID: 8, UpdatedBy: "Editor"},
+		},
+		{
+			description:     "Article not found",
+			articleID:       2,
+			articleToUpdate: &models.Article{Title: "Updated", AuthorID: 7},
+			expectedArticle: nil,
+			expectedErr:     sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			if testCase.current == nil {
+				mockDB.EXPECT().OneArticle(testCase.articleID).Return(nil, testCase.expectedErr)
+			} else {
+				mockDB.EXPECT().OneArticle(testCase.articleID).Return(testCase.current, nil)
+				mockDB.EXPECT().UpdateArticle(testCase.expectedArticle).Return(nil)
+			}
+
+			article, err := service.UpdateArticle(testCase.articleID, testCase.articleToUpdate, editor)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedArticle, article)
+		})
+	}
+}
+
+func TestArticleService_PatchArticle(t *testing.T) {
+	testCases := []struct {
+		description     string
+		patch           string
+		expectedArticle *models.Article
+		expectInvalid   bool
+	}{
+		{
+			description:     "Replace a single field",
+			patch:           `{"title":"Patched"}`,
+			expectedArticle: &models.Article{ID: 1, Title: "Patched", Slug: "patched", Content: "Content", ContentHTML: "<p>Content</p>\n", Author: "Author", AuthorID: 3, UpdatedBy: "Editor"},
+		},
+		{
+			description:     "Null removes a field",
+			patch:           `{"content":null}`,
+			expectedArticle: &models.Article{ID: 1, Title: "Title", Author: "Author", AuthorID: 3, UpdatedBy: "Editor"},
+		},
+		{
+			description:   "Unknown field",
+			patch:         `{"unknown":"value"}`,
+			expectInvalid: true,
+		},
+		{
+			description:   "Malformed patch",
+			patch:         `{invalid`,
+			expectInvalid: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewArticleService(mockDB)
+
+			mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Title: "Title", Content: "Content", Author: "Author", AuthorID: 3}, nil)
+			if !testCase.expectInvalid {
+				mockDB.EXPECT().OneUser(3).Return(&models.User{ID: 3, Username: "Author"}, nil)
+				mockDB.EXPECT().UpdateArticle(testCase.expectedArticle).Return(nil)
+			}
+
+			article, err := service.PatchArticle(1, []byte(testCase.patch), models.Principal{UserID: 8, Username: "Editor", Role: models.RoleEditor})
+
+			if testCase.expectInvalid {
+				assert.ErrorIs(t, err, apperrors.ErrValidation)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedArticle, article)
+		})
+	}
+}
+
+func TestArticleService_DeleteArticle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+
+	service := NewArticleService(mockDB)
+
+	author := models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor}
+
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 7}, nil)
+	mockDB.EXPECT().DeleteArticle(1).Return(nil)
+	mockDB.EXPECT().OneArticle(2).Return(nil, sql.ErrNoRows)
+
+	assert.NoError(t, service.DeleteArticle(1, author))
+	assert.Equal(t, sql.ErrNoRows, service.DeleteArticle(2, author))
+}
+
+func TestArticleService_Permissions(t *testing.T) {
+	author := models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor}
+	other := models.Principal{UserID: 9, Username: "Other", Role: models.RoleAuthor}
+	editor := models.Principal{UserID: 8, Username: "Editor", Role: models.RoleEditor}
+	// writeKey is the author using an API key that may only write articles
+	writeKey := models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor, Scopes: []string{models.ScopeArticlesWrite}}
+	otherWriteKey := models.Principal{UserID: 9, Username: "Other", Role: models.RoleAuthor, Scopes: []string{models.ScopeArticlesWrite}}
+
+	draft := func() *models.Article {
+		return &models.Article{ID: 1, Title: "Title", Author: "Author", AuthorID: 7, Status: models.StatusDraft}
+	}
+	review := func() *models.Article {
+		return &models.Article{ID: 1, Title: "Title", Author: "Author", AuthorID: 7, Status: models.StatusInReview}
+	}
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	yesterday := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	scheduled := func() *models.Article {
+		article := review()
+		article.PublishAt = &tomorrow
+		return article
+	}
+
+	testCases := []struct {
+		description string
+		actor       models.Principal
+		current     *models.Article
+		call        func(service *ArticleService, actor models.Principal) error
+		expectedErr error // nil when the change is saved
+	}{
+		{
+			description: "Author edits their own article",
+			actor:       author,
+			current:     draft(),
+			call:        update(&models.Article{Title: "Updated"}),
+		},
+		{
+			description: "Editor edits any article",
+			actor:       editor,
+			current:     draft(),
+			call:        update(&models.Article{Title: "Updated"}),
+		},
+		{
+			description: "Another author cannot see the draft",
+			actor:       other,
+			current:     draft(),
+			call:        update(&models.Article{Title: "Updated"}),
+			expectedErr: apperrors.ErrNotFound,
+		},
+		{
+			description: "Another author cannot edit a published article",
+			actor:       other,
+			current:     &models.Article{ID: 1, AuthorID: 7, Status: models.StatusPublished},
+			call:        update(&models.Article{Title: "Updated"}),
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Author cannot hand the article to someone else",
+			actor:       author,
+			current:     draft(),
+			call:        update(&models.Article{Title: "Updated", AuthorID: 8}),
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Author cannot schedule their own article",
+			actor:       author,
+			current:     draft(),
+			call:        update(&models.Article{Title: "Title", PublishAt: &tomorrow}),
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Author cannot move the schedule an editor set",
+			actor:       author,
+			current:     scheduled(),
+			call:        update(&models.Article{Title: "Title", PublishAt: timePtr(tomorrow.Add(-time.Hour))}),
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Author edits a scheduled article keeping its schedule",
+			actor:       author,
+			current:     scheduled(),
+			call:        update(&models.Article{Title: "Updated", PublishAt: &tomorrow}),
+		},
+		{
+			description: "Author unschedules their own article",
+			actor:       author,
+			current:     scheduled(),
+			call:        update(&models.Article{Title: "Title"}),
+		},
+		{
+			description: "Editor cannot schedule an article in the past",
+			actor:       editor,
+			current:     review(),
+			call:        update(&models.Article{Title: "Title", PublishAt: timePtr(time.Now().Add(-time.Minute))}),
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "Editor edits an article whose schedule has passed",
+			actor:       editor,
+			current:     &models.Article{ID: 1, Title: "Title", AuthorID: 7, Status: models.StatusInReview, PublishAt: timePtr(yesterday)},
+			call:        update(&models.Article{Title: "Updated", PublishAt: timePtr(yesterday)}),
+		},
+		{
+			description: "Editor schedules an article",
+			actor:       editor,
+			current:     review(),
+			call:        update(&models.Article{Title: "Title", PublishAt: &tomorrow}),
+		},
+		{
+			description: "Author submits their own draft",
+			actor:       author,
+			current:     draft(),
+			call:        run((*ArticleService).SubmitArticle),
+		},
+		{
+			description: "Author cannot publish",
+			actor:       author,
+			current:     review(),
+			call:        run((*ArticleService).PublishArticle),
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Editor publishes",
+			actor:       editor,
+			current:     review(),
+			call:        run((*ArticleService).PublishArticle),
+		},
+		{
+			description: "Author deletes their own article",
+			actor:       author,
+			current:     draft(),
+			call:        func(s *ArticleService, actor models.Principal) error { return s.DeleteArticle(1, actor) },
+		},
+		{
+			description: "Write only key edits the author's own draft",
+			actor:       writeKey,
+			current:     draft(),
+			call:        update(&models.Article{Title: "Updated"}),
+		},
+		{
+			description: "Write only key deletes the author's own draft",
+			actor:       writeKey,
+			current:     draft(),
+			call:        func(s *ArticleService, actor models.Principal) error { return s.DeleteArticle(1, actor) },
+		},
+		{
+			description: "Write only key cannot see another author's draft",
+			actor:       otherWriteKey,
+			current:     draft(),
+			call:        func(s *ArticleService, actor models.Principal) error { return s.DeleteArticle(1, actor) },
+			expectedErr: apperrors.ErrNotFound,
+		},
+		{
+			description: "Another author cannot delete a published article",
+			actor:       other,
+			current:     &models.Article{ID: 1, AuthorID: 7, Status: models.StatusPublished},
+			call:        func(s *ArticleService, actor models.Principal) error { return s.DeleteArticle(1, actor) },
+			expectedErr: apperrors.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewArticleService(mockDB)
+
+			mockDB.EXPECT().OneArticle(1).Return(testCase.current, nil)
+			if testCase.expectedErr == nil {
+				mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil).AnyTimes()
+				mockDB.EXPECT().UpdateArticle(gomock.Any()).Return(nil).MaxTimes(1)
+				mockDB.EXPECT().SetArticleStatus(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+				mockDB.EXPECT().DeleteArticle(1).Return(nil).MaxTimes(1)
+			}
+
+			err := testCase.call(service, testCase.actor)
+
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+// run returns a call of a workflow action on article 1.
+func run(action func(*ArticleService, int, models.Principal) (*models.Article, error)) func(service *ArticleService, actor models.Principal) error {
+	return func(service *ArticleService, actor models.Principal) error {
+		_, err := action(service, 1, actor)
+		return err
+	}
+}
+
+// update returns a call replacing article 1 with article.
+func update(article *models.Article) func(service *ArticleService, actor models.Principal) error {
+	return func(service *ArticleService, actor models.Principal) error {
+		_, err := service.UpdateArticle(1, article, actor)
+		return err
+	}
+}
+
+func TestArticleService_SearchArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+
+	service := NewArticleService(mockDB)
+
+	t.Run("Query is trimmed and page size defaulted", func(t *testing.T) {
+		expected := &models.SearchPage{Results: []models.SearchResult{{Article: models.Article{ID: 1}, Rank: 0.5}}}
+		mockDB.EXPECT().SearchArticles("docker", models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}}).Return(expected, nil)
+
+		page, err := service.SearchArticles("  docker ", models.ListParams{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, page)
+	})
+
+	t.Run("Empty query", func(t *testing.T) {
+		page, err := service.SearchArticles("   ", models.ListParams{})
+
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Nil(t, page)
+	})
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"backend/pkg/models"
+	"strings"
+)
+
+// Diffs take bounded time whatever the revisions hold: past maxDiffLines
+// lines, of both texts together once the lines they start and end with in
+// common are left out, or past maxDiffEdits changes between two lines they
+// have in common, the lines of one text are shown replaced by the lines of
+// the other.
+const (
+	maxDiffLines = 100000
+	maxDiffEdits = 1000
+)
+
+// diffLines returns a shortest line diff turning a into b, computed with
+// the linear space variant of Myers' O(ND) algorithm, within the bounds
+// above. Deleted lines come before the lines inserted in their place.
+func diffLines(a, b string) []models.DiffLine {
+	d := differ{lines: []models.DiffLine{}}
+	d.compare(splitLines(a), splitLines(b))
+	return d.deletesFirst()
+}
+
+// differ collects the lines of a diff as the texts are split up.
+type differ struct {
+	lines []models.DiffLine
+}
+
+func (d *differ) add(op string, texts []string) {
+	for _, text := range texts {
+		d.lines = append(d.lines, models.DiffLine{Op: op, Text: text})
+	}
+}
+
+// compare adds the diff turning x into y. The texts are split where a
+// shortest edit path crosses their middle and each half compared in turn,
+// so only the furthest reaching paths of one round are kept at a time.
+func (d *differ) compare(x, y []string) {
+	prefix := commonPrefix(x, y)
+	d.add(models.DiffEqual, x[:prefix])
+	x, y = x[prefix:], y[prefix:]
+	suffix := commonSuffix(x, y)
+	common := x[len(x)-suffix:]
+	x, y = x[:len(x)-suffix], y[:len(y)-suffix]
+
+	i, j := -1, -1
+	if len(x)+len(y) <= maxDiffLines {
+		i, j = middle(x, y)
+	}
+	if i < 0 {
+		d.add(models.DiffDelete, x)
+		d.add(models.DiffInsert, y)
+	} else {
+		d.compare(x[:i], y[:j])
+		d.compare(x[i:], y[j:])
+	}
+	d.add(models.DiffEqual, common)
+}
+
+// middle returns where a shortest edit path turning x into y is met by
+// one searched for from their ends, or -1 when x and y have no line in
+// common or the paths take more than maxDiffEdits changes. v1[offset+k]
+// and v2[offset+k] are the furthest line of x reached on diagonal k from
+// the start and from the end.
+func middle(x, y []string) (int, int) {
+	n, m := len(x), len(y)
+	if n == 0 || m == 0 {
+		return -1, -1
+	}
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	v1 := make([]int, 2*maxD+2)
+	v2 := make([]int, 2*maxD+2)
+	for k := range v1 {
+		v1[k], v2[k] = -1, -1
+	}
+	v1[offset+1], v2[offset+1] = 0, 0
+
+	delta := n - m
+	// With an odd delta the paths from the start meet the ones from the
+	// end first, with an even one the other way round
+	odd := delta%2 != 0
+	// Diagonals that ran off the edges are not searched again
+	var start1, end1, start2, end2 int
+
+	for d := 0; d < maxD && 2*d < maxDiffEdits; d++ {
+		for k := -d + start1; k <= d-end1; k += 2 {
+			i := v1[offset+k-1] + 1
+			if k == -d || (k != d && v1[offset+k-1] < v1[offset+k+1]) {
+				i = v1[offset+k+1]
+			}
+			j := i - k
+			for i < n && j < m && x[i] == y[j] {
+				i++
+				j++
+			}
+			v1[offset+k] = i
+			switch {
+			case i > n:
+				end1 += 2
+			case j > m:
+				start1 += 2
+			case odd:
+				k2 := offset + delta - k
+				if k2 >= 0 && k2 < len(v2) && v2[k2] != -1 && i >= n-v2[k2] {
+					return i, j
+				}
+			}
+		}
+
+		for k := -d + start2; k <= d-end2; k += 2 {
+			i := v2[offset+k-1] + 1
+			if k == -d || (k != d && v2[offset+k-1] < v2[offset+k+1]) {
+				i = v2[offset+k+1]
+			}
+			j := i - k
+			for i < n && j < m && x[n-i-1] == y[m-j-1] {
+				i++
+				j++
+			}
+			v2[offset+k] = i
+			switch {
+			case i > n:
+				end2 += 2
+			case j > m:
+				start2 += 2
+			case !odd:
+				k1 := offset + delta - k
+				if k1 >= 0 && k1 < len(v1) && v1[k1] != -1 && v1[k1] >= n-i {
+					return v1[k1], v1[k1] - (k1 - offset)
+				}
+			}
+		}
+	}
+	return -1, -1
+}
+
+// deletesFirst returns the lines with the deleted lines of every change
+// before the inserted ones, as the halves of a change can come in either
+// order.
+func (d *differ) deletesFirst() []models.DiffLine {
+	lines := d.lines
+	for start := 0; start < len(lines); {
+		if lines[start].Op == models.DiffEqual {
+			start++
+			continue
+		}
+		end := start
+		var inserted []models.DiffLine
+		for _, line := range lines[start:] {
+			if line.Op == models.DiffEqual {
+				break
+			}
+			if line.Op == models.DiffDelete {
+				lines[end] = line
+				end++
+			} else {
+				inserted = append(inserted, line)
+			}
+		}
+		start = end + copy(lines[end:], inserted)
+	}
+	return lines
+}
+
+// commonPrefix returns how many lines x and y start with in common.
+func commonPrefix(x, y []string) int {
+	n := 0
+	for n < len(x) && n < len(y) && x[n] == y[n] {
+		n++
+	}
+	return n
+}
+
+// commonSuffix returns how many lines x and y end with in common.
+func commonSuffix(x, y []string) int {
+	n := 0
+	for n < len(x) && n < len(y) && x[len(x)-1-n] == y[len(y)-1-n] {
+		n++
+	}
+	return n
+}
+
+// splitLines splits text into lines, ignoring a final line break
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
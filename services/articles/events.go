@@ -0,0 +1,68 @@
+package services
+
+import (
+	"backend/pkg/models"
+	"sync"
+)
+
+// eventBuffer is the number of events a watcher can fall behind by before
+// it is cut off
+const eventBuffer = 64
+
+// events fans the article events of this process out to its watchers.
+// Changes made by other replicas are not seen.
+type events struct {
+	mu       sync.Mutex
+	watchers map[chan models.ArticleEvent]struct{}
+}
+
+// WatchArticles returns a channel receiving every change made to an
+// article from now on, and a function to stop watching. Watchers are not
+// waited for: the channel of a watcher that falls behind is closed, so
+// one slow watcher never holds up writes. Events are sent whoever may see
+// the article, it is up to the watcher to filter them.
+func (s *ArticleService) WatchArticles() (<-chan models.ArticleEvent, func()) {
+	return s.events.watch()
+}
+
+func (e *events) watch() (<-chan models.ArticleEvent, func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.watchers == nil {
+		e.watchers = make(map[chan models.ArticleEvent]struct{})
+	}
+	ch := make(chan models.ArticleEvent, eventBuffer)
+	e.watchers[ch] = struct{}{}
+
+	return ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		e.drop(ch)
+	}
+}
+
+// publish sends an event to every watcher, cutting off the ones whose
+// buffer is full.
+func (e *events) publish(eventType string, article models.Article) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	event := models.ArticleEvent{Type: eventType, Article: article}
+	for ch := range e.watchers {
+		select {
+		case ch <- event:
+		default:
+			e.drop(ch)
+		}
+	}
+}
+
+// drop closes the channel of a watcher, if it has not been dropped yet.
+// The caller holds e.mu.
+func (e *events) drop(ch chan models.ArticleEvent) {
+	if _, ok := e.watchers[ch]; ok {
+		delete(e.watchers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"backend/mocks"
+	"backend/pkg/models"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleService_WatchArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+	author := models.Principal{UserID: 7, Username: "Author", Role: models.RoleAuthor}
+
+	events, stop := service.WatchArticles()
+	defer stop()
+
+	mockDB.EXPECT().OneUser(7).Return(&models.User{ID: 7, Username: "Author"}, nil)
+	mockDB.EXPECT().CreateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) (int, error) {
+		article.ID = 1
+		article.Status = models.StatusDraft
+		return 1, nil
+	})
+	_, err := service.CreateArticle(&models.Article{Title: "New Article", Content: "Hi"}, author)
+	assert.NoError(t, err)
+
+	// failed changes are not sent
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 7, Status: models.StatusDraft}, nil)
+	mockDB.EXPECT().DeleteArticle(1).Return(errors.New("connection refused"))
+	assert.Error(t, service.DeleteArticle(1, author))
+
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 7, Status: models.StatusDraft}, nil)
+	mockDB.EXPECT().DeleteArticle(1).Return(nil)
+	assert.NoError(t, service.DeleteArticle(1, author))
+
+	event := <-events
+	assert.Equal(t, models.EventCreated, event.Type)
+	assert.Equal(t, "new-article", event.Article.Slug)
+	assert.Equal(t, 1, event.Article.ID)
+	event = <-events
+	assert.Equal(t, models.EventDeleted, event.Type)
+	assert.Equal(t, 1, event.Article.ID)
+	assert.Empty(t, events)
+}
+
+func TestArticleService_WatchArticles_SlowWatcher(t *testing.T) {
+	service := NewArticleService(nil)
+	slow, stopSlow := service.WatchArticles()
+	defer stopSlow()
+	fast, stopFast := service.WatchArticles()
+
+	for i := 0; i <= eventBuffer; i++ {
+		service.events.publish(models.EventUpdated, models.Article{ID: i})
+		<-fast
+	}
+
+	// the slow watcher gets what was buffered and is then cut off
+	for i := 0; i < eventBuffer; i++ {
+		event, ok := <-slow
+		assert.True(t, ok)
+		assert.Equal(t, i, event.Article.ID)
+	}
+	_, ok := <-slow
+	assert.False(t, ok)
+
+	stopFast()
+	_, ok = <-fast
+	assert.False(t, ok)
+	// stopping twice is harmless
+	stopFast()
+}
@@ -0,0 +1,34 @@
+package services
+
+import "backend/pkg/models"
+
+// GetFeed returns the latest published articles, limited to the author
+// with a username and to the tag with a slug when they are not empty. An
+// author or tag that does not exist is not found.
+func (s *ArticleService) GetFeed(author, tag string) (*models.Feed, error) {
+	var feed models.Feed
+	var filter models.ArticleFilter
+	if author != "" {
+		user, err := s.repo.UserByUsername(author)
+		if err != nil {
+			return nil, err
+		}
+		feed.Author = user.Username
+		filter.Author = user.Username
+	}
+	if tag != "" {
+		t, err := s.repo.OneTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		feed.Tag = t
+		filter.Tag = t.Slug
+	}
+
+	articles, err := s.repo.LatestArticles(filter, models.FeedSize)
+	if err != nil {
+		return nil, err
+	}
+	feed.Articles = articles
+	return &feed, nil
+}
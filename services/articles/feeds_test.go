@@ -0,0 +1,44 @@
+package services
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleService_GetFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+	latest := []models.Article{{ID: 2}, {ID: 1}}
+
+	// The whole site
+	mockDB.EXPECT().LatestArticles(models.ArticleFilter{}, models.FeedSize).Return(latest, nil)
+	feed, err := service.GetFeed("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Feed{Articles: latest}, feed)
+
+	// Usernames are matched ignoring case and filtered on as they are stored
+	golang := &models.Tag{ID: 4, Name: "Go", Slug: "go"}
+	mockDB.EXPECT().UserByUsername("ADA").Return(&models.User{ID: 7, Username: "Ada"}, nil)
+	mockDB.EXPECT().OneTag("go").Return(golang, nil)
+	mockDB.EXPECT().LatestArticles(models.ArticleFilter{Author: "Ada", Tag: "go"}, models.FeedSize).Return(latest, nil)
+	feed, err = service.GetFeed("ADA", "go")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Feed{Author: "Ada", Tag: golang, Articles: latest}, feed)
+
+	mockDB.EXPECT().UserByUsername("nobody").Return(nil, apperrors.NotFound(appconst.Nouser, sql.ErrNoRows))
+	_, err = service.GetFeed("nobody", "")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	mockDB.EXPECT().OneTag("nothing").Return(nil, apperrors.NotFound(appconst.Notag, sql.ErrNoRows))
+	_, err = service.GetFeed("", "nothing")
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
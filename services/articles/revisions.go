@@ -0,0 +1,89 @@
+package services
+
+import (
+	"backend/pkg/models"
+	"backend/pkg/policy"
+)
+
+// GetRevisions returns a page of the revisions of an article, newest first.
+func (s *ArticleService) GetRevisions(id int, viewer models.Principal, params models.ListParams) (*models.RevisionPage, error) {
+	if err := s.authorizeHistory(id, viewer); err != nil {
+		return nil, err
+	}
+
+	params.Normalize()
+	return s.repo.ArticleRevisions(id, params)
+}
+
+// GetRevision returns one revision of an article.
+func (s *ArticleService) GetRevision(id, revision int, viewer models.Principal) (*models.Revision, error) {
+	if err := s.authorizeHistory(id, viewer); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ArticleRevision(id, revision)
+}
+
+// DiffRevisions compares revision from with revision to of an article line
+// by line. Revision 0 stands for the empty article before the first
+// revision, so diffing it shows everything the article started with.
+func (s *ArticleService) DiffRevisions(id, from, to int, viewer models.Principal) (*models.RevisionDiff, error) {
+	if err := s.authorizeHistory(id, viewer); err != nil {
+		return nil, err
+	}
+
+	older := &models.Revision{}
+	if from > 0 {
+		var err error
+		if older, err = s.repo.ArticleRevision(id, from); err != nil {
+			return nil, err
+		}
+	}
+	newer, err := s.repo.ArticleRevision(id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.RevisionDiff{
+		ArticleID: id,
+		From:      from,
+		To:        to,
+		Title:     diffLines(older.Title, newer.Title),
+		Content:   diffLines(older.Content, newer.Content),
+	}, nil
+}
+
+// authorizeHistory makes sure viewer may read the revisions of the article
+// with the given id. Older revisions hold drafts that were never published,
+// so the history is only shown to those who may edit the article and to
+// editors, even when the article itself is public.
+func (s *ArticleService) authorizeHistory(id int, viewer models.Principal) error {
+	article, err := s.authorizedArticle(id, policy.ViewArticle, viewer)
+	if err != nil {
+		return err
+	}
+	if policy.Can(viewer, policy.ViewAllArticles, nil) {
+		return nil
+	}
+	return policy.Authorize(viewer, policy.EditArticle, article)
+}
+
+// RestoreRevision brings back the title and content an article had at a
+// revision. The restore is saved as a new revision; the article keeps its
+// author, and its current status and schedule, which only change through
+// the workflow.
+func (s *ArticleService) RestoreRevision(id, revision int, actor models.Principal) (*models.Article, error) {
+	current, err := s.authorizedArticle(id, policy.EditArticle, actor)
+	if err != nil {
+		return nil, err
+	}
+	old, err := s.repo.ArticleRevision(id, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	article := *current
+	article.Title = old.Title
+	article.Content = old.Content
+	return s.replace(current, &article, actor)
+}
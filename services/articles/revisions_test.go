@@ -0,0 +1,247 @@
+package services
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	eq := func(text string) models.DiffLine { return models.DiffLine{Op: models.DiffEqual, Text: text} }
+	ins := func(text string) models.DiffLine { return models.DiffLine{Op: models.DiffInsert, Text: text} }
+	del := func(text string) models.DiffLine { return models.DiffLine{Op: models.DiffDelete, Text: text} }
+
+	testCases := []struct {
+		name     string
+		a, b     string
+		expected []models.DiffLine
+	}{
+		{name: "Both empty", a: "", b: "", expected: []models.DiffLine{}},
+		{name: "Identical", a: "one\ntwo", b: "one\ntwo", expected: []models.DiffLine{eq("one"), eq("two")}},
+		{name: "Everything inserted", a: "", b: "one\ntwo\n", expected: []models.DiffLine{ins("one"), ins("two")}},
+		{name: "Everything deleted", a: "one\ntwo", b: "", expected: []models.DiffLine{del("one"), del("two")}},
+		{name: "Line changed", a: "one\ntwo\nthree", b: "one\n2\nthree", expected: []models.DiffLine{eq("one"), del("two"), ins("2"), eq("three")}},
+		{name: "Line added at the end", a: "one", b: "one\ntwo", expected: []models.DiffLine{eq("one"), ins("two")}},
+		{name: "Line removed at the start", a: "one\ntwo", b: "two", expected: []models.DiffLine{del("one"), eq("two")}},
+		{name: "Windows line breaks", a: "one\r\ntwo", b: "one\ntwo", expected: []models.DiffLine{eq("one"), eq("two")}},
+		{
+			name:     "Lines moved",
+			a:        "a\nb\nc\na\nb\nb\na",
+			b:        "c\nb\na\nb\na\nc",
+			expected: []models.DiffLine{del("a"), ins("c"), eq("b"), del("c"), eq("a"), eq("b"), del("b"), eq("a"), ins("c")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, diffLines(testCase.a, testCase.b))
+		})
+	}
+}
+
+// applyDiff returns the texts a diff turns one into the other, and how
+// many lines it changes.
+func applyDiff(lines []models.DiffLine) (string, string, int) {
+	var a, b []string
+	edits := 0
+	for _, line := range lines {
+		if line.Op != models.DiffInsert {
+			a = append(a, line.Text)
+		}
+		if line.Op != models.DiffDelete {
+			b = append(b, line.Text)
+		}
+		if line.Op != models.DiffEqual {
+			edits++
+		}
+	}
+	return strings.Join(a, "\n"), strings.Join(b, "\n"), edits
+}
+
+// numberedLines returns count lines numbered from first.
+func numberedLines(first, count int) []string {
+	lines := make([]string, count)
+	for i := range lines {
+		lines[i] = strconv.Itoa(first + i)
+	}
+	return lines
+}
+
+func TestDiffLines_Large(t *testing.T) {
+	lines := numberedLines(0, 40000)
+	changed := append([]string(nil), lines...)
+	changed[10] = "ten"
+	changed[20000] = "twenty thousand"
+	changed = append(changed[:30000], changed[30001:]...)
+
+	// Every other line changed
+	alternate := numberedLines(0, 3000)
+	for i := 1; i < len(alternate); i += 2 {
+		alternate[i] = "changed"
+	}
+
+	testCases := []struct {
+		name  string
+		a     string
+		b     string
+		edits int
+	}{
+		{name: "A few lines changed", a: strings.Join(lines, "\n"), b: strings.Join(changed, "\n"), edits: 5},
+		{name: "Nothing in common", a: strings.Join(numberedLines(0, 5000), "\n"), b: strings.Join(numberedLines(5000, 5000), "\n"), edits: 10000},
+		// Past maxDiffLines or maxDiffEdits the lines are replaced without
+		// looking for more lines in common
+		{name: "Too many lines", a: strings.Join(numberedLines(0, 60000), "\n"), b: strings.Join(numberedLines(1, 60000), "\n"), edits: 120000},
+		{name: "Too many changes", a: strings.Join(numberedLines(0, 3000), "\n"), b: strings.Join(alternate, "\n"), edits: 5998},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			a, b, edits := applyDiff(diffLines(testCase.a, testCase.b))
+			assert.Equal(t, testCase.a, a)
+			assert.Equal(t, testCase.b, b)
+			assert.Equal(t, testCase.edits, edits)
+		})
+	}
+}
+
+func TestArticleService_DiffRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	author := models.Principal{UserID: 5, Username: "Ada", Role: models.RoleAuthor}
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 5, Status: models.StatusPublished}, nil).Times(2)
+	mockDB.EXPECT().ArticleRevision(1, 1).Return(&models.Revision{Title: "Hello", Content: "one\ntwo"}, nil)
+	mockDB.EXPECT().ArticleRevision(1, 2).Return(&models.Revision{Title: "Hello", Content: "one\nthree"}, nil).Times(2)
+
+	diff, err := service.DiffRevisions(1, 1, 2, author)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.RevisionDiff{
+		ArticleID: 1,
+		From:      1,
+		To:        2,
+		Title:     []models.DiffLine{{Op: models.DiffEqual, Text: "Hello"}},
+		Content:   []models.DiffLine{{Op: models.DiffEqual, Text: "one"}, {Op: models.DiffDelete, Text: "two"}, {Op: models.DiffInsert, Text: "three"}},
+	}, diff)
+
+	// Revision 0 is the empty article
+	diff, err = service.DiffRevisions(1, 0, 2, author)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DiffLine{{Op: models.DiffInsert, Text: "one"}, {Op: models.DiffInsert, Text: "three"}}, diff.Content)
+}
+
+func TestArticleService_GetRevisions_Access(t *testing.T) {
+	draft := &models.Article{ID: 1, Author: "Ada", AuthorID: 5, Status: models.StatusDraft}
+	published := &models.Article{ID: 1, Author: "Ada", AuthorID: 5, Status: models.StatusPublished}
+
+	testCases := []struct {
+		description string
+		article     *models.Article
+		viewer      models.Principal
+		expectedErr error
+	}{
+		{
+			description: "Anonymous users cannot read the history of a published article",
+			viewer:      models.Principal{},
+			expectedErr: apperrors.ErrUnauthorized,
+		},
+		{
+			description: "The history of a draft is as hidden as the draft itself",
+			article:     draft,
+			viewer:      models.Principal{UserID: 9, Username: "Bob", Role: models.RoleAuthor},
+			expectedErr: apperrors.ErrNotFound,
+		},
+		{
+			// Older revisions can hold drafts that were never published
+			description: "Other authors cannot read the history of a published article",
+			article:     published,
+			viewer:      models.Principal{UserID: 9, Username: "Bob", Role: models.RoleAuthor},
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "The author reads the history of their draft",
+			article:     draft,
+			viewer:      models.Principal{UserID: 5, Username: "Ada", Role: models.RoleAuthor},
+		},
+		{
+			description: "Editors read the history of any article",
+			article:     published,
+			viewer:      models.Principal{UserID: 6, Username: "Grace", Role: models.RoleEditor},
+		},
+		{
+			description: "Read only keys of editors read the history",
+			article:     published,
+			viewer:      models.Principal{UserID: 6, Username: "Grace", Role: models.RoleEditor, Scopes: []string{models.ScopeArticlesRead}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewArticleService(mockDB)
+
+			if testCase.article != nil {
+				mockDB.EXPECT().OneArticle(1).Return(testCase.article, nil)
+			}
+			if testCase.expectedErr == nil {
+				mockDB.EXPECT().ArticleRevisions(1, models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}}).Return(&models.RevisionPage{}, nil)
+			}
+
+			page, err := service.GetRevisions(1, testCase.viewer, models.ListParams{})
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, page)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestArticleService_RestoreRevision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	current := &models.Article{ID: 1, Title: "New", Content: "New content", Author: "Ada", AuthorID: 5, Status: models.StatusPublished, Revision: 3}
+	mockDB.EXPECT().OneArticle(1).Return(current, nil)
+	mockDB.EXPECT().ArticleRevision(1, 1).Return(&models.Revision{ArticleID: 1, Revision: 1, Title: "Old", Content: "Old content", Author: "Bob", Status: models.StatusDraft}, nil)
+	mockDB.EXPECT().OneUser(5).Return(&models.User{ID: 5, Username: "Ada"}, nil)
+	mockDB.EXPECT().UpdateArticle(gomock.Any()).DoAndReturn(func(article *models.Article) error {
+		article.Revision++
+		return nil
+	})
+
+	article, err := service.RestoreRevision(1, 1, models.Principal{UserID: 6, Username: "Grace", Role: models.RoleEditor})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Old", article.Title)
+	assert.Equal(t, "Old content", article.Content)
+	// The author and status are not part of the restore
+	assert.Equal(t, "Ada", article.Author)
+	assert.Equal(t, "Grace", article.UpdatedBy)
+	assert.Equal(t, models.StatusPublished, article.Status)
+	assert.Equal(t, 4, article.Revision)
+
+	// Unknown revisions are not found
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1}, nil)
+	mockDB.EXPECT().ArticleRevision(1, 9).Return(nil, apperrors.NotFound(appconst.Norevision, nil))
+
+	_, err = service.RestoreRevision(1, 9, models.Principal{UserID: 6, Username: "Grace", Role: models.RoleEditor})
+
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"log"
+	"sync"
+	"time"
+)
+
+// SchedulerActor is recorded as updated_by on articles the scheduler publishes.
+const SchedulerActor = "scheduler"
+
+// publishBatch is the number of due articles published per statement
+const publishBatch = 100
+
+// GetScheduledArticles returns a page of the reviewed articles waiting to be
+// published, soonest first. Editors see every scheduled article, other
+// signed in users only their own; anonymous viewers none.
+func (s *ArticleService) GetScheduledArticles(params models.ListParams) (*models.ArticlePage, error) {
+	if params.Viewer.Anonymous() {
+		return nil, apperrors.Unauthorized(appconst.Unauthenticated, nil)
+	}
+	params.Normalize()
+	params.AllStatuses = policy.Can(params.Viewer, policy.ViewAllArticles, nil)
+	return s.repo.ScheduledArticles(params)
+}
+
+// PublishDueArticles publishes every reviewed article whose publish_at has
+// passed and returns them. The articles published before an error are
+// returned with it.
+func (s *ArticleService) PublishDueArticles() ([]models.Article, error) {
+	var published []models.Article
+	for {
+		batch, err := s.repo.PublishDueArticles(publishBatch, SchedulerActor)
+		if err != nil {
+			return published, err
+		}
+		for _, article := range batch {
+			s.events.publish(models.EventUpdated, article)
+		}
+		published = append(published, batch...)
+		if len(batch) < publishBatch {
+			return published, nil
+		}
+	}
+}
+
+// Scheduler publishes scheduled articles in the background. Every replica
+// can run one: due articles are claimed with row locks that the other
+// replicas skip, so an article is only ever published once.
+type Scheduler struct {
+	service  *ArticleService
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewScheduler returns a scheduler checking for due articles every interval.
+func NewScheduler(service *ArticleService, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		service:  service,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the scheduler in a goroutine until Stop is called. Due articles
+// are published right away and then on every tick.
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Stop asks the scheduler to stop and waits until a run in progress has
+// finished. It must only be called after Start.
+func (s *Scheduler) Stop() {
+	s.once.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.publishDue()
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishDue runs one round of the scheduler; errors are logged and retried
+// on the next tick.
+func (s *Scheduler) publishDue() {
+	published, err := s.service.PublishDueArticles()
+	for _, article := range published {
+		log.Println(appconst.Scheduledpublished, article.ID, article.Title)
+	}
+	if err != nil {
+		log.Println(appconst.Schedulererror, err)
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"backend/mocks"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleService_PublishDueArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	// Full batches are followed by another one until a short batch comes back
+	full := make([]models.Article, publishBatch)
+	gomock.InOrder(
+		mockDB.EXPECT().PublishDueArticles(publishBatch, SchedulerActor).Return(full, nil),
+		mockDB.EXPECT().PublishDueArticles(publishBatch, SchedulerActor).Return([]models.Article{{ID: 1}}, nil),
+	)
+
+	published, err := service.PublishDueArticles()
+
+	assert.NoError(t, err)
+	assert.Len(t, published, publishBatch+1)
+
+	// Articles published before an error are still reported
+	gomock.InOrder(
+		mockDB.EXPECT().PublishDueArticles(publishBatch, SchedulerActor).Return(full, nil),
+		mockDB.EXPECT().PublishDueArticles(publishBatch, SchedulerActor).Return(nil, apperrors.Unavailable("down", nil)),
+	)
+
+	published, err = service.PublishDueArticles()
+
+	assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+	assert.Len(t, published, publishBatch)
+}
+
+func TestArticleService_GetScheduledArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	editor := models.Principal{UserID: 2, Username: "Grace", Role: models.RoleEditor}
+	author := models.Principal{UserID: 7, Username: "Ada", Role: models.RoleAuthor}
+
+	// editors see every scheduled article, authors only their own
+	expected := &models.ArticlePage{Articles: []models.Article{{ID: 1}}}
+	mockDB.EXPECT().ScheduledArticles(models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}, Viewer: editor, AllStatuses: true}).Return(expected, nil)
+	page, err := service.GetScheduledArticles(models.ListParams{Viewer: editor})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, page)
+
+	mockDB.EXPECT().ScheduledArticles(models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}, Viewer: author}).Return(expected, nil)
+	_, err = service.GetScheduledArticles(models.ListParams{Viewer: author})
+	assert.NoError(t, err)
+
+	_, err = service.GetScheduledArticles(models.ListParams{})
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+}
+
+func TestScheduler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+
+	// The first run happens on start, the next ones on every tick
+	runs := make(chan struct{}, 10)
+	mockDB.EXPECT().PublishDueArticles(publishBatch, SchedulerActor).DoAndReturn(func(int, string) ([]models.Article, error) {
+		runs <- struct{}{}
+		return []models.Article{{ID: 1, Title: "Due"}}, nil
+	}).MinTimes(2)
+
+	scheduler := NewScheduler(NewArticleService(mockDB), time.Millisecond)
+	scheduler.Start()
+	<-runs
+	<-runs
+	scheduler.Stop()
+
+	// Stopping twice is harmless and no run happens after Stop returns
+	scheduler.Stop()
+	count := len(runs)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, count, len(runs))
+}
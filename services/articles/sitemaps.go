@@ -0,0 +1,31 @@
+package services
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/sitemap"
+)
+
+// GetSitemapPages returns the pages the published articles are listed on in
+// sitemaps, which is none when nothing is published.
+func (s *ArticleService) GetSitemapPages() ([]models.SitemapPage, error) {
+	return s.repo.SitemapPages(sitemap.MaxURLs)
+}
+
+// GetSitemap returns the published articles listed on a page of sitemaps.
+// The first page is there even when nothing is published; any other page
+// past the last is not found.
+func (s *ArticleService) GetSitemap(page int) ([]models.SitemapEntry, error) {
+	if page < 1 {
+		return nil, apperrors.NotFound(appconst.Nositemap, nil)
+	}
+	entries, err := s.repo.SitemapEntries(page, sitemap.MaxURLs)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 && page > 1 {
+		return nil, apperrors.NotFound(appconst.Nositemap, nil)
+	}
+	return entries, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"backend/mocks"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/sitemap"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleService_GetSitemap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	mockDB.EXPECT().SitemapPages(sitemap.MaxURLs).Return([]models.SitemapPage{{Number: 1}}, nil)
+	pages, err := service.GetSitemapPages()
+	assert.NoError(t, err)
+	assert.Len(t, pages, 1)
+
+	entries := []models.SitemapEntry{{ArticleID: 1, Slug: "first"}}
+	mockDB.EXPECT().SitemapEntries(1, sitemap.MaxURLs).Return(entries, nil)
+	got, err := service.GetSitemap(1)
+	assert.NoError(t, err)
+	assert.Equal(t, entries, got)
+
+	// The first sitemap is there before anything is published
+	mockDB.EXPECT().SitemapEntries(1, sitemap.MaxURLs).Return([]models.SitemapEntry{}, nil)
+	got, err = service.GetSitemap(1)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	// Pages past the last are not
+	mockDB.EXPECT().SitemapEntries(3, sitemap.MaxURLs).Return([]models.SitemapEntry{}, nil)
+	_, err = service.GetSitemap(3)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	_, err = service.GetSitemap(0)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
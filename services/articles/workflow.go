@@ -0,0 +1,92 @@
+package services
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"fmt"
+)
+
+// Article workflow actions
+const (
+	ActionSubmit    = "submit"
+	ActionPublish   = "publish"
+	ActionUnpublish = "unpublish"
+	ActionArchive   = "archive"
+)
+
+// transition is a workflow action: the statuses it applies to, the status
+// it leads to and what the policy has to allow the actor.
+type transition struct {
+	from   []string
+	to     string
+	policy policy.Action
+}
+
+// transitions is the article state machine:
+//
+//	draft -> in_review -> published -> archived
+//
+// Published articles can be taken back to draft, and anything that is not
+// archived yet can be archived. Archived is final.
+var transitions = map[string]transition{
+	ActionSubmit:    {from: []string{models.StatusDraft}, to: models.StatusInReview, policy: policy.SubmitArticle},
+	ActionPublish:   {from: []string{models.StatusInReview}, to: models.StatusPublished, policy: policy.PublishArticle},
+	ActionUnpublish: {from: []string{models.StatusPublished}, to: models.StatusDraft, policy: policy.PublishArticle},
+	ActionArchive:   {from: []string{models.StatusDraft, models.StatusInReview, models.StatusPublished}, to: models.StatusArchived, policy: policy.ArchiveArticle},
+}
+
+// SubmitArticle sends a draft for review.
+func (s *ArticleService) SubmitArticle(id int, actor models.Principal) (*models.Article, error) {
+	return s.transition(id, ActionSubmit, actor)
+}
+
+// PublishArticle makes a reviewed article public.
+func (s *ArticleService) PublishArticle(id int, actor models.Principal) (*models.Article, error) {
+	return s.transition(id, ActionPublish, actor)
+}
+
+// UnpublishArticle takes a published article back to draft.
+func (s *ArticleService) UnpublishArticle(id int, actor models.Principal) (*models.Article, error) {
+	return s.transition(id, ActionUnpublish, actor)
+}
+
+// ArchiveArticle retires an article for good.
+func (s *ArticleService) ArchiveArticle(id int, actor models.Principal) (*models.Article, error) {
+	return s.transition(id, ActionArchive, actor)
+}
+
+// transition applies a workflow action to an article on behalf of actor.
+// Actions that do not apply to the article's current status are rejected
+// with a conflict.
+func (s *ArticleService) transition(id int, action string, actor models.Principal) (*models.Article, error) {
+	t := transitions[action]
+
+	article, err := s.authorizedArticle(id, t.policy, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	from := article.Status
+	if !allowed(t.from, from) {
+		return nil, apperrors.Conflict(fmt.Sprintf(appconst.Invalidtransition, action, from), nil)
+	}
+
+	article.Status = t.to
+	article.UpdatedBy = actor.Username
+	if err := s.repo.SetArticleStatus(article, from); err != nil {
+		return nil, err
+	}
+	s.events.publish(models.EventUpdated, *article)
+	return article, nil
+}
+
+func allowed(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
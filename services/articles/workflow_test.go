@@ -0,0 +1,91 @@
+package services
+
+import (
+	"backend/mocks"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArticleService_Transitions(t *testing.T) {
+	testCases := []struct {
+		action   string
+		from     string
+		expected string // empty when the action is rejected
+	}{
+		{action: ActionSubmit, from: models.StatusDraft, expected: models.StatusInReview},
+		{action: ActionSubmit, from: models.StatusInReview},
+		{action: ActionSubmit, from: models.StatusPublished},
+		{action: ActionSubmit, from: models.StatusArchived},
+		{action: ActionPublish, from: models.StatusDraft},
+		{action: ActionPublish, from: models.StatusInReview, expected: models.StatusPublished},
+		{action: ActionPublish, from: models.StatusPublished},
+		{action: ActionPublish, from: models.StatusArchived},
+		{action: ActionUnpublish, from: models.StatusDraft},
+		{action: ActionUnpublish, from: models.StatusInReview},
+		{action: ActionUnpublish, from: models.StatusPublished, expected: models.StatusDraft},
+		{action: ActionUnpublish, from: models.StatusArchived},
+		{action: ActionArchive, from: models.StatusDraft, expected: models.StatusArchived},
+		{action: ActionArchive, from: models.StatusInReview, expected: models.StatusArchived},
+		{action: ActionArchive, from: models.StatusPublished, expected: models.StatusArchived},
+		{action: ActionArchive, from: models.StatusArchived},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.action+" from "+testCase.from, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewArticleService(mockDB)
+
+			mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Author: "Ada", Status: testCase.from}, nil)
+			if testCase.expected != "" {
+				mockDB.EXPECT().SetArticleStatus(&models.Article{ID: 1, Author: "Ada", Status: testCase.expected, UpdatedBy: "Grace"}, testCase.from).Return(nil)
+			}
+
+			actions := map[string]func(id int, actor models.Principal) (*models.Article, error){
+				ActionSubmit:    service.SubmitArticle,
+				ActionPublish:   service.PublishArticle,
+				ActionUnpublish: service.UnpublishArticle,
+				ActionArchive:   service.ArchiveArticle,
+			}
+			article, err := actions[testCase.action](1, models.Principal{UserID: 2, Username: "Grace", Role: models.RoleEditor})
+
+			if testCase.expected == "" {
+				assert.ErrorIs(t, err, apperrors.ErrConflict)
+				assert.Nil(t, article)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, article.Status)
+		})
+	}
+}
+
+func TestArticleService_TransitionErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewArticleService(mockDB)
+
+	grace := models.Principal{UserID: 2, Username: "Grace", Role: models.RoleEditor}
+
+	// Anonymous changes are refused before anything is read
+	_, err := service.PublishArticle(1, models.Principal{})
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+
+	// A missing article is reported as such
+	mockDB.EXPECT().OneArticle(1).Return(nil, apperrors.NotFound("not found", sql.ErrNoRows))
+	_, err = service.PublishArticle(1, grace)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	// A transition that lost a race is passed on
+	mockDB.EXPECT().OneArticle(2).Return(&models.Article{ID: 2, Status: models.StatusInReview}, nil)
+	mockDB.EXPECT().SetArticleStatus(gomock.Any(), models.StatusInReview).Return(apperrors.Conflict("changed", nil))
+	_, err = service.PublishArticle(2, grace)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+}
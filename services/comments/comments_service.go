@@ -0,0 +1,326 @@
+package comments
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"backend/pkg/repository/dbrepo"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type CommentServices interface {
+	GetComments(articleID int, view string, params models.ListParams, viewer models.Principal) (*models.CommentPage, error)
+	GetArticlesComments(articleIDs []int, limit int) (map[int][]models.Comment, error)
+	PostComment(articleID int, request models.CommentRequest, actor models.Principal) (*models.Comment, error)
+	EditComment(articleID, id int, request models.CommentRequest, actor models.Principal) (*models.Comment, error)
+	DeleteComment(articleID, id int, actor models.Principal) error
+	ModerationQueue(status string, params models.ListParams, actor models.Principal) (*models.CommentPage, error)
+	ModerateComment(id int, status string, actor models.Principal) (*models.Comment, error)
+}
+
+type CommentService struct {
+	repo dbrepo.DatabaseRepo
+	// scorer rates new and edited comments; edits of approved comments
+	// scoring threshold or more are held for moderation again
+	scorer    SpamScorer
+	threshold float64
+}
+
+func NewCommentService(repo dbrepo.DatabaseRepo, scorer SpamScorer, threshold float64) *CommentService {
+	return &CommentService{
+		repo:      repo,
+		scorer:    scorer,
+		threshold: threshold,
+	}
+}
+
+// GetComments returns a page of the approved threads on an article the
+// viewer may see. The tree view nests replies under the comment they answer; the flat
+// view lists each thread depth first.
+func (s *CommentService) GetComments(articleID int, view string, params models.ListParams, viewer models.Principal) (*models.CommentPage, error) {
+	if view == "" {
+		view = models.CommentTree
+	}
+	if view != models.CommentTree && view != models.CommentFlat {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Invalidview, strings.Join(models.CommentViews, ", ")), nil)
+	}
+	if err := s.checkArticle(articleID, viewer); err != nil {
+		return nil, err
+	}
+
+	params.Normalize()
+	page, err := s.repo.ArticleComments(articleID, params)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range page.Comments {
+		public(&page.Comments[i])
+	}
+	if view == models.CommentTree {
+		page.Comments = nest(page.Comments)
+	}
+	return page, nil
+}
+
+// GetArticlesComments returns the first limit approved comments on each of
+// the articles, in the order they were made, by the ID of the article.
+// Callers have checked the articles are visible to whoever asks.
+func (s *CommentService) GetArticlesComments(articleIDs []int, limit int) (map[int][]models.Comment, error) {
+	params := models.ListParams{Limit: limit}
+	params.Normalize()
+	comments, err := s.repo.CommentsByArticles(articleIDs, params.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	byArticle := make(map[int][]models.Comment, len(articleIDs))
+	for i := range comments {
+		public(&comments[i])
+		byArticle[comments[i].ArticleID] = append(byArticle[comments[i].ArticleID], comments[i])
+	}
+	return byArticle, nil
+}
+
+// PostComment comments on an article the actor can see, or replies to an
+// approved comment on it. Every comment waits as pending until a moderator
+// decides on it, with its spam score to help them.
+func (s *CommentService) PostComment(articleID int, request models.CommentRequest, actor models.Principal) (*models.Comment, error) {
+	if err := policy.Authorize(actor, policy.PostComment, nil); err != nil {
+		return nil, err
+	}
+	body, err := commentBody(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	comment := &models.Comment{
+		ArticleID: articleID,
+		AuthorID:  actor.UserID,
+		Author:    actor.Username,
+		Body:      body,
+	}
+	if request.ParentID != nil {
+		parent, err := s.comment(articleID, *request.ParentID)
+		if errors.Is(err, apperrors.ErrNotFound) || (err == nil && parent.Status != models.CommentApproved) {
+			return nil, apperrors.Validation(appconst.Noparent, nil)
+		}
+		if err != nil {
+			return nil, err
+		}
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+
+	comment.Status = models.CommentPending
+	if err := s.score(comment, actor); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateComment(comment); err != nil {
+		return nil, err
+	}
+	public(comment)
+	return comment, nil
+}
+
+// EditComment changes the body of a comment. Only its author can edit it.
+// An edit that looks like spam is held for moderation again.
+func (s *CommentService) EditComment(articleID, id int, request models.CommentRequest, actor models.Principal) (*models.Comment, error) {
+	if err := policy.Authorize(actor, policy.PostComment, nil); err != nil {
+		return nil, err
+	}
+	body, err := commentBody(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkArticle(articleID, actor); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.comment(articleID, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actor.UserID {
+		return nil, apperrors.Forbidden(appconst.Forbidden, nil)
+	}
+
+	comment.Body = body
+	if err := s.score(comment, actor); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateComment(comment); err != nil {
+		return nil, err
+	}
+	public(comment)
+	return comment, nil
+}
+
+// DeleteComment deletes a comment. Authors can delete their own comments,
+// editors any comment. A comment with replies stays in the thread, marked
+// deleted.
+func (s *CommentService) DeleteComment(articleID, id int, actor models.Principal) error {
+	if err := policy.Authorize(actor, policy.PostComment, nil); err != nil {
+		return err
+	}
+	if err := s.checkArticle(articleID, actor); err != nil {
+		return err
+	}
+
+	comment, err := s.comment(articleID, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != actor.UserID {
+		if err := policy.Authorize(actor, policy.ModerateComments, nil); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.DeleteComment(id)
+}
+
+// ModerationQueue returns a page of the comments with a status, pending
+// ones by default, oldest first. Only moderators see the queue.
+func (s *CommentService) ModerationQueue(status string, params models.ListParams, actor models.Principal) (*models.CommentPage, error) {
+	if err := policy.Authorize(actor, policy.ModerateComments, nil); err != nil {
+		return nil, err
+	}
+	if status == "" {
+		status = models.CommentPending
+	}
+	if !validStatus(status, models.CommentStatuses) {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Commentstatus, strings.Join(models.CommentStatuses, ", ")), nil)
+	}
+
+	params.Normalize()
+	return s.repo.CommentQueue(status, params)
+}
+
+// ModerateComment approves or rejects a comment, or marks it as spam. Only
+// approved comments are listed on their article.
+func (s *CommentService) ModerateComment(id int, status string, actor models.Principal) (*models.Comment, error) {
+	if err := policy.Authorize(actor, policy.ModerateComments, nil); err != nil {
+		return nil, err
+	}
+	decisions := []string{models.CommentApproved, models.CommentRejected, models.CommentSpam}
+	if !validStatus(status, decisions) {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Commentstatus, strings.Join(decisions, ", ")), nil)
+	}
+
+	return s.repo.SetCommentStatus(id, status, actor.Username)
+}
+
+// score rates a new or edited comment by actor, and holds it for
+// moderation when it looks like spam.
+func (s *CommentService) score(comment *models.Comment, actor models.Principal) error {
+	author, err := s.repo.OneUser(actor.UserID)
+	if err != nil {
+		return err
+	}
+
+	result := s.scorer.Score(*comment, *author)
+	comment.SpamScore = result.Score
+	comment.SpamReasons = result.Reasons
+	if result.Score >= s.threshold {
+		comment.Status = models.CommentPending
+	}
+	return nil
+}
+
+// checkArticle returns nil if the article exists and viewer may see it.
+// Hidden articles are reported as not found, as GetArticleByID does.
+func (s *CommentService) checkArticle(articleID int, viewer models.Principal) error {
+	article, err := s.repo.OneArticle(articleID)
+	if err != nil {
+		return err
+	}
+	if !policy.Can(viewer, policy.ViewArticle, article) {
+		return apperrors.NotFound(appconst.NoArticleforid, nil)
+	}
+	return nil
+}
+
+// comment returns a comment on the article that is not deleted.
+func (s *CommentService) comment(articleID, id int) (*models.Comment, error) {
+	comment, err := s.repo.OneComment(id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.ArticleID != articleID || comment.Deleted {
+		return nil, apperrors.NotFound(appconst.Nocomment, nil)
+	}
+	return comment, nil
+}
+
+// commentBody returns the body of a comment without surrounding space, or
+// an error if it is empty or too long.
+func commentBody(body string) (string, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return "", apperrors.Validation(appconst.Commentbody, nil)
+	}
+	if utf8.RuneCountInString(body) > models.MaxCommentLength {
+		return "", apperrors.Validation(fmt.Sprintf(appconst.Commentlength, models.MaxCommentLength), nil)
+	}
+	return body, nil
+}
+
+// public hides who wrote a deleted comment, and how every comment was
+// moderated, from those who are not moderators.
+func public(comment *models.Comment) {
+	if comment.Deleted {
+		comment.AuthorID = 0
+		comment.Author = ""
+		comment.Body = ""
+	}
+	comment.SpamScore = 0
+	comment.SpamReasons = nil
+	comment.ModeratedBy = ""
+	comment.ModeratedAt = nil
+}
+
+func validStatus(status string, statuses []string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// nest turns comments listed depth first into a tree of threads, keeping
+// the order of replies.
+func nest(comments []models.Comment) []models.Comment {
+	// Replies by the ID of the comment they answer; threads are under 0
+	replies := map[int][]models.Comment{}
+	for _, comment := range comments {
+		parent := 0
+		if comment.ParentID != nil {
+			parent = *comment.ParentID
+		}
+		replies[parent] = append(replies[parent], comment)
+	}
+
+	var build func(parent int) []models.Comment
+	build = func(parent int) []models.Comment {
+		children := replies[parent]
+		for i := range children {
+			children[i].Replies = build(children[i].ID)
+		}
+		return children
+	}
+
+	threads := build(0)
+	if threads == nil {
+		threads = []models.Comment{}
+	}
+	return threads
+}
@@ -0,0 +1,398 @@
+package comments
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ada   = models.Principal{UserID: 1, Username: "ada", Role: models.RoleAuthor}
+	grace = models.Principal{UserID: 2, Username: "grace", Role: models.RoleAuthor}
+	edith = models.Principal{UserID: 3, Username: "edith", Role: models.RoleEditor}
+)
+
+// fixedScorer gives every comment the same spam score
+type fixedScorer float64
+
+func (f fixedScorer) Score(models.Comment, models.User) SpamScore {
+	return SpamScore{Score: float64(f), Reasons: []string{ReasonLinks}}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// thread is a page of comments on article 1, depth first: comment 1 with
+// the replies 2 and 4, 2 with the reply 3, and the deleted comment 5 with
+// the reply 6
+func thread() *models.CommentPage {
+	return &models.CommentPage{
+		Comments: []models.Comment{
+			{ID: 1, ArticleID: 1, AuthorID: 1, Author: "ada", Body: "First"},
+			{ID: 2, ArticleID: 1, ParentID: intPtr(1), AuthorID: 2, Author: "grace", Body: "Reply", Depth: 1},
+			{ID: 3, ArticleID: 1, ParentID: intPtr(2), AuthorID: 1, Author: "ada", Body: "Reply to reply", Depth: 2},
+			{ID: 4, ArticleID: 1, ParentID: intPtr(1), AuthorID: 3, Author: "edith", Body: "Another reply", Depth: 1},
+			{ID: 5, ArticleID: 1, AuthorID: 2, Author: "grace", Deleted: true},
+			{ID: 6, ArticleID: 1, ParentID: intPtr(5), AuthorID: 1, Author: "ada", Body: "Orphan", Depth: 1},
+		},
+		PageInfo: models.PageInfo{Total: 2},
+	}
+}
+
+func TestCommentService_GetComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 1, Status: models.StatusPublished}, nil).Times(2)
+	mockDB.EXPECT().ArticleComments(1, models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}}).DoAndReturn(func(int, models.ListParams) (*models.CommentPage, error) {
+		return thread(), nil
+	}).Times(2)
+
+	// Replies are nested in the tree view, and deleted comments do not tell
+	// who wrote them
+	page, err := service.GetComments(1, "", models.ListParams{}, models.Principal{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Comments, 2)
+	first := page.Comments[0]
+	assert.Equal(t, 1, first.ID)
+	assert.Len(t, first.Replies, 2)
+	assert.Equal(t, 2, first.Replies[0].ID)
+	assert.Equal(t, 3, first.Replies[0].Replies[0].ID)
+	assert.Equal(t, 4, first.Replies[1].ID)
+	deleted := page.Comments[1]
+	assert.Equal(t, models.Comment{ID: 5, ArticleID: 1, Deleted: true, Replies: []models.Comment{thread().Comments[5]}}, deleted)
+
+	// The flat view keeps the order and depth
+	page, err = service.GetComments(1, models.CommentFlat, models.ListParams{}, models.Principal{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Comments, 6)
+	assert.Equal(t, 2, page.Comments[2].Depth)
+	assert.Empty(t, page.Comments[4].Author)
+
+	_, err = service.GetComments(1, "nested", models.ListParams{}, models.Principal{})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	// Drafts and their comments are hidden from readers
+	mockDB.EXPECT().OneArticle(2).Return(&models.Article{ID: 2, AuthorID: 1, Status: models.StatusDraft}, nil)
+	_, err = service.GetComments(2, "", models.ListParams{}, grace)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestCommentService_GetArticlesComments(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+	// Limits above the page size are capped
+	mockDB.EXPECT().CommentsByArticles([]int{1, 2, 3}, models.MaxPageSize).Return([]models.Comment{
+		{ID: 1, ArticleID: 1, AuthorID: 1, Author: "ada", Body: "First", SpamScore: 0.2, ModeratedBy: "edith"},
+		{ID: 5, ArticleID: 1, AuthorID: 2, Author: "grace", Body: "Gone", Deleted: true},
+		{ID: 7, ArticleID: 2, AuthorID: 1, Author: "ada", Body: "Other"},
+	}, nil)
+
+	byArticle, err := service.GetArticlesComments([]int{1, 2, 3}, 500)
+	assert.NoError(t, err)
+	assert.Len(t, byArticle[1], 2)
+	assert.Equal(t, models.Comment{ID: 1, ArticleID: 1, AuthorID: 1, Author: "ada", Body: "First"}, byArticle[1][0])
+	assert.Equal(t, models.Comment{ID: 5, ArticleID: 1, Deleted: true}, byArticle[1][1])
+	assert.Len(t, byArticle[2], 1)
+	assert.Empty(t, byArticle[3])
+}
+
+func TestNest_Empty(t *testing.T) {
+	assert.Equal(t, []models.Comment{}, nest(nil))
+}
+
+func TestCommentService_PostComment(t *testing.T) {
+	testCases := []struct {
+		description string
+		request     models.CommentRequest
+		actor       models.Principal
+		parent      *models.Comment
+		spamScore   float64
+		expectSave  bool
+		expected    models.Comment
+		expectedErr error
+	}{
+		{
+			description: "Comment waits for a moderator",
+			request:     models.CommentRequest{Body: "  Nice post  "},
+			actor:       grace,
+			expectSave:  true,
+			expected:    models.Comment{ID: 9, ArticleID: 1, AuthorID: 2, Author: "grace", Body: "Nice post", Status: models.CommentPending},
+		},
+		{
+			description: "Comment that looks like spam",
+			request:     models.CommentRequest{Body: "Cheap pills"},
+			actor:       grace,
+			spamScore:   0.9,
+			expectSave:  true,
+			expected:    models.Comment{ID: 9, ArticleID: 1, AuthorID: 2, Author: "grace", Body: "Cheap pills", Status: models.CommentPending},
+		},
+		{
+			description: "Reply",
+			request:     models.CommentRequest{Body: "Thanks", ParentID: intPtr(2)},
+			actor:       ada,
+			parent:      &models.Comment{ID: 2, ArticleID: 1, Depth: 1, Status: models.CommentApproved},
+			expectSave:  true,
+			expected:    models.Comment{ID: 9, ArticleID: 1, ParentID: intPtr(2), AuthorID: 1, Author: "ada", Body: "Thanks", Depth: 2, Status: models.CommentPending},
+		},
+		{
+			description: "Reply to a comment on another article",
+			request:     models.CommentRequest{Body: "Thanks", ParentID: intPtr(2)},
+			actor:       ada,
+			parent:      &models.Comment{ID: 2, ArticleID: 7},
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "Reply to a deleted comment",
+			request:     models.CommentRequest{Body: "Thanks", ParentID: intPtr(2)},
+			actor:       ada,
+			parent:      &models.Comment{ID: 2, ArticleID: 1, Deleted: true},
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "Reply to a pending comment",
+			request:     models.CommentRequest{Body: "Thanks", ParentID: intPtr(2)},
+			actor:       ada,
+			parent:      &models.Comment{ID: 2, ArticleID: 1, Status: models.CommentPending},
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "Anonymous",
+			request:     models.CommentRequest{Body: "Nice post"},
+			expectedErr: apperrors.ErrUnauthorized,
+		},
+		{
+			description: "Read only API key",
+			request:     models.CommentRequest{Body: "Nice post"},
+			actor:       models.Principal{UserID: 2, Username: "grace", Role: models.RoleAuthor, Scopes: []string{models.ScopeArticlesRead}},
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Empty body",
+			request:     models.CommentRequest{Body: " \n "},
+			actor:       grace,
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "Body too long",
+			request:     models.CommentRequest{Body: strings.Repeat("é", models.MaxCommentLength+1)},
+			actor:       grace,
+			expectedErr: apperrors.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewCommentService(mockDB, fixedScorer(testCase.spamScore), DefaultHoldThreshold)
+
+			mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 1, Status: models.StatusPublished}, nil).AnyTimes()
+			if testCase.parent != nil {
+				mockDB.EXPECT().OneComment(testCase.parent.ID).Return(testCase.parent, nil)
+			}
+			if testCase.expectSave {
+				mockDB.EXPECT().OneUser(testCase.actor.UserID).Return(&models.User{ID: testCase.actor.UserID}, nil)
+				mockDB.EXPECT().CreateComment(gomock.Any()).DoAndReturn(func(comment *models.Comment) error {
+					// The score is stored, but not shown to the author
+					assert.Equal(t, testCase.spamScore, comment.SpamScore)
+					comment.ID = 9
+					return nil
+				})
+			}
+
+			comment, err := service.PostComment(1, testCase.request, testCase.actor)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, comment)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &testCase.expected, comment)
+		})
+	}
+}
+
+func TestCommentService_EditComment(t *testing.T) {
+	testCases := []struct {
+		description string
+		articleID   int
+		actor       models.Principal
+		expectSave  bool
+		expectedErr error
+	}{
+		{description: "Author", articleID: 1, actor: grace, expectSave: true},
+		{description: "Editor", articleID: 1, actor: edith, expectedErr: apperrors.ErrForbidden},
+		{description: "Other article", articleID: 2, actor: grace, expectedErr: apperrors.ErrNotFound},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+			mockDB.EXPECT().OneArticle(testCase.articleID).Return(&models.Article{ID: testCase.articleID, Status: models.StatusPublished}, nil)
+			mockDB.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 1, AuthorID: 2, Author: "grace", Body: "Old", Status: models.CommentApproved}, nil)
+			if testCase.expectSave {
+				mockDB.EXPECT().OneUser(2).Return(&models.User{ID: 2}, nil)
+				mockDB.EXPECT().UpdateComment(&models.Comment{ID: 4, ArticleID: 1, AuthorID: 2, Author: "grace", Body: "New", Status: models.CommentApproved, SpamReasons: []string{ReasonLinks}}).Return(nil)
+			}
+
+			comment, err := service.EditComment(testCase.articleID, 4, models.CommentRequest{Body: "New"}, testCase.actor)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "New", comment.Body)
+		})
+	}
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	testCases := []struct {
+		description  string
+		actor        models.Principal
+		expectDelete bool
+		expectedErr  error
+	}{
+		{description: "Author", actor: grace, expectDelete: true},
+		{description: "Editor", actor: edith, expectDelete: true},
+		{description: "Other user", actor: ada, expectedErr: apperrors.ErrForbidden},
+		{description: "Anonymous", expectedErr: apperrors.ErrUnauthorized},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+			mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, AuthorID: 1, Status: models.StatusPublished}, nil).AnyTimes()
+			mockDB.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 1, AuthorID: 2}, nil).AnyTimes()
+			if testCase.expectDelete {
+				mockDB.EXPECT().DeleteComment(4).Return(nil)
+			}
+
+			err := service.DeleteComment(1, 4, testCase.actor)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCommentService_DeleteMissingComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Status: models.StatusPublished}, nil)
+	mockDB.EXPECT().OneComment(4).Return(nil, apperrors.NotFound(appconst.Nocomment, sql.ErrNoRows))
+
+	assert.ErrorIs(t, service.DeleteComment(1, 4, grace), apperrors.ErrNotFound)
+}
+
+func TestCommentService_EditHeldComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewCommentService(mockDB, fixedScorer(0.8), DefaultHoldThreshold)
+
+	mockDB.EXPECT().OneArticle(1).Return(&models.Article{ID: 1, Status: models.StatusPublished}, nil)
+	mockDB.EXPECT().OneComment(4).Return(&models.Comment{ID: 4, ArticleID: 1, AuthorID: 2, Status: models.CommentApproved}, nil)
+	mockDB.EXPECT().OneUser(2).Return(&models.User{ID: 2}, nil)
+	mockDB.EXPECT().UpdateComment(gomock.Any()).Return(nil)
+
+	// An approved comment edited into spam waits for a moderator again
+	comment, err := service.EditComment(1, 4, models.CommentRequest{Body: "Buy now"}, grace)
+	assert.NoError(t, err)
+	assert.Equal(t, models.CommentPending, comment.Status)
+	assert.Zero(t, comment.SpamScore)
+}
+
+func TestCommentService_ModerationQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+	mockDB.EXPECT().CommentQueue(models.CommentPending, gomock.Any()).Return(&models.CommentPage{Comments: []models.Comment{{ID: 4, SpamScore: 0.7}}}, nil)
+	mockDB.EXPECT().CommentQueue(models.CommentSpam, gomock.Any()).Return(&models.CommentPage{Comments: []models.Comment{}}, nil)
+
+	// Moderators see the spam scores
+	page, err := service.ModerationQueue("", models.ListParams{}, edith)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.7, page.Comments[0].SpamScore)
+
+	_, err = service.ModerationQueue(models.CommentSpam, models.ListParams{}, edith)
+	assert.NoError(t, err)
+
+	_, err = service.ModerationQueue("hidden", models.ListParams{}, edith)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	_, err = service.ModerationQueue("", models.ListParams{}, grace)
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestCommentService_ModerateComment(t *testing.T) {
+	testCases := []struct {
+		description string
+		status      string
+		actor       models.Principal
+		expectSave  bool
+		expectedErr error
+	}{
+		{description: "Approve", status: models.CommentApproved, actor: edith, expectSave: true},
+		{description: "Reject", status: models.CommentRejected, actor: edith, expectSave: true},
+		{description: "Spam", status: models.CommentSpam, actor: edith, expectSave: true},
+		{description: "Back to pending", status: models.CommentPending, actor: edith, expectedErr: apperrors.ErrValidation},
+		{description: "Author", status: models.CommentApproved, actor: grace, expectedErr: apperrors.ErrForbidden},
+		{description: "Anonymous", status: models.CommentApproved, expectedErr: apperrors.ErrUnauthorized},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewCommentService(mockDB, fixedScorer(0), DefaultHoldThreshold)
+
+			if testCase.expectSave {
+				mockDB.EXPECT().SetCommentStatus(4, testCase.status, "edith").Return(&models.Comment{ID: 4, Status: testCase.status, ModeratedBy: "edith"}, nil)
+			}
+
+			comment, err := service.ModerateComment(4, testCase.status, testCase.actor)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.status, comment.Status)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package comments
+
+import (
+	"backend/pkg/models"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultHoldThreshold is the spam score at or above which edited comments
+// are held for moderation again.
+const DefaultHoldThreshold = 0.5
+
+// Reasons a comment looks like spam
+const (
+	ReasonLinks        = "links"
+	ReasonBlockedWords = "blocked_words"
+	ReasonRepetition   = "repetition"
+	ReasonNewAccount   = "new_account"
+)
+
+// SpamScore rates how likely a comment is spam, from 0 for surely not to 1
+// for surely, with the reasons it was rated so.
+type SpamScore struct {
+	Score   float64
+	Reasons []string
+}
+
+// SpamScorer rates new and edited comments for moderators. Edits scoring at
+// or above the hold threshold of the CommentService wait for a moderator
+// again.
+type SpamScorer interface {
+	Score(comment models.Comment, author models.User) SpamScore
+}
+
+// HeuristicScorer is the built in SpamScorer. It adds up the signs of spam
+// it finds: many links, blocked words, repetitive text and an author who
+// registered just now.
+type HeuristicScorer struct {
+	// FreeLinks is the number of links a comment can have before they count
+	FreeLinks int
+	// LinkWeight is added for every link above FreeLinks
+	LinkWeight float64
+	// BlockedWords are matched as whole words, ignoring case
+	BlockedWords []string
+	// BlockedWeight is added when any blocked word is found
+	BlockedWeight float64
+	// RepetitionWeight is added for text repeating the same few words or
+	// the same character over and over
+	RepetitionWeight float64
+	// NewAccountAge is how long after registering an account counts as new
+	NewAccountAge time.Duration
+	// NewAccountWeight is added for comments by new accounts
+	NewAccountWeight float64
+	// Now returns the current time; time.Now when nil
+	Now func() time.Time
+}
+
+// NewHeuristicScorer returns a HeuristicScorer with the default weights
+// that blocks the given words. With the default hold threshold, a blocked
+// word or a new account posting links is held, a single sign is not.
+func NewHeuristicScorer(blockedWords []string) *HeuristicScorer {
+	return &HeuristicScorer{
+		FreeLinks:        1,
+		LinkWeight:       0.2,
+		BlockedWords:     blockedWords,
+		BlockedWeight:    0.6,
+		RepetitionWeight: 0.4,
+		NewAccountAge:    24 * time.Hour,
+		NewAccountWeight: 0.3,
+	}
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(https?://|www\.)\S+`)
+
+// minRun is how many times a character repeated in a row makes text
+// repetitive
+const minRun = 10
+
+// Score rates a comment.
+func (h *HeuristicScorer) Score(comment models.Comment, author models.User) SpamScore {
+	var result SpamScore
+	add := func(weight float64, reason string) {
+		result.Score += weight
+		result.Reasons = append(result.Reasons, reason)
+	}
+
+	if links := len(linkPattern.FindAllString(comment.Body, -1)); links > h.FreeLinks {
+		add(float64(links-h.FreeLinks)*h.LinkWeight, ReasonLinks)
+	}
+	if h.blocked(comment.Body) {
+		add(h.BlockedWeight, ReasonBlockedWords)
+	}
+	if repetitive(comment.Body) {
+		add(h.RepetitionWeight, ReasonRepetition)
+	}
+	if author.CreatedAt != nil && h.now().Sub(*author.CreatedAt) < h.NewAccountAge {
+		add(h.NewAccountWeight, ReasonNewAccount)
+	}
+
+	result.Score = math.Min(result.Score, 1)
+	return result
+}
+
+func (h *HeuristicScorer) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
+
+// blocked reports whether body contains a blocked word.
+func (h *HeuristicScorer) blocked(body string) bool {
+	if len(h.BlockedWords) == 0 {
+		return false
+	}
+	blocked := map[string]bool{}
+	for _, word := range h.BlockedWords {
+		blocked[strings.ToLower(word)] = true
+	}
+	for _, word := range words(body) {
+		if blocked[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// repetitive reports whether body is mostly the same few words, or has a
+// character repeated over and over.
+func repetitive(body string) bool {
+	if repeatedRun(body) {
+		return true
+	}
+
+	all := words(body)
+	if len(all) < 10 {
+		return false
+	}
+	distinct := map[string]bool{}
+	for _, word := range all {
+		distinct[word] = true
+	}
+	return float64(len(distinct))/float64(len(all)) < 0.3
+}
+
+// repeatedRun reports whether a character other than a space is repeated
+// minRun times or more in a row.
+func repeatedRun(body string) bool {
+	var last rune
+	run := 0
+	for _, r := range body {
+		if r == last && !unicode.IsSpace(r) {
+			run++
+			if run >= minRun {
+				return true
+			}
+			continue
+		}
+		last, run = r, 1
+	}
+	return false
+}
+
+// words splits text into lower case words.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
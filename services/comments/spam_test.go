@@ -0,0 +1,87 @@
+package comments
+
+import (
+	"backend/pkg/models"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeuristicScorer(t *testing.T) {
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	longAgo := now.AddDate(-1, 0, 0)
+	justNow := now.Add(-time.Hour)
+
+	scorer := NewHeuristicScorer([]string{"casino", "Pills"})
+	scorer.Now = func() time.Time { return now }
+
+	testCases := []struct {
+		description     string
+		body            string
+		registered      time.Time
+		expectedReasons []string
+		held            bool
+	}{
+		{
+			description: "Ordinary comment",
+			body:        "Thanks, the part about indexes helped me. See https://example.com/docs for more.",
+			registered:  longAgo,
+		},
+		{
+			description:     "Many links",
+			body:            "http://a.example http://b.example www.c.example https://d.example",
+			registered:      longAgo,
+			expectedReasons: []string{ReasonLinks},
+			held:            true,
+		},
+		{
+			description:     "Blocked word in any case",
+			body:            "Best CASINO in town",
+			registered:      longAgo,
+			expectedReasons: []string{ReasonBlockedWords},
+			held:            true,
+		},
+		{
+			description: "Blocked word inside another word",
+			body:        "Casinos are a topic for another article",
+			registered:  longAgo,
+		},
+		{
+			description:     "Repeated words",
+			body:            strings.Repeat("buy now ", 8),
+			registered:      longAgo,
+			expectedReasons: []string{ReasonRepetition},
+		},
+		{
+			description:     "Repeated character",
+			body:            "Great post!!!!!!!!!!!!",
+			registered:      longAgo,
+			expectedReasons: []string{ReasonRepetition},
+		},
+		{
+			description:     "New account",
+			body:            "Hello there",
+			registered:      justNow,
+			expectedReasons: []string{ReasonNewAccount},
+		},
+		{
+			description:     "New account with links",
+			body:            "Look at http://a.example and http://b.example",
+			registered:      justNow,
+			expectedReasons: []string{ReasonLinks, ReasonNewAccount},
+			held:            true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			result := scorer.Score(models.Comment{Body: testCase.body}, models.User{CreatedAt: &testCase.registered})
+
+			assert.Equal(t, testCase.expectedReasons, result.Reasons)
+			assert.Equal(t, testCase.held, result.Score >= DefaultHoldThreshold, "score %v", result.Score)
+			assert.LessOrEqual(t, result.Score, 1.0)
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package media
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/imaging"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"backend/pkg/repository/dbrepo"
+	"backend/pkg/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxFilenameLength is the longest file name kept, in characters
+const maxFilenameLength = 255
+
+type MediaServices interface {
+	UploadMedia(file io.Reader, filename string, actor models.Principal) (*models.Media, bool, error)
+	OpenMedia(id int) (*models.Media, io.ReadSeekCloser, error)
+	OpenVariant(id, width int) (*models.MediaVariant, io.ReadSeekCloser, error)
+	ProcessPendingMedia() (int, error)
+	MaxSize() int64
+}
+
+type MediaService struct {
+	repo    dbrepo.DatabaseRepo
+	store   storage.BlobStore
+	maxSize int64
+	widths  []int
+	// pending is signalled when an image is uploaded, so a Processor makes
+	// its variants without waiting for the next tick
+	pending chan struct{}
+}
+
+// NewMediaService returns a service keeping uploads of at most maxSize
+// bytes in store. Images are resized to each of widths that is narrower
+// than they are.
+func NewMediaService(repo dbrepo.DatabaseRepo, store storage.BlobStore, maxSize int64, widths []int) *MediaService {
+	widths = append([]int(nil), widths...)
+	sort.Ints(widths)
+	return &MediaService{
+		repo:    repo,
+		store:   store,
+		maxSize: maxSize,
+		widths:  widths,
+		pending: make(chan struct{}, 1),
+	}
+}
+
+// MaxSize returns the size of the largest file that can be uploaded.
+func (s *MediaService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// UploadMedia stores the file read from file. The file is spooled to disk
+// while its checksum is taken, so a file that is too large is never stored,
+// and its content type is sniffed from its first bytes. Images are stored
+// and checksummed without their metadata. A file that was uploaded before
+// is not stored again: the earlier upload is returned and created is false.
+func (s *MediaService) UploadMedia(file io.Reader, filename string, actor models.Principal) (media *models.Media, created bool, err error) {
+	if err := policy.Authorize(actor, policy.UploadMedia, nil); err != nil {
+		return nil, false, err
+	}
+
+	spool, err := os.CreateTemp("", "media-*")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hash), io.LimitReader(file, s.maxSize+1))
+	if err != nil {
+		return nil, false, err
+	}
+	if size > s.maxSize {
+		return nil, false, apperrors.TooLarge(fmt.Sprintf(appconst.Mediatoolarge, s.maxSize), nil)
+	}
+	if size == 0 {
+		return nil, false, apperrors.Validation(appconst.Mediaempty, nil)
+	}
+
+	contentType, err := sniff(spool)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+
+	// Images are stored without their metadata, so originals served as
+	// they are do not give away where they were taken
+	var body io.Reader = spool
+	if models.IsImage(contentType) {
+		data, err := io.ReadAll(spool)
+		if err != nil {
+			return nil, false, err
+		}
+		if data, err = imaging.StripMetadata(data, contentType); err != nil {
+			return nil, false, apperrors.Validation(appconst.Mediamalformed, err)
+		}
+		hash.Reset()
+		hash.Write(data)
+		size = int64(len(data))
+		body = bytes.NewReader(data)
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	existing, err := s.repo.MediaByChecksum(checksum)
+	if err == nil {
+		return withURL(existing), false, nil
+	}
+	if !errors.Is(err, apperrors.ErrNotFound) {
+		return nil, false, err
+	}
+
+	media = &models.Media{
+		Checksum:    checksum,
+		ContentType: contentType,
+		Size:        size,
+		Filename:    cleanFilename(filename),
+		StorageKey:  checksum[:2] + "/" + checksum,
+		Status:      models.MediaReady,
+	}
+	if models.IsImage(contentType) {
+		media.Status = models.MediaPending
+	}
+	if actor.UserID != 0 {
+		media.UploadedBy = &actor.UserID
+	}
+
+	if err := s.store.Put(context.Background(), media.StorageKey, body, size, contentType); err != nil {
+		log.Println(appconst.Mediaerror, err)
+		return nil, false, apperrors.Unavailable(appconst.Unavailableerror, err)
+	}
+
+	// The same file uploaded at the same time is stored once; whoever comes
+	// second gets the upload of the first
+	if err := s.repo.CreateMedia(media); err != nil {
+		if !errors.Is(err, apperrors.ErrConflict) {
+			return nil, false, err
+		}
+		existing, err := s.repo.MediaByChecksum(checksum)
+		if err != nil {
+			return nil, false, err
+		}
+		return withURL(existing), false, nil
+	}
+
+	if media.Status == models.MediaPending {
+		select {
+		case s.pending <- struct{}{}:
+		default:
+		}
+	}
+	return withURL(media), true, nil
+}
+
+// OpenMedia returns a media file with its bytes, which the caller has to
+// close.
+func (s *MediaService) OpenMedia(id int) (*models.Media, io.ReadSeekCloser, error) {
+	media, err := s.repo.OneMedia(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	blob, err := s.open(media.StorageKey, appconst.Nomedia)
+	if err != nil {
+		return nil, nil, err
+	}
+	return withURL(media), blob, nil
+}
+
+// OpenVariant returns the variant of an image with a width with its bytes,
+// which the caller has to close.
+func (s *MediaService) OpenVariant(id, width int) (*models.MediaVariant, io.ReadSeekCloser, error) {
+	variant, err := s.repo.MediaVariant(id, width)
+	if err != nil {
+		return nil, nil, err
+	}
+	blob, err := s.open(variant.StorageKey, appconst.Novariant)
+	if err != nil {
+		return nil, nil, err
+	}
+	return variant, blob, nil
+}
+
+// open opens a stored file, which is not found with notFoundMsg when the
+// store has lost it.
+func (s *MediaService) open(key, notFoundMsg string) (io.ReadSeekCloser, error) {
+	blob, err := s.store.Open(context.Background(), key)
+	if errors.Is(err, fs.ErrNotExist) {
+		log.Println(appconst.Mediaunavailable, err)
+		return nil, apperrors.NotFound(notFoundMsg, err)
+	}
+	if err != nil {
+		log.Println(appconst.Mediaunavailable, err)
+		return nil, apperrors.Unavailable(appconst.Unavailableerror, err)
+	}
+	return blob, nil
+}
+
+// sniff returns the content type of the file from its first bytes, which
+// has to be one of models.MediaTypes.
+func sniff(file io.ReaderAt) (string, error) {
+	head := make([]byte, 512)
+	n, err := file.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	for _, allowed := range models.MediaTypes {
+		if contentType == allowed {
+			return contentType, nil
+		}
+	}
+	return "", apperrors.Unsupported(fmt.Sprintf(appconst.Mediatype, strings.Join(models.MediaTypes, ", ")), nil)
+}
+
+// cleanFilename keeps the last element of a file name a client sent,
+// without control characters.
+func cleanFilename(filename string) string {
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, filename)
+	if utf8.RuneCountInString(filename) > maxFilenameLength {
+		filename = string([]rune(filename)[:maxFilenameLength])
+	}
+	return strings.TrimSpace(filename)
+}
+
+func withURL(media *models.Media) *models.Media {
+	media.URL = models.MediaURL(media.ID)
+	return media
+}
@@ -0,0 +1,220 @@
+package media
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var ada = models.Principal{UserID: 1, Username: "ada", Role: models.RoleAuthor}
+
+// png is the start of a PNG file, enough for its type to be sniffed
+var png = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 24)...)
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func newStore(t *testing.T) *storage.LocalStore {
+	store, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	return store
+}
+
+func newService(t *testing.T, mockDB *mocks.MockDBInterface, maxSize int64) (*MediaService, *storage.LocalStore) {
+	store := newStore(t)
+	return NewMediaService(mockDB, store, maxSize, nil), store
+}
+
+func TestMediaService_UploadMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service, store := newService(t, mockDB, 64)
+	sum := checksum(png)
+
+	mockDB.EXPECT().MediaByChecksum(sum).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows))
+	mockDB.EXPECT().CreateMedia(gomock.Any()).DoAndReturn(func(media *models.Media) error {
+		assert.Equal(t, &models.Media{
+			Checksum:    sum,
+			ContentType: "image/png",
+			Size:        int64(len(png)),
+			Filename:    "dot.png",
+			StorageKey:  sum[:2] + "/" + sum,
+			Status:      models.MediaPending,
+			UploadedBy:  &ada.UserID,
+		}, media)
+		media.ID = 3
+		return nil
+	})
+
+	media, created, err := service.UploadMedia(bytes.NewReader(png), `C:\Users\ada\dot.png`, ada)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "/media/3", media.URL)
+
+	blob, err := store.Open(context.Background(), media.StorageKey)
+	assert.NoError(t, err)
+	stored, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, png, stored)
+
+	// The same file again is the same media
+	mockDB.EXPECT().MediaByChecksum(sum).Return(&models.Media{ID: 3, Checksum: sum}, nil)
+	media, created, err = service.UploadMedia(bytes.NewReader(png), "again.png", ada)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 3, media.ID)
+	assert.Equal(t, "/media/3", media.URL)
+}
+
+func TestMediaService_UploadMediaStripsMetadata(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service, store := newService(t, mockDB, 64)
+	sum := checksum(png)
+
+	// The image is stored and checksummed without its text chunk
+	tagged := append(append([]byte{}, png...), "\x00\x00\x00\x07tEXtGPS\x0051N\x00\x00\x00\x00"...)
+	mockDB.EXPECT().MediaByChecksum(sum).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows))
+	mockDB.EXPECT().CreateMedia(gomock.Any()).DoAndReturn(func(media *models.Media) error {
+		assert.Equal(t, int64(len(png)), media.Size)
+		media.ID = 3
+		return nil
+	})
+
+	media, created, err := service.UploadMedia(bytes.NewReader(tagged), "dot.png", ada)
+	assert.NoError(t, err)
+	assert.True(t, created)
+
+	blob, err := store.Open(context.Background(), media.StorageKey)
+	assert.NoError(t, err)
+	stored, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, png, stored)
+}
+
+func TestMediaService_UploadMediaConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service, _ := newService(t, mockDB, 64)
+	sum := checksum(png)
+
+	// Someone else stored the file between the lookup and the insert
+	gomock.InOrder(
+		mockDB.EXPECT().MediaByChecksum(sum).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows)),
+		mockDB.EXPECT().CreateMedia(gomock.Any()).Return(apperrors.Conflict(appconst.Conflicterror, nil)),
+		mockDB.EXPECT().MediaByChecksum(sum).Return(&models.Media{ID: 4, Checksum: sum}, nil),
+	)
+
+	media, created, err := service.UploadMedia(bytes.NewReader(png), "dot.png", ada)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 4, media.ID)
+}
+
+func TestMediaService_UploadMediaRejected(t *testing.T) {
+	testCases := []struct {
+		name  string
+		actor models.Principal
+		file  []byte
+		kind  error
+	}{
+		{name: "Anonymous", file: png, kind: apperrors.ErrUnauthorized},
+		{name: "Too large", actor: ada, file: append(png, make([]byte, 64)...), kind: apperrors.ErrTooLarge},
+		{name: "Empty", actor: ada, kind: apperrors.ErrValidation},
+		{name: "Text", actor: ada, file: []byte("<script>alert(1)</script>"), kind: apperrors.ErrUnsupported},
+		{name: "Named like an image", actor: ada, file: []byte("GIF8 is not enough"), kind: apperrors.ErrUnsupported},
+		{name: "Malformed image", actor: ada, file: append(append([]byte{}, png...), 0, 0, 0, 9), kind: apperrors.ErrValidation},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			service, _ := newService(t, mocks.NewMockDBInterface(ctrl), 64)
+
+			_, _, err := service.UploadMedia(bytes.NewReader(tc.file), "dot.gif", tc.actor)
+			assert.ErrorIs(t, err, tc.kind)
+		})
+	}
+}
+
+func TestMediaService_OpenMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service, store := newService(t, mockDB, 64)
+	assert.NoError(t, store.Put(context.Background(), "ab/abc", bytes.NewReader(png), int64(len(png)), "image/png"))
+
+	mockDB.EXPECT().OneMedia(3).Return(&models.Media{ID: 3, StorageKey: "ab/abc"}, nil)
+	media, blob, err := service.OpenMedia(3)
+	assert.NoError(t, err)
+	assert.Equal(t, "/media/3", media.URL)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, png, data)
+
+	// Metadata whose blob is gone is not found
+	mockDB.EXPECT().OneMedia(4).Return(&models.Media{ID: 4, StorageKey: "cd/cde"}, nil)
+	_, _, err = service.OpenMedia(4)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	mockDB.EXPECT().OneMedia(5).Return(nil, apperrors.NotFound(appconst.Nomedia, sql.ErrNoRows))
+	_, _, err = service.OpenMedia(5)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestCleanFilename(t *testing.T) {
+	tests := map[string]string{
+		"dot.png":                "dot.png",
+		"../../etc/passwd":       "passwd",
+		`C:\Users\ada\photo.jpg`: "photo.jpg",
+		"line\nbreak.gif":        "linebreak.gif",
+		" spaced .png ":          "spaced .png",
+		strings.Repeat("é", 300): strings.Repeat("é", maxFilenameLength),
+	}
+	for filename, expected := range tests {
+		assert.Equal(t, expected, cleanFilename(filename), filename)
+	}
+}
+
+func TestMediaService_OpenVariant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service, store := newService(t, mockDB, 64)
+	assert.NoError(t, store.Put(context.Background(), "ab/abc-320", bytes.NewReader(png), int64(len(png)), "image/png"))
+
+	mockDB.EXPECT().MediaVariant(3, 320).Return(&models.MediaVariant{MediaID: 3, Width: 320, StorageKey: "ab/abc-320"}, nil)
+	variant, blob, err := service.OpenVariant(3, 320)
+	assert.NoError(t, err)
+	assert.Equal(t, 320, variant.Width)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, png, data)
+
+	mockDB.EXPECT().MediaVariant(3, 640).Return(&models.MediaVariant{MediaID: 3, Width: 640, StorageKey: "ab/abc-640"}, nil)
+	_, _, err = service.OpenVariant(3, 640)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	mockDB.EXPECT().MediaVariant(3, 1).Return(nil, apperrors.NotFound(appconst.Novariant, sql.ErrNoRows))
+	_, _, err = service.OpenVariant(3, 1)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
@@ -0,0 +1,181 @@
+package media
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/imaging"
+	"backend/pkg/models"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"sync"
+	"time"
+)
+
+// processBatch is the number of pending images claimed per statement
+const processBatch = 10
+
+// ProcessPendingMedia makes the variants and placeholder of every image
+// waiting for them and returns how many were processed. An image that
+// cannot be decoded is marked failed. One that fails for another reason,
+// such as the store being down, stays claimed until the claim goes stale
+// and is then retried.
+func (s *MediaService) ProcessPendingMedia() (int, error) {
+	processed := 0
+	for {
+		batch, err := s.repo.ClaimPendingMedia(processBatch)
+		if err != nil {
+			return processed, err
+		}
+		for i := range batch {
+			if err := s.processImage(&batch[i]); err != nil {
+				log.Println(appconst.Processingerror, batch[i].ID, err)
+				continue
+			}
+			log.Println(appconst.Mediaprocessed, batch[i].ID, batch[i].Filename)
+			processed++
+		}
+		if len(batch) < processBatch {
+			return processed, nil
+		}
+	}
+}
+
+// processImage decodes an image, stores a variant of it for each width and
+// saves them with its size and placeholder. Variants are encoded from the
+// decoded pixels, so none of them carry the EXIF data of the upload.
+func (s *MediaService) processImage(media *models.Media) error {
+	ctx := context.Background()
+	data, err := s.read(ctx, media.StorageKey)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s.fail(media, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return s.fail(media, err)
+	}
+	bounds := img.Bounds()
+	media.Width, media.Height = bounds.Dx(), bounds.Dy()
+	media.Placeholder = imaging.Blurhash(img)
+
+	var variants []models.MediaVariant
+	for _, width := range variantWidths(s.widths, media.Width) {
+		resized := img
+		if width != media.Width {
+			resized = imaging.Resize(img, width)
+		}
+		var buf bytes.Buffer
+		contentType, err := imaging.Encode(&buf, resized)
+		if err != nil {
+			return err
+		}
+		variant := models.MediaVariant{
+			MediaID:     media.ID,
+			Width:       width,
+			Height:      resized.Bounds().Dy(),
+			ContentType: contentType,
+			Size:        int64(buf.Len()),
+			StorageKey:  fmt.Sprintf("%s-%d", media.StorageKey, width),
+		}
+		if err := s.store.Put(ctx, variant.StorageKey, &buf, variant.Size, contentType); err != nil {
+			return err
+		}
+		variants = append(variants, variant)
+	}
+
+	return s.repo.SaveMediaVariants(media, variants)
+}
+
+func (s *MediaService) read(ctx context.Context, key string) ([]byte, error) {
+	blob, err := s.store.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	return io.ReadAll(io.LimitReader(blob, s.maxSize+1))
+}
+
+// fail marks an image that will never be processed and returns why.
+func (s *MediaService) fail(media *models.Media, cause error) error {
+	if err := s.repo.SetMediaStatus(media.ID, models.MediaFailed); err != nil {
+		return err
+	}
+	return cause
+}
+
+// variantWidths returns the widths an image of a width is resized to:
+// every configured width narrower than the image, and the image's own
+// width in place of the wider ones, so an image is never scaled up.
+func variantWidths(widths []int, original int) []int {
+	var result []int
+	for _, width := range widths {
+		if width >= original {
+			return append(result, original)
+		}
+		result = append(result, width)
+	}
+	return result
+}
+
+// Processor makes the variants of uploaded images in the background, on
+// every tick and as soon as an image is uploaded. Like the scheduler, every
+// replica can run one: pending images are claimed with row locks the other
+// replicas skip.
+type Processor struct {
+	service  *MediaService
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewProcessor returns a processor checking for pending images every
+// interval.
+func NewProcessor(service *MediaService, interval time.Duration) *Processor {
+	return &Processor{
+		service:  service,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the processor in a goroutine until Stop is called. Pending
+// images are processed right away and then on every tick or upload.
+func (p *Processor) Start() {
+	go p.run()
+}
+
+// Stop asks the processor to stop and waits until a run in progress has
+// finished. It must only be called after Start.
+func (p *Processor) Stop() {
+	p.once.Do(func() { close(p.stop) })
+	<-p.done
+}
+
+func (p *Processor) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.service.ProcessPendingMedia(); err != nil {
+			log.Println(appconst.Processingerror, err)
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		case <-p.service.pending:
+		}
+	}
+}
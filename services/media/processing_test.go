@@ -0,0 +1,122 @@
+package media
+
+import (
+	"backend/mocks"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	stdpng "image/png"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// encodePNG returns an opaque PNG image of a size.
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, stdpng.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestMediaService_ProcessPendingMedia(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	store := newStore(t)
+	service := NewMediaService(mockDB, store, 1<<20, []int{640, 160, 320})
+
+	data := encodePNG(t, 400, 200)
+	assert.NoError(t, store.Put(context.Background(), "ab/abc", bytes.NewReader(data), int64(len(data)), "image/png"))
+	assert.NoError(t, store.Put(context.Background(), "cd/cde", bytes.NewReader(png), int64(len(png)), "image/png"))
+
+	gomock.InOrder(
+		mockDB.EXPECT().ClaimPendingMedia(processBatch).Return([]models.Media{
+			{ID: 1, StorageKey: "ab/abc", ContentType: "image/png", Status: models.MediaProcessing},
+			// Only the start of a PNG, which does not decode
+			{ID: 2, StorageKey: "cd/cde", ContentType: "image/png", Status: models.MediaProcessing},
+		}, nil),
+		mockDB.EXPECT().SaveMediaVariants(gomock.Any(), gomock.Any()).DoAndReturn(func(media *models.Media, variants []models.MediaVariant) error {
+			assert.Equal(t, 1, media.ID)
+			assert.Equal(t, 400, media.Width)
+			assert.Equal(t, 200, media.Height)
+			assert.Len(t, media.Placeholder, 28)
+
+			// The image is not scaled up past its own width
+			assert.Len(t, variants, 3)
+			for i, width := range []int{160, 320, 400} {
+				assert.Equal(t, width, variants[i].Width)
+				assert.Equal(t, width/2, variants[i].Height)
+				assert.Equal(t, "image/jpeg", variants[i].ContentType)
+
+				blob, err := store.Open(context.Background(), variants[i].StorageKey)
+				assert.NoError(t, err)
+				stored, _ := io.ReadAll(blob)
+				blob.Close()
+				assert.Equal(t, variants[i].Size, int64(len(stored)))
+				config, format, err := image.DecodeConfig(bytes.NewReader(stored))
+				assert.NoError(t, err)
+				assert.Equal(t, "jpeg", format)
+				assert.Equal(t, width, config.Width)
+			}
+			return nil
+		}),
+		mockDB.EXPECT().SetMediaStatus(2, models.MediaFailed).Return(nil),
+	)
+
+	processed, err := service.ProcessPendingMedia()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	mockDB.EXPECT().ClaimPendingMedia(processBatch).Return(nil, apperrors.Unavailable("down", nil))
+	_, err = service.ProcessPendingMedia()
+	assert.ErrorIs(t, err, apperrors.ErrUnavailable)
+}
+
+func TestVariantWidths(t *testing.T) {
+	widths := []int{320, 640, 1024}
+	assert.Equal(t, []int{320, 640, 1024}, variantWidths(widths, 2000))
+	assert.Equal(t, []int{320, 640, 800}, variantWidths(widths, 800))
+	assert.Equal(t, []int{320, 640}, variantWidths(widths, 640))
+	assert.Equal(t, []int{100}, variantWidths(widths, 100))
+	assert.Empty(t, variantWidths(nil, 100))
+}
+
+func TestProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewMediaService(mockDB, newStore(t), 64, nil)
+
+	runs := make(chan struct{}, 10)
+	mockDB.EXPECT().ClaimPendingMedia(processBatch).DoAndReturn(func(int) ([]models.Media, error) {
+		runs <- struct{}{}
+		return nil, nil
+	}).MinTimes(2)
+
+	// The interval is too long to tick during the test, so the second run
+	// comes from the upload
+	processor := NewProcessor(service, time.Hour)
+	processor.Start()
+	<-runs
+
+	mockDB.EXPECT().MediaByChecksum(checksum(png)).Return(nil, apperrors.NotFound("", nil))
+	mockDB.EXPECT().CreateMedia(gomock.Any()).Return(nil)
+	_, _, err := service.UploadMedia(bytes.NewReader(png), "dot.png", ada)
+	assert.NoError(t, err)
+	<-runs
+
+	processor.Stop()
+	processor.Stop()
+}
@@ -0,0 +1,348 @@
+package taxonomy
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"backend/pkg/repository/dbrepo"
+	"backend/pkg/utility"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type TaxonomyServices interface {
+	GetTags(params models.ListParams) (*models.TagPage, error)
+	GetTag(slug string) (*models.Tag, error)
+	GetTagArticles(slug string, params models.ListParams) (*models.ArticlePage, error)
+	RenameTag(slug string, request models.TagRequest, actor models.Principal) (*models.Tag, error)
+	MergeTag(slug string, request models.TagMergeRequest, actor models.Principal) (*models.Tag, error)
+	GetCategories() ([]models.Category, error)
+	GetCategoryArticles(slug string, params models.ListParams) (*models.ArticlePage, error)
+	CreateCategory(request models.CategoryRequest, actor models.Principal) (*models.Category, error)
+	UpdateCategory(slug string, request models.CategoryRequest, actor models.Principal) (*models.Category, error)
+	DeleteCategory(slug string, actor models.Principal) error
+}
+
+type TaxonomyService struct {
+	repo dbrepo.DatabaseRepo
+}
+
+func NewTaxonomyService(repo dbrepo.DatabaseRepo) *TaxonomyService {
+	return &TaxonomyService{
+		repo: repo,
+	}
+}
+
+// GetTags returns a page of tags sorted by name, each with its number of
+// published articles.
+func (s *TaxonomyService) GetTags(params models.ListParams) (*models.TagPage, error) {
+	params.Normalize()
+	return s.repo.Tags(params)
+}
+
+// GetTag returns the tag with a slug.
+func (s *TaxonomyService) GetTag(slug string) (*models.Tag, error) {
+	return s.repo.OneTag(slug)
+}
+
+// GetTagArticles returns a page of the articles with a tag that
+// params.Viewer may see, paged and sorted like the article listing.
+func (s *TaxonomyService) GetTagArticles(slug string, params models.ListParams) (*models.ArticlePage, error) {
+	if _, err := s.repo.OneTag(slug); err != nil {
+		return nil, err
+	}
+	params.Filter.Tag = slug
+	return s.articles(params)
+}
+
+// RenameTag gives a tag a new name, and the slug that goes with it. A tag
+// cannot take the slug of another tag; the two have to be merged instead.
+func (s *TaxonomyService) RenameTag(slug string, request models.TagRequest, actor models.Principal) (*models.Tag, error) {
+	if err := policy.Authorize(actor, policy.ManageTaxonomy, nil); err != nil {
+		return nil, err
+	}
+	name, newSlug, err := CleanName(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := s.repo.OneTag(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if newSlug != tag.Slug {
+		_, err := s.repo.OneTag(newSlug)
+		if err == nil {
+			return nil, apperrors.Conflict(fmt.Sprintf(appconst.Tagexists, newSlug), nil)
+		}
+		if !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	tag.Name, tag.Slug = name, newSlug
+	if err := s.repo.RenameTag(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// MergeTag moves the articles of a tag to the tag named in the request and
+// deletes the first tag. It returns the tag that was kept.
+func (s *TaxonomyService) MergeTag(slug string, request models.TagMergeRequest, actor models.Principal) (*models.Tag, error) {
+	if err := policy.Authorize(actor, policy.ManageTaxonomy, nil); err != nil {
+		return nil, err
+	}
+	from, err := s.repo.OneTag(slug)
+	if err != nil {
+		return nil, err
+	}
+	if request.Into == from.Slug {
+		return nil, apperrors.Validation(appconst.Mergetarget, nil)
+	}
+	into, err := s.repo.OneTag(request.Into)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, apperrors.Validation(appconst.Mergetarget, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.MergeTags(from.ID, into.ID); err != nil {
+		return nil, err
+	}
+	return s.repo.OneTag(into.Slug)
+}
+
+// GetCategories returns the category tree: the top level categories sorted
+// by name, each with the categories below it.
+func (s *TaxonomyService) GetCategories() ([]models.Category, error) {
+	categories, err := s.repo.Categories()
+	if err != nil {
+		return nil, err
+	}
+	return tree(categories), nil
+}
+
+// GetCategoryArticles returns a page of the articles in a category or the
+// categories below it that params.Viewer may see, paged and sorted like the
+// article listing.
+func (s *TaxonomyService) GetCategoryArticles(slug string, params models.ListParams) (*models.ArticlePage, error) {
+	if _, err := s.repo.OneCategory(slug); err != nil {
+		return nil, err
+	}
+	params.Filter.Category = slug
+	return s.articles(params)
+}
+
+// CreateCategory adds a category, at the top level or below the parent in
+// the request.
+func (s *TaxonomyService) CreateCategory(request models.CategoryRequest, actor models.Principal) (*models.Category, error) {
+	if err := policy.Authorize(actor, policy.ManageTaxonomy, nil); err != nil {
+		return nil, err
+	}
+	name, slug, err := CleanName(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.Categories()
+	if err != nil {
+		return nil, err
+	}
+	if find(categories, slug) != nil {
+		return nil, apperrors.Conflict(fmt.Sprintf(appconst.Categoryexists, slug), nil)
+	}
+	if request.ParentID != nil && findID(categories, *request.ParentID) == nil {
+		return nil, apperrors.Validation(appconst.Noparentcategory, nil)
+	}
+
+	category := &models.Category{ParentID: request.ParentID, Name: name, Slug: slug}
+	if err := s.repo.CreateCategory(category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// UpdateCategory renames a category, or moves it with the categories below
+// it under another parent. A category cannot be moved below itself.
+func (s *TaxonomyService) UpdateCategory(slug string, request models.CategoryRequest, actor models.Principal) (*models.Category, error) {
+	if err := policy.Authorize(actor, policy.ManageTaxonomy, nil); err != nil {
+		return nil, err
+	}
+	name, newSlug, err := CleanName(request.Name)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.Categories()
+	if err != nil {
+		return nil, err
+	}
+	category := find(categories, slug)
+	if category == nil {
+		return nil, apperrors.NotFound(appconst.Nocategory, nil)
+	}
+	if newSlug != slug && find(categories, newSlug) != nil {
+		return nil, apperrors.Conflict(fmt.Sprintf(appconst.Categoryexists, newSlug), nil)
+	}
+	if request.ParentID != nil {
+		if findID(categories, *request.ParentID) == nil {
+			return nil, apperrors.Validation(appconst.Noparentcategory, nil)
+		}
+		if below(categories, *request.ParentID, category.ID) {
+			return nil, apperrors.Validation(appconst.Categorycycle, nil)
+		}
+	}
+
+	updated := *category
+	updated.ParentID, updated.Name, updated.Slug = request.ParentID, name, newSlug
+	if err := s.repo.UpdateCategory(&updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteCategory deletes a category once no categories are below it. Its
+// articles stay, without the category.
+func (s *TaxonomyService) DeleteCategory(slug string, actor models.Principal) error {
+	if err := policy.Authorize(actor, policy.ManageTaxonomy, nil); err != nil {
+		return err
+	}
+	categories, err := s.repo.Categories()
+	if err != nil {
+		return err
+	}
+	category := find(categories, slug)
+	if category == nil {
+		return apperrors.NotFound(appconst.Nocategory, nil)
+	}
+	for _, child := range categories {
+		if child.ParentID != nil && *child.ParentID == category.ID {
+			return apperrors.Conflict(appconst.Categorychildren, nil)
+		}
+	}
+	return s.repo.DeleteCategory(category.ID)
+}
+
+// articles reads a page of the article listing, as GetAllArticles does.
+func (s *TaxonomyService) articles(params models.ListParams) (*models.ArticlePage, error) {
+	params.Normalize()
+	params.AllStatuses = policy.Can(params.Viewer, policy.ViewAllArticles, nil)
+	return s.repo.AllArticles(params)
+}
+
+// CleanName collapses the spaces in the name of a tag or category and
+// returns it with its slug, or an error if it is too long or has no letter
+// or digit to make a slug from.
+func CleanName(name string) (string, string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	slug := utility.Slugify(name)
+	if slug == "" || utf8.RuneCountInString(name) > models.MaxTagLength {
+		return "", "", apperrors.Validation(fmt.Sprintf(appconst.Invalidname, models.MaxTagLength), nil)
+	}
+	return name, slug, nil
+}
+
+// CleanTags cleans the tag names of an article, dropping the names that
+// are the same tag as an earlier one.
+func CleanTags(names []string) ([]string, error) {
+	var tags []string
+	seen := map[string]bool{}
+	for _, name := range names {
+		name, slug, err := CleanName(name)
+		if err != nil {
+			return nil, err
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, name)
+	}
+	if len(tags) > models.MaxArticleTags {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Toomanytags, models.MaxArticleTags), nil)
+	}
+	return tags, nil
+}
+
+// CleanCategories trims the category slugs of an article and drops
+// repeated ones.
+func CleanCategories(slugs []string) []string {
+	var categories []string
+	seen := map[string]bool{}
+	for _, slug := range slugs {
+		slug = strings.TrimSpace(slug)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		categories = append(categories, slug)
+	}
+	return categories
+}
+
+// tree nests categories below their parents, keeping their order.
+func tree(categories []models.Category) []models.Category {
+	children := map[int][]models.Category{}
+	var roots []models.Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		children[*category.ParentID] = append(children[*category.ParentID], category)
+	}
+
+	var nest func(level []models.Category) []models.Category
+	nest = func(level []models.Category) []models.Category {
+		for i := range level {
+			level[i].Children = nest(children[level[i].ID])
+		}
+		return level
+	}
+
+	if roots == nil {
+		return []models.Category{}
+	}
+	return nest(roots)
+}
+
+// find returns the category with a slug, or nil.
+func find(categories []models.Category, slug string) *models.Category {
+	for i := range categories {
+		if categories[i].Slug == slug {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// findID returns the category with an ID, or nil.
+func findID(categories []models.Category, id int) *models.Category {
+	for i := range categories {
+		if categories[i].ID == id {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// below reports whether the category with ID id is ancestor or one of the
+// categories below it.
+func below(categories []models.Category, id, ancestor int) bool {
+	// The walk up stops after every category was visited, in case the
+	// stored tree has a loop
+	for steps := 0; steps <= len(categories); steps++ {
+		if id == ancestor {
+			return true
+		}
+		category := findID(categories, id)
+		if category == nil || category.ParentID == nil {
+			return false
+		}
+		id = *category.ParentID
+	}
+	return true
+}
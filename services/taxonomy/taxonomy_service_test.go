@@ -0,0 +1,247 @@
+package taxonomy
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	ada   = models.Principal{UserID: 1, Username: "ada", Role: models.RoleAuthor}
+	edith = models.Principal{UserID: 3, Username: "edith", Role: models.RoleEditor}
+	root  = models.Principal{UserID: 4, Username: "root", Role: models.RoleAdmin}
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// categories is the flat category list: news with tech below it, go below
+// tech, and sport at the top level
+func categories() []models.Category {
+	return []models.Category{
+		{ID: 3, ParentID: intPtr(2), Name: "Go", Slug: "go"},
+		{ID: 1, Name: "News", Slug: "news"},
+		{ID: 4, Name: "Sport", Slug: "sport"},
+		{ID: 2, ParentID: intPtr(1), Name: "Tech", Slug: "tech"},
+	}
+}
+
+func notFound(message string) error {
+	return apperrors.NotFound(message, sql.ErrNoRows)
+}
+
+func TestTaxonomyService_GetTagArticles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().OneTag("go").Return(&models.Tag{ID: 1, Name: "Go", Slug: "go"}, nil).Times(2)
+	mockDB.EXPECT().AllArticles(gomock.Any()).DoAndReturn(func(params models.ListParams) (*models.ArticlePage, error) {
+		assert.Equal(t, "go", params.Filter.Tag)
+		assert.Equal(t, models.DefaultPageSize, params.Limit)
+		assert.Equal(t, params.Viewer.Role == models.RoleEditor, params.AllStatuses)
+		return &models.ArticlePage{Articles: []models.Article{{ID: 1, Tags: []string{"Go"}}}}, nil
+	}).Times(2)
+
+	page, err := service.GetTagArticles("go", models.ListParams{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Articles, 1)
+
+	// Editors see every status, as in the article listing
+	_, err = service.GetTagArticles("go", models.ListParams{Viewer: edith})
+	assert.NoError(t, err)
+
+	mockDB.EXPECT().OneTag("rust").Return(nil, notFound(appconst.Notag))
+	_, err = service.GetTagArticles("rust", models.ListParams{})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestTaxonomyService_RenameTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().OneTag("golang").DoAndReturn(func(string) (*models.Tag, error) {
+		return &models.Tag{ID: 1, Name: "golang", Slug: "golang"}, nil
+	}).AnyTimes()
+	mockDB.EXPECT().OneTag("go").Return(nil, notFound(appconst.Notag))
+	mockDB.EXPECT().RenameTag(&models.Tag{ID: 1, Name: "Go", Slug: "go"}).Return(nil)
+
+	tag, err := service.RenameTag("golang", models.TagRequest{Name: "  Go "}, root)
+	assert.NoError(t, err)
+	assert.Equal(t, "go", tag.Slug)
+
+	// Changing only the case keeps the slug, so there is nothing to collide with
+	mockDB.EXPECT().RenameTag(&models.Tag{ID: 1, Name: "GoLang", Slug: "golang"}).Return(nil)
+	_, err = service.RenameTag("golang", models.TagRequest{Name: "GoLang"}, root)
+	assert.NoError(t, err)
+
+	mockDB.EXPECT().OneTag("rust").Return(&models.Tag{ID: 2, Name: "Rust", Slug: "rust"}, nil)
+	_, err = service.RenameTag("golang", models.TagRequest{Name: "Rust"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+	_, err = service.RenameTag("golang", models.TagRequest{Name: "!!"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	_, err = service.RenameTag("golang", models.TagRequest{Name: "Go"}, edith)
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestTaxonomyService_MergeTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().OneTag("golang").Return(&models.Tag{ID: 1, Name: "golang", Slug: "golang"}, nil).AnyTimes()
+	mockDB.EXPECT().OneTag("go").Return(&models.Tag{ID: 2, Name: "Go", Slug: "go", Articles: 3}, nil).Times(2)
+	mockDB.EXPECT().MergeTags(1, 2).Return(nil)
+
+	tag, err := service.MergeTag("golang", models.TagMergeRequest{Into: "go"}, root)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, tag.Articles)
+
+	_, err = service.MergeTag("golang", models.TagMergeRequest{Into: "golang"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	mockDB.EXPECT().OneTag("rust").Return(nil, notFound(appconst.Notag))
+	_, err = service.MergeTag("golang", models.TagMergeRequest{Into: "rust"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	_, err = service.MergeTag("golang", models.TagMergeRequest{Into: "go"}, ada)
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestTaxonomyService_GetCategories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().Categories().Return(categories(), nil)
+	tree, err := service.GetCategories()
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "news", tree[0].Slug)
+	assert.Equal(t, "tech", tree[0].Children[0].Slug)
+	assert.Equal(t, "go", tree[0].Children[0].Children[0].Slug)
+	assert.Equal(t, "sport", tree[1].Slug)
+	assert.Empty(t, tree[1].Children)
+
+	mockDB.EXPECT().Categories().Return(nil, nil)
+	tree, err = service.GetCategories()
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Category{}, tree)
+}
+
+func TestTaxonomyService_CreateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().Categories().Return(categories(), nil).AnyTimes()
+	mockDB.EXPECT().CreateCategory(&models.Category{ParentID: intPtr(2), Name: "Rust", Slug: "rust"}).DoAndReturn(func(category *models.Category) error {
+		category.ID = 5
+		return nil
+	})
+
+	category, err := service.CreateCategory(models.CategoryRequest{Name: "Rust", ParentID: intPtr(2)}, root)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, category.ID)
+
+	_, err = service.CreateCategory(models.CategoryRequest{Name: "Tech"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+	_, err = service.CreateCategory(models.CategoryRequest{Name: "Rust", ParentID: intPtr(9)}, root)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	_, err = service.CreateCategory(models.CategoryRequest{Name: "Rust"}, edith)
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestTaxonomyService_UpdateCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().Categories().Return(categories(), nil).AnyTimes()
+
+	// Tech moves with go below it to the top level
+	mockDB.EXPECT().UpdateCategory(&models.Category{ID: 2, Name: "Technology", Slug: "technology"}).Return(nil)
+	category, err := service.UpdateCategory("tech", models.CategoryRequest{Name: "Technology"}, root)
+	assert.NoError(t, err)
+	assert.Nil(t, category.ParentID)
+
+	mockDB.EXPECT().UpdateCategory(&models.Category{ID: 2, ParentID: intPtr(4), Name: "Tech", Slug: "tech"}).Return(nil)
+	_, err = service.UpdateCategory("tech", models.CategoryRequest{Name: "Tech", ParentID: intPtr(4)}, root)
+	assert.NoError(t, err)
+
+	// A category cannot be moved below itself or the categories below it
+	for _, parent := range []int{2, 3} {
+		_, err = service.UpdateCategory("tech", models.CategoryRequest{Name: "Tech", ParentID: intPtr(parent)}, root)
+		assert.ErrorIs(t, err, apperrors.ErrValidation)
+		assert.Equal(t, appconst.Categorycycle, err.Error())
+	}
+
+	_, err = service.UpdateCategory("tech", models.CategoryRequest{Name: "Sport"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+	_, err = service.UpdateCategory("rust", models.CategoryRequest{Name: "Rust"}, root)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestTaxonomyService_DeleteCategory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewTaxonomyService(mockDB)
+
+	mockDB.EXPECT().Categories().Return(categories(), nil).AnyTimes()
+	mockDB.EXPECT().DeleteCategory(3).Return(nil)
+
+	assert.NoError(t, service.DeleteCategory("go", root))
+	assert.ErrorIs(t, service.DeleteCategory("tech", root), apperrors.ErrConflict)
+	assert.ErrorIs(t, service.DeleteCategory("rust", root), apperrors.ErrNotFound)
+	assert.ErrorIs(t, service.DeleteCategory("go", ada), apperrors.ErrForbidden)
+}
+
+func TestCleanTags(t *testing.T) {
+	tags, err := CleanTags([]string{" Go ", "web  apps", "go", "GO!"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Go", "web apps"}, tags)
+
+	tags, err = CleanTags(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, tags)
+
+	_, err = CleanTags([]string{"Go", " "})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	_, err = CleanTags([]string{strings.Repeat("a", models.MaxTagLength+1)})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	var many []string
+	for i := 0; i <= models.MaxArticleTags; i++ {
+		many = append(many, fmt.Sprint("tag", i))
+	}
+	_, err = CleanTags(many)
+	assert.EqualError(t, err, fmt.Sprintf(appconst.Toomanytags, models.MaxArticleTags))
+}
+
+func TestCleanCategories(t *testing.T) {
+	assert.Equal(t, []string{"news", "tech"}, CleanCategories([]string{" news", "tech", "news "}))
+	assert.Nil(t, CleanCategories(nil))
+}
@@ -0,0 +1,133 @@
+package users
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CreateAPIKey issues an API key acting for the actor, limited to the
+// requested scopes. The key itself is only returned here; the server keeps
+// its hash.
+func (s *UserService) CreateAPIKey(request models.APIKeyRequest, actor models.Principal) (*models.APIKey, error) {
+	if err := policy.Authorize(actor, policy.ManageAPIKeys, nil); err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, apperrors.Validation(appconst.Apikeyname, nil)
+	}
+	scopes, ok := apiScopes(request.Scopes)
+	if !ok {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Apikeyscopes, strings.Join(models.APIScopes, ", ")), nil)
+	}
+	// Anyone can manage their own keys, but only admins can hand the admin
+	// scope on
+	for _, scope := range scopes {
+		if scope == models.ScopeAdmin && actor.Role != models.RoleAdmin {
+			return nil, apperrors.Forbidden(appconst.Adminscope, nil)
+		}
+	}
+
+	key, prefix, hash, err := auth.NewAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	stored := &models.APIKey{
+		UserID:  actor.UserID,
+		Name:    name,
+		Prefix:  prefix,
+		KeyHash: hash,
+		Scopes:  scopes,
+	}
+	if err := s.repo.CreateAPIKey(stored); err != nil {
+		return nil, err
+	}
+
+	stored.Key = key
+	return stored, nil
+}
+
+// ListAPIKeys returns the API keys the actor has issued, revoked ones
+// included.
+func (s *UserService) ListAPIKeys(actor models.Principal) ([]models.APIKey, error) {
+	if err := policy.Authorize(actor, policy.ManageAPIKeys, nil); err != nil {
+		return nil, err
+	}
+
+	return s.repo.APIKeys(actor.UserID)
+}
+
+// RevokeAPIKey revokes an API key for good. Users revoke their own keys,
+// admins any key; the keys of others are not found for everybody else.
+func (s *UserService) RevokeAPIKey(id int, actor models.Principal) error {
+	if err := policy.Authorize(actor, policy.ManageAPIKeys, nil); err != nil {
+		return err
+	}
+
+	key, err := s.repo.OneAPIKey(id)
+	if err != nil {
+		return err
+	}
+	if key.UserID != actor.UserID && !policy.Can(actor, policy.ManageUsers, nil) {
+		return apperrors.NotFound(appconst.Noapikey, nil)
+	}
+
+	return s.repo.RevokeAPIKey(id)
+}
+
+// VerifyAPIKey returns the principal a request authenticated with an API
+// key is made by, and records that the key was used. The principal has the
+// current role of the user who issued the key.
+func (s *UserService) VerifyAPIKey(key string) (models.Principal, error) {
+	if !auth.ValidAPIKey(key) {
+		return models.Principal{}, apperrors.Unauthorized(appconst.Invalidapikey, nil)
+	}
+
+	stored, err := s.repo.UseAPIKey(auth.HashAPIKey(key))
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return models.Principal{}, apperrors.Unauthorized(appconst.Invalidapikey, nil)
+	}
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	user, err := s.repo.OneUser(stored.UserID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return models.Principal{}, apperrors.Unauthorized(appconst.Invalidapikey, nil)
+	}
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	return models.Principal{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+		Scopes:   stored.Scopes,
+	}, nil
+}
+
+// apiScopes returns the requested scopes without duplicates, in the order
+// of models.APIScopes. It reports false if none or an unknown scope is
+// requested.
+func apiScopes(requested []string) ([]string, bool) {
+	wanted := map[string]bool{}
+	for _, scope := range requested {
+		wanted[scope] = true
+	}
+
+	scopes := []string{}
+	for _, scope := range models.APIScopes {
+		if wanted[scope] {
+			scopes = append(scopes, scope)
+			delete(wanted, scope)
+		}
+	}
+	return scopes, len(scopes) > 0 && len(wanted) == 0
+}
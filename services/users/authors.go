@@ -0,0 +1,33 @@
+package users
+
+import "backend/pkg/models"
+
+// GetAuthors returns the users with ids as anyone reading their articles
+// sees them, without their email address. IDs without a user are left out.
+func (s *UserService) GetAuthors(ids []int) ([]models.User, error) {
+	users, err := s.repo.UsersByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		author(&users[i])
+	}
+	return users, nil
+}
+
+// GetAuthor returns the user with a username, ignoring case, as anyone
+// reading their articles sees them.
+func (s *UserService) GetAuthor(username string) (*models.User, error) {
+	user, err := s.repo.UserByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	author(user)
+	return user, nil
+}
+
+// author hides what only the user and admins may see of an account.
+func author(user *models.User) {
+	user.Email = ""
+	user.PasswordHash = ""
+}
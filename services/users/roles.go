@@ -0,0 +1,46 @@
+package users
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/models"
+	"backend/pkg/policy"
+	"fmt"
+	"strings"
+)
+
+// ListUsers returns a page of users for an admin.
+func (s *UserService) ListUsers(params models.ListParams, actor models.Principal) (*models.UserPage, error) {
+	if err := policy.Authorize(actor, policy.ManageUsers, nil); err != nil {
+		return nil, err
+	}
+
+	params.Normalize()
+	return s.repo.AllUsers(params)
+}
+
+// SetRole gives the user with the given id another role. Admins cannot
+// change their own role, so there is always an admin left to undo a
+// change. The new role applies to access tokens issued from then on.
+func (s *UserService) SetRole(id int, role string, actor models.Principal) (*models.User, error) {
+	if err := policy.Authorize(actor, policy.ManageUsers, nil); err != nil {
+		return nil, err
+	}
+	if !validRole(role) {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Invalidrole, strings.Join(models.UserRoles, ", ")), nil)
+	}
+	if id == actor.UserID {
+		return nil, apperrors.Conflict(appconst.Ownrole, nil)
+	}
+
+	return s.repo.SetUserRole(id, role)
+}
+
+func validRole(role string) bool {
+	for _, r := range models.UserRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package users
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"errors"
+)
+
+// Refresh exchanges a refresh token for a new session. Refresh tokens are
+// rotated: each one works once and is replaced by a new token of the same
+// family. A token presented a second time means it was copied, so the
+// whole family is revoked and its owner has to sign in again.
+func (s *UserService) Refresh(refreshToken string) (*models.Session, error) {
+	hash := auth.HashRefreshToken(refreshToken)
+
+	token, err := s.repo.UseRefreshToken(hash)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		if err := s.repo.RevokeRefreshTokens(hash); err != nil {
+			return nil, err
+		}
+		return nil, apperrors.Unauthorized(appconst.Invalidrefresh, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.OneUser(token.UserID)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		return nil, apperrors.Unauthorized(appconst.Invalidrefresh, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.newSession(user, token.Family)
+}
+
+// Logout revokes a refresh token together with every token it was rotated
+// from or into. Access tokens already issued stay valid until they expire.
+func (s *UserService) Logout(refreshToken string) error {
+	return s.repo.RevokeRefreshTokens(auth.HashRefreshToken(refreshToken))
+}
+
+// newSession issues an access token and a refresh token of the given
+// family for the user.
+func (s *UserService) newSession(user *models.User, family string) (*models.Session, error) {
+	accessToken, expires, err := s.tokens.Issue(user)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	stored := &models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		Family:    family,
+		ExpiresAt: s.tokens.RefreshExpiry(),
+	}
+	if err := s.repo.CreateRefreshToken(stored); err != nil {
+		return nil, err
+	}
+
+	return &models.Session{
+		AccessToken:      accessToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        expires,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
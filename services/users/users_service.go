@@ -0,0 +1,118 @@
+package users
+
+import (
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/password"
+	"backend/pkg/repository/dbrepo"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Password length limits, in characters
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 256
+)
+
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{3,32}$`)
+
+type UserServices interface {
+	Register(registration models.Registration) (*models.User, error)
+	Login(credentials models.Credentials) (*models.Session, error)
+	Refresh(refreshToken string) (*models.Session, error)
+	Logout(refreshToken string) error
+	ListUsers(params models.ListParams, actor models.Principal) (*models.UserPage, error)
+	SetRole(id int, role string, actor models.Principal) (*models.User, error)
+	GetAuthors(ids []int) ([]models.User, error)
+	GetAuthor(username string) (*models.User, error)
+	CreateAPIKey(request models.APIKeyRequest, actor models.Principal) (*models.APIKey, error)
+	ListAPIKeys(actor models.Principal) ([]models.APIKey, error)
+	RevokeAPIKey(id int, actor models.Principal) error
+	VerifyAPIKey(key string) (models.Principal, error)
+}
+
+type UserService struct {
+	repo   dbrepo.UserRepo
+	tokens *auth.Tokens
+	// dummyHash is verified against when a username is unknown, so a failed
+	// login takes as long whether or not the user exists
+	dummyHash string
+}
+
+func NewUserService(repo dbrepo.UserRepo, tokens *auth.Tokens) *UserService {
+	dummyHash, _ := password.Hash("")
+	return &UserService{
+		repo:      repo,
+		tokens:    tokens,
+		dummyHash: dummyHash,
+	}
+}
+
+// Register creates a user account with a hashed password.
+func (s *UserService) Register(registration models.Registration) (*models.User, error) {
+	if !usernamePattern.MatchString(registration.Username) {
+		return nil, apperrors.Validation(appconst.Invalidusername, nil)
+	}
+	address, err := mail.ParseAddress(registration.Email)
+	if err != nil || address.Address != strings.TrimSpace(registration.Email) {
+		return nil, apperrors.Validation(appconst.Invalidemail, err)
+	}
+	length := utf8.RuneCountInString(registration.Password)
+	if length < MinPasswordLength {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Shortpassword, MinPasswordLength), nil)
+	}
+	if length > MaxPasswordLength {
+		return nil, apperrors.Validation(fmt.Sprintf(appconst.Longpassword, MaxPasswordLength), nil)
+	}
+
+	hash, err := password.Hash(registration.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		Username:     registration.Username,
+		Email:        address.Address,
+		PasswordHash: hash,
+	}
+	if _, err := s.repo.CreateUser(user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Login starts a session for the user whose username and password match.
+// Unknown users and wrong passwords are reported alike so usernames cannot
+// be probed.
+func (s *UserService) Login(credentials models.Credentials) (*models.Session, error) {
+	user, err := s.repo.UserByUsername(credentials.Username)
+	if errors.Is(err, apperrors.ErrNotFound) {
+		password.Verify(credentials.Password, s.dummyHash)
+		return nil, apperrors.Unauthorized(appconst.Badcredentials, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := password.Verify(credentials.Password, user.PasswordHash); err != nil {
+		return nil, apperrors.Unauthorized(appconst.Badcredentials, err)
+	}
+
+	family, err := auth.NewFamily()
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.newSession(user, family)
+	if err != nil {
+		return nil, err
+	}
+	session.User = user
+	return session, nil
+}
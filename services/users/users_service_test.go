@@ -0,0 +1,394 @@
+package users
+
+import (
+	"backend/mocks"
+	appconst "backend/pkg/appconstant"
+	"backend/pkg/apperrors"
+	"backend/pkg/auth"
+	"backend/pkg/models"
+	"backend/pkg/password"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_Register(t *testing.T) {
+	testCases := []struct {
+		description     string
+		registration    models.Registration
+		expectedMessage string // empty when the registration is valid
+	}{
+		{
+			description:  "Valid registration",
+			registration: models.Registration{Username: "ada.lovelace", Email: "ada@example.com", Password: "analytical engine"},
+		},
+		{
+			description:     "Username too short",
+			registration:    models.Registration{Username: "ad", Email: "ada@example.com", Password: "analytical engine"},
+			expectedMessage: appconst.Invalidusername,
+		},
+		{
+			description:     "Username with spaces",
+			registration:    models.Registration{Username: "ada lovelace", Email: "ada@example.com", Password: "analytical engine"},
+			expectedMessage: appconst.Invalidusername,
+		},
+		{
+			description:     "Email with a display name",
+			registration:    models.Registration{Username: "ada", Email: "Ada <ada@example.com>", Password: "analytical engine"},
+			expectedMessage: appconst.Invalidemail,
+		},
+		{
+			description:     "Password too short",
+			registration:    models.Registration{Username: "ada", Email: "ada@example.com", Password: "engine"},
+			expectedMessage: "password must be at least 8 characters",
+		},
+		{
+			description:     "Password too long",
+			registration:    models.Registration{Username: "ada", Email: "ada@example.com", Password: strings.Repeat("x", MaxPasswordLength+1)},
+			expectedMessage: "password must be at most 256 characters",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := &UserService{repo: mockDB}
+
+			if testCase.expectedMessage == "" {
+				mockDB.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user *models.User) (int, error) {
+					user.ID = 1
+					return 1, nil
+				})
+			}
+
+			user, err := service.Register(testCase.registration)
+
+			if testCase.expectedMessage != "" {
+				assert.ErrorIs(t, err, apperrors.ErrValidation)
+				message, _ := apperrors.Message(err)
+				assert.Equal(t, testCase.expectedMessage, message)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 1, user.ID)
+			// Only the hash of the password is stored
+			assert.NotContains(t, user.PasswordHash, testCase.registration.Password)
+			assert.NoError(t, password.Verify(testCase.registration.Password, user.PasswordHash))
+		})
+	}
+}
+
+// testTokens signs access tokens for the tests
+func testTokens(t *testing.T) *auth.Tokens {
+	tokens, err := auth.NewTokens(auth.Config{Algorithm: auth.HS256, Secret: []byte("test secret"), Issuer: "test", AccessTTL: time.Minute, RefreshTTL: time.Hour})
+	assert.NoError(t, err)
+	return tokens
+}
+
+func TestUserService_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	tokens := testTokens(t)
+	service := NewUserService(mockDB, tokens)
+
+	hash, _ := password.Hash("analytical engine")
+	mockDB.EXPECT().UserByUsername("ada").Return(&models.User{ID: 1, Username: "ada", Role: models.RoleEditor, PasswordHash: hash}, nil).Times(2)
+	mockDB.EXPECT().UserByUsername("bob").Return(nil, apperrors.NotFound(appconst.Nouser, sql.ErrNoRows))
+
+	var stored *models.RefreshToken
+	mockDB.EXPECT().CreateRefreshToken(gomock.Any()).DoAndReturn(func(token *models.RefreshToken) error {
+		stored = token
+		return nil
+	})
+
+	session, err := service.Login(models.Credentials{Username: "ada", Password: "analytical engine"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, session.User.ID)
+	assert.Equal(t, "Bearer", session.TokenType)
+
+	principal, err := tokens.Verify(session.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Principal{UserID: 1, Username: "ada", Role: models.RoleEditor}, principal)
+
+	// Only the hash of the refresh token is stored
+	assert.Equal(t, 1, stored.UserID)
+	assert.Equal(t, auth.HashRefreshToken(session.RefreshToken), stored.TokenHash)
+	assert.NotEmpty(t, stored.Family)
+
+	// A wrong password and an unknown user fail the same way
+	_, err = service.Login(models.Credentials{Username: "ada", Password: "difference engine"})
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	_, err = service.Login(models.Credentials{Username: "bob", Password: "analytical engine"})
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	assert.NotErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestUserService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewUserService(mockDB, testTokens(t))
+
+	hash := auth.HashRefreshToken("first")
+	gomock.InOrder(
+		// The token is rotated into a new one of the same family
+		mockDB.EXPECT().UseRefreshToken(hash).Return(&models.RefreshToken{ID: 1, UserID: 1, TokenHash: hash, Family: "family"}, nil),
+		mockDB.EXPECT().OneUser(1).Return(&models.User{ID: 1, Username: "ada"}, nil),
+		mockDB.EXPECT().CreateRefreshToken(gomock.Any()).DoAndReturn(func(token *models.RefreshToken) error {
+			assert.Equal(t, "family", token.Family)
+			assert.NotEqual(t, hash, token.TokenHash)
+			return nil
+		}),
+		// Using it again revokes the family
+		mockDB.EXPECT().UseRefreshToken(hash).Return(nil, apperrors.NotFound(appconst.Invalidrefresh, sql.ErrNoRows)),
+		mockDB.EXPECT().RevokeRefreshTokens(hash).Return(nil),
+	)
+
+	session, err := service.Refresh("first")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "first", session.RefreshToken)
+	assert.Nil(t, session.User)
+
+	_, err = service.Refresh("first")
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+	assert.NotErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestUserService_Logout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewUserService(mockDB, testTokens(t))
+
+	mockDB.EXPECT().RevokeRefreshTokens(auth.HashRefreshToken("token")).Return(nil)
+
+	assert.NoError(t, service.Logout("token"))
+}
+
+func TestUserService_SetRole(t *testing.T) {
+	admin := models.Principal{UserID: 1, Username: "root", Role: models.RoleAdmin}
+	editor := models.Principal{UserID: 2, Username: "grace", Role: models.RoleEditor}
+
+	testCases := []struct {
+		description string
+		userID      int
+		role        string
+		actor       models.Principal
+		expectSave  bool
+		expectedErr error
+	}{
+		{description: "Admin promotes a user", userID: 3, role: models.RoleEditor, actor: admin, expectSave: true},
+		{description: "Anonymous", userID: 3, role: models.RoleEditor, expectedErr: apperrors.ErrUnauthorized},
+		{description: "Editor", userID: 3, role: models.RoleAdmin, actor: editor, expectedErr: apperrors.ErrForbidden},
+		{description: "Unknown role", userID: 3, role: "owner", actor: admin, expectedErr: apperrors.ErrValidation},
+		{description: "Own role", userID: 1, role: models.RoleAuthor, actor: admin, expectedErr: apperrors.ErrConflict},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewUserService(mockDB, testTokens(t))
+
+			if testCase.expectSave {
+				mockDB.EXPECT().SetUserRole(testCase.userID, testCase.role).Return(&models.User{ID: testCase.userID, Role: testCase.role}, nil)
+			}
+
+			user, err := service.SetRole(testCase.userID, testCase.role, testCase.actor)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.role, user.Role)
+		})
+	}
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewUserService(mockDB, testTokens(t))
+
+	mockDB.EXPECT().AllUsers(models.ListParams{Limit: models.DefaultPageSize, Sort: models.Sort{Field: models.SortTitle}}).Return(&models.UserPage{Users: []models.User{{ID: 1}}}, nil)
+
+	page, err := service.ListUsers(models.ListParams{}, models.Principal{UserID: 1, Username: "root", Role: models.RoleAdmin})
+	assert.NoError(t, err)
+	assert.Len(t, page.Users, 1)
+
+	_, err = service.ListUsers(models.ListParams{}, models.Principal{UserID: 2, Username: "grace", Role: models.RoleEditor})
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+}
+
+func TestUserService_GetAuthors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewUserService(mockDB, testTokens(t))
+
+	// Anyone may see who wrote an article, but not their email address
+	mockDB.EXPECT().UsersByIDs([]int{1, 2}).Return([]models.User{
+		{ID: 1, Username: "ada", Email: "ada@example.com", PasswordHash: "hash", Role: models.RoleEditor},
+		{ID: 2, Username: "grace", Email: "grace@example.com", PasswordHash: "hash"},
+	}, nil)
+	mockDB.EXPECT().UserByUsername("Ada").Return(&models.User{ID: 1, Username: "ada", Email: "ada@example.com", PasswordHash: "hash"}, nil)
+
+	authors, err := service.GetAuthors([]int{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.User{{ID: 1, Username: "ada", Role: models.RoleEditor}, {ID: 2, Username: "grace"}}, authors)
+
+	author, err := service.GetAuthor("Ada")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.User{ID: 1, Username: "ada"}, author)
+}
+
+func TestUserService_CreateAPIKey(t *testing.T) {
+	admin := models.Principal{UserID: 1, Username: "root", Role: models.RoleAdmin}
+	author := models.Principal{UserID: 3, Username: "ada", Role: models.RoleAuthor}
+
+	testCases := []struct {
+		description    string
+		request        models.APIKeyRequest
+		actor          models.Principal
+		expectedScopes []string // nil when the key is not issued
+		expectedErr    error
+	}{
+		{
+			description:    "Author issues a write key",
+			request:        models.APIKeyRequest{Name: "ci", Scopes: []string{models.ScopeArticlesWrite, models.ScopeArticlesRead, models.ScopeArticlesWrite}},
+			actor:          author,
+			expectedScopes: []string{models.ScopeArticlesRead, models.ScopeArticlesWrite},
+		},
+		{
+			description:    "Admin issues an admin key",
+			request:        models.APIKeyRequest{Name: "ops", Scopes: []string{models.ScopeAdmin}},
+			actor:          admin,
+			expectedScopes: []string{models.ScopeAdmin},
+		},
+		{
+			description: "Anonymous",
+			request:     models.APIKeyRequest{Name: "ci", Scopes: []string{models.ScopeArticlesRead}},
+			expectedErr: apperrors.ErrUnauthorized,
+		},
+		{
+			description: "Key without the admin scope",
+			request:     models.APIKeyRequest{Name: "ci", Scopes: []string{models.ScopeArticlesRead}},
+			actor:       models.Principal{UserID: 3, Username: "ada", Role: models.RoleAuthor, Scopes: []string{models.ScopeArticlesWrite}},
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "Author asks for the admin scope",
+			request:     models.APIKeyRequest{Name: "ci", Scopes: []string{models.ScopeAdmin}},
+			actor:       author,
+			expectedErr: apperrors.ErrForbidden,
+		},
+		{
+			description: "No name",
+			request:     models.APIKeyRequest{Name: " ", Scopes: []string{models.ScopeArticlesRead}},
+			actor:       author,
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "No scopes",
+			request:     models.APIKeyRequest{Name: "ci"},
+			actor:       author,
+			expectedErr: apperrors.ErrValidation,
+		},
+		{
+			description: "Unknown scope",
+			request:     models.APIKeyRequest{Name: "ci", Scopes: []string{models.ScopeArticlesRead, "users:write"}},
+			actor:       author,
+			expectedErr: apperrors.ErrValidation,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.description, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDBInterface(ctrl)
+			service := NewUserService(mockDB, testTokens(t))
+
+			var stored *models.APIKey
+			if testCase.expectedScopes != nil {
+				mockDB.EXPECT().CreateAPIKey(gomock.Any()).DoAndReturn(func(key *models.APIKey) error {
+					key.ID = 7
+					stored = key
+					return nil
+				})
+			}
+
+			key, err := service.CreateAPIKey(testCase.request, testCase.actor)
+
+			if testCase.expectedErr != nil {
+				assert.ErrorIs(t, err, testCase.expectedErr)
+				assert.Nil(t, key)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.actor.UserID, key.UserID)
+			assert.Equal(t, testCase.expectedScopes, key.Scopes)
+			assert.True(t, strings.HasPrefix(key.Key, key.Prefix+"_"))
+			// Only the hash of the key is stored
+			assert.Equal(t, auth.HashAPIKey(key.Key), stored.KeyHash)
+		})
+	}
+}
+
+func TestUserService_RevokeAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewUserService(mockDB, testTokens(t))
+
+	author := models.Principal{UserID: 3, Username: "ada", Role: models.RoleAuthor}
+	other := models.Principal{UserID: 4, Username: "grace", Role: models.RoleEditor}
+	admin := models.Principal{UserID: 1, Username: "root", Role: models.RoleAdmin}
+
+	mockDB.EXPECT().OneAPIKey(7).Return(&models.APIKey{ID: 7, UserID: 3}, nil).Times(3)
+	mockDB.EXPECT().RevokeAPIKey(7).Return(nil).Times(2)
+
+	assert.NoError(t, service.RevokeAPIKey(7, author))
+	// The keys of other users are not found, except by admins
+	assert.ErrorIs(t, service.RevokeAPIKey(7, other), apperrors.ErrNotFound)
+	assert.NoError(t, service.RevokeAPIKey(7, admin))
+	assert.ErrorIs(t, service.RevokeAPIKey(7, models.Principal{}), apperrors.ErrUnauthorized)
+}
+
+func TestUserService_VerifyAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDBInterface(ctrl)
+	service := NewUserService(mockDB, testTokens(t))
+
+	key, _, hash, err := auth.NewAPIKey()
+	assert.NoError(t, err)
+	revoked, _, revokedHash, err := auth.NewAPIKey()
+	assert.NoError(t, err)
+
+	mockDB.EXPECT().UseAPIKey(hash).Return(&models.APIKey{ID: 7, UserID: 3, Scopes: []string{models.ScopeArticlesRead}}, nil)
+	mockDB.EXPECT().OneUser(3).Return(&models.User{ID: 3, Username: "ada", Role: models.RoleEditor}, nil)
+	mockDB.EXPECT().UseAPIKey(revokedHash).Return(nil, apperrors.NotFound(appconst.Noapikey, sql.ErrNoRows))
+
+	principal, err := service.VerifyAPIKey(key)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Principal{UserID: 3, Username: "ada", Role: models.RoleEditor, Scopes: []string{models.ScopeArticlesRead}}, principal)
+
+	_, err = service.VerifyAPIKey(revoked)
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+
+	// Malformed keys are turned away without a lookup
+	_, err = service.VerifyAPIKey("not a key")
+	assert.ErrorIs(t, err, apperrors.ErrUnauthorized)
+}